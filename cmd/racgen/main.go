@@ -0,0 +1,227 @@
+// Command racgen generates UnmarshalRAC methods for structs with rac tags of a package,
+// so records of rac output are decoded without reflection. Run by go generate in internal/entity.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const _key = "rac"
+
+var ErrUnsupportedType = errors.New("unsupported field type")
+
+// field - struct field filled from rac key.
+type field struct {
+	Name string
+	Kind string // string, int, bool or time
+}
+
+// record - struct with rac tags, fields are grouped by key in order of first appearance.
+type record struct {
+	Name   string
+	Keys   []string
+	Fields map[string][]field
+}
+
+func main() {
+	var (
+		dir    string
+		output string
+	)
+
+	flag.StringVar(&dir, "dir", ".", "package directory")
+	flag.StringVar(&output, "output", "rac_gen.go", "generated file name, relative to dir")
+	flag.Parse()
+
+	src, err := generate(dir, output)
+	if err != nil {
+		log.Fatalf("racgen - generate: %s", err)
+	}
+
+	if err = os.WriteFile(filepath.Join(dir, output), src, 0o644); err != nil { //nolint:gosec // source file
+		log.Fatalf("racgen - os.WriteFile: %s", err)
+	}
+}
+
+// generate - source of UnmarshalRAC methods for package in dir, output file itself is skipped.
+func generate(dir, output string) ([]byte, error) {
+	fset := token.NewFileSet()
+
+	pkgs, err := parser.ParseDir(fset, dir, func(fi fs.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != output
+	}, 0)
+	if err != nil {
+		return nil, fmt.Errorf("parser.ParseDir: %w", err)
+	}
+
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected single package in %s, got %d", dir, len(pkgs))
+	}
+
+	var (
+		pkgName string
+		records []record
+	)
+
+	for name, pkg := range pkgs {
+		pkgName = name
+
+		files := make([]string, 0, len(pkg.Files))
+		for path := range pkg.Files {
+			files = append(files, path)
+		}
+
+		sort.Strings(files)
+
+		for _, path := range files {
+			rs, err := collect(pkg.Files[path])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+
+			records = append(records, rs...)
+		}
+	}
+
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "// Code generated by racgen. DO NOT EDIT.\n\npackage %s\n\n", pkgName)
+
+	for _, r := range records {
+		writeRecord(&b, r)
+	}
+
+	b.WriteString("var (\n")
+
+	for _, r := range records {
+		fmt.Fprintf(&b, "_ RACUnmarshaler = (*%s)(nil)\n", r.Name)
+	}
+
+	b.WriteString(")\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format.Source: %w", err)
+	}
+
+	return src, nil
+}
+
+// collect - structs of file having at least one rac tag.
+func collect(f *ast.File) ([]record, error) {
+	var rv []record
+
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range gd.Specs {
+			ts := spec.(*ast.TypeSpec) //nolint:forcetypeassert // type declaration
+			st, ok := ts.Type.(*ast.StructType)
+
+			if !ok {
+				continue
+			}
+
+			r := record{Name: ts.Name.Name, Fields: make(map[string][]field)}
+
+			for _, f := range st.Fields.List {
+				if f.Tag == nil {
+					continue
+				}
+
+				tag, err := strconv.Unquote(f.Tag.Value)
+				if err != nil {
+					return nil, err
+				}
+
+				key, ok := reflect.StructTag(tag).Lookup(_key)
+				if !ok {
+					continue
+				}
+
+				kind, err := kindOf(f.Type)
+				if err != nil {
+					return nil, fmt.Errorf("%s.%s: %w", r.Name, f.Names[0].Name, err)
+				}
+
+				if _, seen := r.Fields[key]; !seen {
+					r.Keys = append(r.Keys, key)
+				}
+
+				for _, n := range f.Names {
+					r.Fields[key] = append(r.Fields[key], field{Name: n.Name, Kind: kind})
+				}
+			}
+
+			if len(r.Keys) > 0 {
+				rv = append(rv, r)
+			}
+		}
+	}
+
+	return rv, nil
+}
+
+func kindOf(expr ast.Expr) (string, error) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		switch t.Name {
+		case "string", "int", "bool":
+			return t.Name, nil
+		}
+	case *ast.SelectorExpr:
+		if x, ok := t.X.(*ast.Ident); ok && x.Name == "time" && t.Sel.Name == "Time" {
+			return "time", nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %T", ErrUnsupportedType, expr)
+}
+
+// writeRecord - same semantics as reflection based Unmarshal: unparsable values are skipped,
+// zero record is not found.
+func writeRecord(b *bytes.Buffer, r record) {
+	fmt.Fprintf(b, "// UnmarshalRAC - decodes record of rac output into %s.\n", r.Name)
+	fmt.Fprintf(b, "func (v *%s) UnmarshalRAC(lines []string) error {\n", r.Name)
+	b.WriteString("for _, line := range lines {\n")
+	b.WriteString("key, value, err := GetKeyValue(line, ':')\nif err != nil {\ncontinue\n}\n\n")
+	b.WriteString("switch key {\n")
+
+	for _, key := range r.Keys {
+		fmt.Fprintf(b, "case %q:\n", key)
+
+		for _, f := range r.Fields[key] {
+			switch f.Kind {
+			case "string":
+				fmt.Fprintf(b, "v.%s = value\n", f.Name)
+			case "bool":
+				fmt.Fprintf(b, "v.%s = parseBool(value)\n", f.Name)
+			case "int":
+				fmt.Fprintf(b, "if x, ok := parseInt(value); ok {\nv.%s = x\n}\n", f.Name)
+			case "time":
+				fmt.Fprintf(b, "if x, ok := parseTime(value); ok {\nv.%s = x\n}\n", f.Name)
+			}
+		}
+	}
+
+	b.WriteString("}\n}\n\n")
+	fmt.Fprintf(b, "if *v == (%s{}) {\nreturn ErrNotFound\n}\n\nreturn nil\n}\n\n", r.Name)
+}
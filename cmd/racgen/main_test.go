@@ -0,0 +1,35 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerateUpToDate - rac_gen.go of entity must be regenerated after changes of rac tags.
+func TestGenerateUpToDate(t *testing.T) {
+	t.Parallel()
+
+	const dir = "../../internal/entity"
+
+	got, err := generate(dir, "rac_gen.go")
+	require.NoError(t, err)
+
+	want, err := os.ReadFile(dir + "/rac_gen.go")
+	require.NoError(t, err)
+
+	require.Equal(t, string(want), string(got), "run go generate ./internal/entity")
+}
+
+func TestGenerateUnsupportedType(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	src := "package x\n\ntype Bad struct {\n\tF float64 `rac:\"f\"`\n}\n"
+	require.NoError(t, os.WriteFile(dir+"/x.go", []byte(src), 0o644))
+
+	_, err := generate(dir, "rac_gen.go")
+	require.ErrorIs(t, err, ErrUnsupportedType)
+}
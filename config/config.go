@@ -1,8 +1,12 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 	"gopkg.in/yaml.v3"
@@ -10,8 +14,28 @@ import (
 
 // Config -.
 type Config struct {
-	App `yaml:"app"`
-	Log `yaml:"logger"`
+	App      `yaml:"app"`
+	Agent    `yaml:"agent"`
+	Log      `yaml:"logger"`
+	Lock     `yaml:"lock"`
+	Graceful `yaml:"graceful"`
+	Drain    `yaml:"drain"`
+	Retry    `yaml:"retry"`
+
+	Credentials `yaml:"credentials"`
+	Retention   `yaml:"retention"`
+	Hooks       `yaml:"hooks"`
+
+	Clusters map[string]Cluster `yaml:"clusters"`
+	Profiles map[string]Profile `yaml:"profiles"`
+
+	// secrets - values resolved of secret://name references
+	secrets []string
+}
+
+// Secrets - values of config taken from encrypted secrets file, e.g. to redact them from logs.
+func (c *Config) Secrets() []string {
+	return c.secrets
 }
 
 // App -.
@@ -20,6 +44,307 @@ type App struct {
 	PathTo1C  string `env-required:"true" yaml:"path_to_1cs" env:"PATH_TO_1C"`
 
 	LockCode string `env-required:"true" yaml:"lock_code"`
+
+	// Engine - how cluster is administered: rac executable or native RAS protocol client
+	Engine string `yaml:"engine" env:"ENGINE" env-default:"rac"`
+	// ExperimentalRAS - engine ras is accepted only if set, it is read only until its exchanges are verified
+	ExperimentalRAS bool `yaml:"experimental_ras" env:"EXPERIMENTAL_RAS"`
+
+	// ServerTimeZone - IANA time zone of cluster servers, rac and RAS give their time without zone, local if empty
+	ServerTimeZone string `yaml:"server_time_zone" env:"SERVER_TIME_ZONE"`
+
+	// Encrypted secrets referred by secret://name values, key is taken from env SECRETS_KEY or key file
+	SecretsPath    string `yaml:"secrets_path"      env:"SECRETS_PATH" env-default:"secrets.enc"`
+	SecretsKeyFile string `yaml:"secrets_key_file"  env:"SECRETS_KEY_FILE"`
+}
+
+// ServerLocation - time zone of cluster servers, local one if not set.
+func (a App) ServerLocation() (*time.Location, error) {
+	if a.ServerTimeZone == "" {
+		return time.Local, nil
+	}
+
+	loc, err := time.LoadLocation(a.ServerTimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("config - ServerLocation - time.LoadLocation: %w", err)
+	}
+
+	return loc, nil
+}
+
+const (
+	EngineRAC = "rac"
+	EngineRAS = "ras"
+)
+
+// Agent - central server administrator, hardened agents require it even to list clusters -.
+type Agent struct {
+	Admin   string `yaml:"admin"     env:"AGENT_ADMIN"`
+	Pwd     string `yaml:"pwd"       env:"AGENT_PWD"`
+	PwdFrom string `yaml:"pwd_from"  env:"AGENT_PWD_FROM"` // source of Pwd, see Credentials
+}
+
+// Cluster - entry of clusters inventory, selected by alias -.
+type Cluster struct {
+	RAS        string `yaml:"ras"`  // RAS host:port
+	Name       string `yaml:"name"` // cluster host:port as shown by cluster list
+	AgentAdmin string `yaml:"agent_admin"`
+	AgentPwd   string `yaml:"agent_pwd"`
+	Admin      string `yaml:"admin"`
+	Pwd        string `yaml:"pwd"`
+	Output     string `yaml:"output"` // default directory for backups
+
+	// Sources of passwords, see Credentials
+	AgentPwdFrom string `yaml:"agent_pwd_from"`
+	PwdFrom      string `yaml:"pwd_from"`
+}
+
+// ErrUnknownCluster - alias is absent in clusters inventory -.
+var ErrUnknownCluster = errors.New("cluster is not found in inventory")
+
+// ClusterByAlias - inventory entry, agent credentials default to agent section.
+func (c *Config) ClusterByAlias(alias string) (Cluster, error) {
+	cl, ok := c.Clusters[alias]
+	if !ok {
+		return Cluster{}, fmt.Errorf("%w: %s", ErrUnknownCluster, alias)
+	}
+
+	if cl.AgentAdmin == "" {
+		cl.AgentAdmin, cl.AgentPwd, cl.AgentPwdFrom = c.Agent.Admin, c.Agent.Pwd, c.Agent.PwdFrom
+	}
+
+	return cl, nil
+}
+
+// Aliases - sorted aliases of clusters inventory.
+func (c *Config) Aliases() []string {
+	rv := make([]string, 0, len(c.Clusters))
+
+	for alias := range c.Clusters {
+		rv = append(rv, alias)
+	}
+
+	sort.Strings(rv)
+
+	return rv
+}
+
+// Profile - named backup target, replaces flags of backup, flags given explicitly win -.
+// Values of inventory entry given by Cluster are used for empty connection and cluster credentials.
+type Profile struct {
+	Cluster string `yaml:"cluster"` // alias of clusters inventory
+	RAS     string `yaml:"ras"`     // RAS host:port
+	Name    string `yaml:"name"`    // cluster host:port as shown by cluster list
+
+	Infobase string `yaml:"infobase"`
+
+	AgentAdmin      string `yaml:"agent_admin"`
+	AgentPwd        string `yaml:"agent_pwd"`
+	AgentPwdFrom    string `yaml:"agent_pwd_from"`
+	Admin           string `yaml:"admin"`
+	Pwd             string `yaml:"pwd"`
+	PwdFrom         string `yaml:"pwd_from"`
+	InfobaseUser    string `yaml:"infobase_user"`
+	InfobasePwd     string `yaml:"infobase_pwd"`
+	InfobasePwdFrom string `yaml:"infobase_pwd_from"`
+
+	Output   string `yaml:"output"`
+	LockCode string `yaml:"lock_code"`
+	Engine   string `yaml:"engine"`
+
+	// Retention and hooks of profile, empty values are taken from sections of config
+	Retention Retention `yaml:"retention"`
+	Hooks     Hooks     `yaml:"hooks"`
+}
+
+// ErrUnknownProfile - profile is absent in config -.
+var ErrUnknownProfile = errors.New("profile is not found in config")
+
+// ProfileByName - profile with config values applied: lock code, engine, retention and hooks.
+func (c *Config) ProfileByName(name string) (Profile, error) {
+	p, ok := c.Profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("%w: %s", ErrUnknownProfile, name)
+	}
+
+	if p.LockCode == "" {
+		p.LockCode = c.App.LockCode
+	}
+
+	if p.Engine == "" {
+		p.Engine = c.App.Engine
+	}
+
+	if p.Retention.Keep == 0 {
+		p.Retention.Keep = c.Retention.Keep
+	}
+
+	if p.Retention.MaxAge == 0 {
+		p.Retention.MaxAge = c.Retention.MaxAge
+	}
+
+	if p.Hooks.Before == "" {
+		p.Hooks.Before = c.Hooks.Before
+	}
+
+	if p.Hooks.After == "" {
+		p.Hooks.After = c.Hooks.After
+	}
+
+	return p, nil
+}
+
+// Retention - backups of infobase kept in output directory after successful backup, all if empty -.
+type Retention struct {
+	Keep   int           `yaml:"keep"`    // newest backups to keep
+	MaxAge time.Duration `yaml:"max_age"` // older backups are removed, the newest one is always kept
+}
+
+// Hooks - commands run around backup, split by spaces, no shell is involved -.
+// They get env BACKUP_INFOBASE, BACKUP_OUTPUT and, after backup, BACKUP_EXIT_CODE.
+type Hooks struct {
+	Before string `yaml:"before"` // failure cancels backup
+	After  string `yaml:"after"`  // run whatever backup ends with, failure fails successful backup
+}
+
+// Lock - denial of sessions while making a backup, Infobases overrides it by infobase name -.
+type Lock struct {
+	Message           string        `yaml:"message"              env-default:"База закрыта на создание резервной копии до {to}"`
+	ExpectedDuration  time.Duration `yaml:"expected_duration"    env-default:"45m"`
+	Margin            time.Duration `yaml:"margin"               env-default:"15m"`
+	KeepScheduledJobs bool          `yaml:"keep_scheduled_jobs"`
+
+	Infobases map[string]LockOverride `yaml:"infobases"`
+}
+
+// LockOverride - lock settings of single infobase, empty values are taken from Lock -.
+type LockOverride struct {
+	Message           string        `yaml:"message"`
+	ExpectedDuration  time.Duration `yaml:"expected_duration"`
+	Margin            time.Duration `yaml:"margin"`
+	KeepScheduledJobs *bool         `yaml:"keep_scheduled_jobs"`
+}
+
+// For - lock settings of infobase with its overrides applied, names are compared case-insensitively.
+func (l Lock) For(infobase string) Lock {
+	rv := l
+	rv.Infobases = nil
+
+	for name, o := range l.Infobases {
+		if !strings.EqualFold(name, infobase) {
+			continue
+		}
+
+		if o.Message != "" {
+			rv.Message = o.Message
+		}
+
+		if o.ExpectedDuration > 0 {
+			rv.ExpectedDuration = o.ExpectedDuration
+		}
+
+		if o.Margin > 0 {
+			rv.Margin = o.Margin
+		}
+
+		if o.KeepScheduledJobs != nil {
+			rv.KeepScheduledJobs = *o.KeepScheduledJobs
+		}
+	}
+
+	return rv
+}
+
+// Credentials - sources of passwords which are not given explicitly: env:VAR, file:PATH, prompt or cmd:COMMAND,
+// Infobases overrides it by infobase name -.
+type Credentials struct {
+	ClusterPwdFrom  string `yaml:"cluster_pwd_from"   env:"CLUSTER_PWD_FROM"`
+	InfobasePwdFrom string `yaml:"infobase_pwd_from"  env:"INFOBASE_PWD_FROM"`
+
+	Infobases map[string]InfobaseCredentials `yaml:"infobases"`
+}
+
+// InfobaseCredentials - source of password of single infobase -.
+type InfobaseCredentials struct {
+	PwdFrom string `yaml:"pwd_from"`
+}
+
+// For - credentials settings of infobase with its overrides applied, names are compared case-insensitively.
+func (c Credentials) For(infobase string) Credentials {
+	rv := c
+	rv.Infobases = nil
+
+	for name, o := range c.Infobases {
+		if strings.EqualFold(name, infobase) && o.PwdFrom != "" {
+			rv.InfobasePwdFrom = o.PwdFrom
+		}
+	}
+
+	return rv
+}
+
+// Graceful - sessions termination with warning and grace period -.
+type Graceful struct {
+	Enabled      bool          `yaml:"enabled"        env:"GRACEFUL_ENABLED"`
+	GracePeriod  time.Duration `yaml:"grace_period"   env-default:"5m"`
+	PollInterval time.Duration `yaml:"poll_interval"  env-default:"15s"`
+	HardDeadline time.Duration `yaml:"hard_deadline"  env-default:"15m"`
+	Message      string        `yaml:"message"        env-default:"База будет закрыта через {minutes} мин. для создания резервной копии, сохраните документы"`
+	ErrorMessage string        `yaml:"error_message"  env-default:"Сеанс завершен администратором для создания резервной копии"`
+}
+
+// Drain - waiting until infobase has no sessions before dump -.
+type Drain struct {
+	Timeout  time.Duration `yaml:"timeout"   env-default:"2m"`
+	Interval time.Duration `yaml:"interval"  env-default:"5s"`
+}
+
+// Retry - repeating rac calls failed by transient errors, e.g. RAS dropping connections under load,
+// Operations overrides it by operation type: read, update or terminate -.
+type Retry struct {
+	Attempts int           `yaml:"attempts"  env-default:"3"`
+	Initial  time.Duration `yaml:"initial"   env-default:"1s"`
+	Max      time.Duration `yaml:"max"       env-default:"15s"`
+	Deadline time.Duration `yaml:"deadline"  env-default:"1m"`
+
+	Operations map[string]RetryOverride `yaml:"operations"`
+}
+
+// RetryOverride - retry settings of single operation type, empty values are taken from Retry -.
+type RetryOverride struct {
+	Attempts int           `yaml:"attempts"`
+	Initial  time.Duration `yaml:"initial"`
+	Max      time.Duration `yaml:"max"`
+	Deadline time.Duration `yaml:"deadline"`
+}
+
+// For - retry settings of operation type with its overrides applied.
+func (r Retry) For(op string) Retry {
+	rv := r
+	rv.Operations = nil
+
+	o, ok := r.Operations[op]
+	if !ok {
+		return rv
+	}
+
+	if o.Attempts > 0 {
+		rv.Attempts = o.Attempts
+	}
+
+	if o.Initial > 0 {
+		rv.Initial = o.Initial
+	}
+
+	if o.Max > 0 {
+		rv.Max = o.Max
+	}
+
+	if o.Deadline > 0 {
+		rv.Deadline = o.Deadline
+	}
+
+	return rv
 }
 
 // Log -.
@@ -28,12 +353,18 @@ type Log struct {
 	Path  string `env-required:"true" yaml:"path"`
 }
 
-func New() (*Config, error) {
-	configPath := os.Getenv("CONFIG_PATH")
-	if configPath == "" {
-		configPath = "./config.yml"
+// configFile - config file, CONFIG_PATH or ./config.yml.
+func configFile() string {
+	if p := os.Getenv("CONFIG_PATH"); p != "" {
+		return p
 	}
 
+	return "./config.yml"
+}
+
+func New() (*Config, error) {
+	configPath := configFile()
+
 	cfg := &Config{}
 
 	err := cleanenv.ReadConfig(configPath, cfg)
@@ -41,19 +372,31 @@ func New() (*Config, error) {
 		return nil, fmt.Errorf("config error: %w", err)
 	}
 
+	err = checkKeys(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("config error: %w", err)
+	}
+
 	err = cleanenv.ReadEnv(cfg)
 	if err != nil {
 		return nil, err
 	}
 
+	err = resolveSecrets(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("config error: %w", err)
+	}
+
+	err = cfg.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("config error: %w", err)
+	}
+
 	return cfg, nil
 }
 
 func Prepare() error {
-	configPath := os.Getenv("CONFIG_PATH")
-	if configPath == "" {
-		configPath = "./config.yml"
-	}
+	configPath := configFile()
 
 	if _, err := os.Stat(configPath); err == nil {
 		return os.ErrExist
@@ -64,11 +407,41 @@ func Prepare() error {
 			PathToRAC: "path to rac file",
 			PathTo1C:  "path to 1c executable client",
 			LockCode:  "12345",
+			Engine:    EngineRAC,
 		},
+		Agent{},
 		Log{
 			Level: "debug",
 			Path:  "log.log",
 		},
+		Lock{
+			Message:          "База закрыта на создание резервной копии до {to}",
+			ExpectedDuration: 45 * time.Minute,
+			Margin:           15 * time.Minute,
+		},
+		Graceful{
+			GracePeriod:  5 * time.Minute,
+			PollInterval: 15 * time.Second,
+			HardDeadline: 15 * time.Minute,
+			Message:      "База будет закрыта через {minutes} мин. для создания резервной копии, сохраните документы",
+			ErrorMessage: "Сеанс завершен администратором для создания резервной копии",
+		},
+		Drain{
+			Timeout:  2 * time.Minute,
+			Interval: 5 * time.Second,
+		},
+		Retry{
+			Attempts: 3,
+			Initial:  time.Second,
+			Max:      15 * time.Second,
+			Deadline: time.Minute,
+		},
+		Credentials{},
+		Retention{},
+		Hooks{},
+		map[string]Cluster{},
+		map[string]Profile{},
+		nil,
 	}
 
 	yamlData, err := yaml.Marshal(&cfg)
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/ilyakaznacheev/cleanenv"
+
+	"github.com/antonmisa/1cctl_cli/pkg/secrets"
+)
+
+// SecretsLocation - secrets file and its key file of config, env overrides them as in New.
+// Only these keys are read, without validation and resolving secrets, so secrets can be managed
+// before config is complete. Defaults are returned if there is no config file.
+func SecretsLocation() (file, keyFile string, err error) {
+	// same keys as App
+	var cfg struct {
+		App struct {
+			SecretsPath    string `yaml:"secrets_path"      env:"SECRETS_PATH" env-default:"secrets.enc"`
+			SecretsKeyFile string `yaml:"secrets_key_file"  env:"SECRETS_KEY_FILE"`
+		} `yaml:"app"`
+	}
+
+	configPath := configFile()
+
+	_, err = os.Stat(configPath)
+
+	switch {
+	case err == nil:
+		err = cleanenv.ReadConfig(configPath, &cfg)
+	case errors.Is(err, os.ErrNotExist):
+		err = cleanenv.ReadEnv(&cfg)
+	}
+
+	if err != nil {
+		return "", "", fmt.Errorf("config error: %w", err)
+	}
+
+	return cfg.App.SecretsPath, cfg.App.SecretsKeyFile, nil
+}
+
+// resolveSecrets - replaces secret://name values of config with secrets of encrypted file,
+// file and key are required only if config has such values.
+func resolveSecrets(cfg *Config) error {
+	v := reflect.ValueOf(cfg).Elem()
+
+	var refs int
+
+	_ = walkStrings(v, func(s string) (string, error) {
+		if secrets.IsRef(s) {
+			refs++
+		}
+
+		return s, nil
+	})
+
+	if refs == 0 {
+		return nil
+	}
+
+	key, err := secrets.LoadKey(cfg.App.SecretsKeyFile)
+	if err != nil {
+		return fmt.Errorf("resolveSecrets - secrets.LoadKey: %w", err)
+	}
+
+	store, err := secrets.Open(cfg.App.SecretsPath, key)
+	if err != nil {
+		return fmt.Errorf("resolveSecrets - secrets.Open: %w", err)
+	}
+
+	return walkStrings(v, func(s string) (string, error) {
+		if !secrets.IsRef(s) {
+			return s, nil
+		}
+
+		v, err := store.Resolve(s)
+		if err != nil {
+			return "", err
+		}
+
+		cfg.secrets = append(cfg.secrets, v)
+
+		return v, nil
+	})
+}
+
+// walkStrings - replaces every string of v, including fields of nested structs and values of maps, by fn.
+func walkStrings(v reflect.Value, fn func(string) (string, error)) error {
+	switch v.Kind() { //nolint:exhaustive // other kinds have no strings
+	case reflect.String:
+		s, err := fn(v.String())
+		if err != nil {
+			return err
+		}
+
+		if v.CanSet() {
+			v.SetString(s)
+		}
+	case reflect.Pointer:
+		if !v.IsNil() {
+			return walkStrings(v.Elem(), fn)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !v.Type().Field(i).IsExported() {
+				continue
+			}
+
+			if err := walkStrings(v.Field(i), fn); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if err := walkStrings(v.Index(i), fn); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+
+		for iter.Next() {
+			// Map values are not addressable, copy is changed and put back
+			e := reflect.New(iter.Value().Type()).Elem()
+			e.Set(iter.Value())
+
+			if err := walkStrings(e, fn); err != nil {
+				return err
+			}
+
+			v.SetMapIndex(iter.Key(), e)
+		}
+	}
+
+	return nil
+}
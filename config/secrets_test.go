@@ -0,0 +1,128 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/antonmisa/1cctl_cli/pkg/secrets"
+)
+
+func TestResolveSecrets(t *testing.T) {
+	key, err := secrets.NewKey()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "secrets.enc")
+
+	s := secrets.New(path, key)
+	require.NoError(t, s.Set("prod/cluster_pwd", "admin-pwd"))
+	require.NoError(t, s.Set("agent_pwd", "agent-pwd"))
+	require.NoError(t, s.Save())
+
+	keyFile := filepath.Join(dir, "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(key.String()), 0o600))
+
+	t.Setenv(secrets.EnvKey, "")
+	t.Setenv(secrets.EnvKeyFile, "")
+
+	cfg := &Config{
+		App:   App{LockCode: "12345", SecretsPath: path, SecretsKeyFile: keyFile},
+		Agent: Agent{Admin: "agent", Pwd: "secret://agent_pwd"},
+		Clusters: map[string]Cluster{
+			"prod": {RAS: "srv:1545", Admin: "admin", Pwd: "secret://prod/cluster_pwd"},
+		},
+	}
+
+	require.NoError(t, resolveSecrets(cfg))
+	require.Equal(t, "agent-pwd", cfg.Agent.Pwd)
+	require.Equal(t, "admin-pwd", cfg.Clusters["prod"].Pwd)
+	require.Equal(t, "srv:1545", cfg.Clusters["prod"].RAS)
+	require.Equal(t, "12345", cfg.App.LockCode)
+	require.ElementsMatch(t, []string{"agent-pwd", "admin-pwd"}, cfg.Secrets())
+
+	cfg.Lock.Message = "secret://absent"
+	require.ErrorIs(t, resolveSecrets(cfg), secrets.ErrUnknownSecret)
+}
+
+func TestResolveSecretsNotUsed(t *testing.T) {
+	t.Setenv(secrets.EnvKey, "")
+	t.Setenv(secrets.EnvKeyFile, "")
+
+	cfg := &Config{
+		App:   App{SecretsPath: filepath.Join(t.TempDir(), "absent.enc")},
+		Agent: Agent{Admin: "agent", Pwd: "plain"},
+	}
+
+	require.NoError(t, resolveSecrets(cfg))
+	require.Equal(t, "plain", cfg.Agent.Pwd)
+
+	cfg.Agent.Pwd = "secret://agent_pwd"
+	require.ErrorIs(t, resolveSecrets(cfg), secrets.ErrNoKey)
+}
+
+func TestSecretsLocation(t *testing.T) {
+	cases := []struct {
+		name    string
+		yaml    string
+		env     string
+		file    string
+		keyFile string
+	}{
+		{
+			name: "No config",
+			file: "secrets.enc",
+		},
+		{
+			name: "Config",
+			yaml: `
+app:
+  lock_code: "12345"
+  secrets_path: /etc/1cctl/secrets.enc
+  secrets_key_file: /etc/1cctl/key
+`,
+			file:    "/etc/1cctl/secrets.enc",
+			keyFile: "/etc/1cctl/key",
+		},
+		{
+			name: "Env wins",
+			yaml: `
+app:
+  secrets_path: /etc/1cctl/secrets.enc
+`,
+			env:  "/tmp/secrets.enc",
+			file: "/tmp/secrets.enc",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yml")
+
+			if tc.yaml != "" {
+				require.NoError(t, os.WriteFile(path, []byte(tc.yaml), 0o600))
+			}
+
+			t.Setenv("CONFIG_PATH", path)
+			t.Setenv("SECRETS_PATH", tc.env)
+			t.Setenv(secrets.EnvKeyFile, "")
+
+			// set but empty env var overrides config
+			require.NoError(t, os.Unsetenv(secrets.EnvKeyFile))
+
+			if tc.env == "" {
+				require.NoError(t, os.Unsetenv("SECRETS_PATH"))
+			}
+
+			file, keyFile, err := SecretsLocation()
+
+			require.NoError(t, err)
+			require.Equal(t, tc.file, file)
+			require.Equal(t, tc.keyFile, keyFile)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrUnknownKeys  = errors.New("unknown keys")
+	ErrRequired     = errors.New("value is required")
+	ErrInvalid      = errors.New("invalid value")
+	ErrExperimental = errors.New("experimental, set app.experimental_ras to use it")
+)
+
+// checkKeys - keys of yaml config file which are unknown, e.g. misspelled ones, are reported with their lines.
+func checkKeys(path string) error {
+	if ext := strings.ToLower(filepath.Ext(path)); ext != ".yml" && ext != ".yaml" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("checkKeys - os.ReadFile: %w", err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	err = dec.Decode(&Config{})
+
+	var te *yaml.TypeError
+
+	if err == nil || errors.Is(err, io.EOF) || !errors.As(err, &te) {
+		// other failures are reported by reading config
+		return nil
+	}
+
+	var unknown []string
+
+	for _, e := range te.Errors {
+		if strings.Contains(e, "not found in type") {
+			unknown = append(unknown, e)
+		}
+	}
+
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrUnknownKeys, strings.Join(unknown, "; "))
+}
+
+// Validate - reports all missing and invalid values of config at once.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if !validEngine(c.App.Engine) {
+		errs = append(errs, fmt.Errorf("app.engine %q: %w", c.App.Engine, ErrInvalid))
+	}
+
+	if c.App.Engine == EngineRAS && !c.App.ExperimentalRAS {
+		errs = append(errs, fmt.Errorf("app.engine %q: %w", c.App.Engine, ErrExperimental))
+	}
+
+	if _, err := c.App.ServerLocation(); err != nil {
+		errs = append(errs, fmt.Errorf("app.server_time_zone %q: %w", c.App.ServerTimeZone, ErrInvalid))
+	}
+
+	for _, alias := range c.Aliases() {
+		if c.Clusters[alias].RAS == "" {
+			errs = append(errs, fmt.Errorf("clusters.%s.ras: %w", alias, ErrRequired))
+		}
+	}
+
+	names := make([]string, 0, len(c.Profiles))
+
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		errs = append(errs, c.validateProfile(name, c.Profiles[name])...)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Config) validateProfile(name string, p Profile) []error {
+	var errs []error
+
+	output := p.Output
+
+	if p.Cluster != "" {
+		cl, ok := c.Clusters[p.Cluster]
+		if !ok {
+			errs = append(errs, fmt.Errorf("profiles.%s.cluster %q: %w", name, p.Cluster, ErrUnknownCluster))
+		}
+
+		if output == "" {
+			output = cl.Output
+		}
+	}
+
+	if p.Infobase == "" {
+		errs = append(errs, fmt.Errorf("profiles.%s.infobase: %w", name, ErrRequired))
+	}
+
+	if output == "" {
+		errs = append(errs, fmt.Errorf("profiles.%s.output: %w", name, ErrRequired))
+	}
+
+	if p.Engine != "" && !validEngine(p.Engine) {
+		errs = append(errs, fmt.Errorf("profiles.%s.engine %q: %w", name, p.Engine, ErrInvalid))
+	}
+
+	if p.Engine == EngineRAS && !c.App.ExperimentalRAS {
+		errs = append(errs, fmt.Errorf("profiles.%s.engine %q: %w", name, p.Engine, ErrExperimental))
+	}
+
+	if p.Retention.Keep < 0 {
+		errs = append(errs, fmt.Errorf("profiles.%s.retention.keep %d: %w", name, p.Retention.Keep, ErrInvalid))
+	}
+
+	if p.Retention.MaxAge < 0 {
+		errs = append(errs, fmt.Errorf("profiles.%s.retention.max_age %s: %w", name, p.Retention.MaxAge, ErrInvalid))
+	}
+
+	return errs
+}
+
+func validEngine(engine string) bool {
+	return engine == "" || engine == EngineRAC || engine == EngineRAS
+}
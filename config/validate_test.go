@@ -0,0 +1,117 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const _validConfig = `
+app:
+  path_to_rac: rac
+  path_to_1cs: 1cv8
+  lock_code: "12345"
+logger:
+  level: info
+  path: log.log
+retention:
+  keep: 7
+clusters:
+  prod:
+    ras: srv-1c:1545
+    name: srv-1c:1541
+    output: D:/backup/prod
+profiles:
+  buh_prod:
+    cluster: prod
+    infobase: Buh
+    infobase_user: backup
+    retention:
+      max_age: 720h
+    hooks:
+      after: upload.cmd
+`
+
+func TestNew(t *testing.T) {
+	cases := []struct {
+		name string
+		yaml string
+		err  []string
+	}{
+		{
+			name: "Valid",
+			yaml: _validConfig,
+		},
+		{
+			name: "Unknown keys",
+			yaml: _validConfig + `    infobse: Zup
+lockk:
+  message: x
+`,
+			err: []string{ErrUnknownKeys.Error(), "line 25: field infobse not found in type config.Profile", "line 26: field lockk not found"},
+		},
+		{
+			name: "Missing values",
+			yaml: _validConfig + `  zup:
+    cluster: test
+    engine: rpc
+    retention:
+      keep: -1
+`,
+			err: []string{
+				`profiles.zup.cluster "test": ` + ErrUnknownCluster.Error(),
+				"profiles.zup.infobase: " + ErrRequired.Error(),
+				"profiles.zup.output: " + ErrRequired.Error(),
+				`profiles.zup.engine "rpc": ` + ErrInvalid.Error(),
+				"profiles.zup.retention.keep -1: " + ErrInvalid.Error(),
+			},
+		},
+		{
+			name: "Experimental engine",
+			yaml: strings.Replace(_validConfig, "app:\n", "app:\n  engine: ras\n", 1),
+			err:  []string{`app.engine "ras": ` + ErrExperimental.Error()},
+		},
+		{
+			name: "Unknown time zone",
+			yaml: strings.Replace(_validConfig, "app:\n", "app:\n  server_time_zone: Mars/Olympus\n", 1),
+			err:  []string{`app.server_time_zone "Mars/Olympus": ` + ErrInvalid.Error()},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.yaml), 0o600))
+
+			t.Setenv("CONFIG_PATH", path)
+
+			cfg, err := New()
+
+			if tc.err != nil {
+				for _, e := range tc.err {
+					require.ErrorContains(t, err, e)
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			p, err := cfg.ProfileByName("buh_prod")
+			require.NoError(t, err)
+			require.Equal(t, "12345", p.LockCode)
+			require.Equal(t, EngineRAC, p.Engine)
+			require.Equal(t, Retention{Keep: 7, MaxAge: 720 * time.Hour}, p.Retention)
+			require.Equal(t, "upload.cmd", p.Hooks.After)
+
+			_, err = cfg.ProfileByName("zup")
+			require.ErrorIs(t, err, ErrUnknownProfile)
+		})
+	}
+}
@@ -3,19 +3,17 @@ package app
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
-	"os"
-	"os/signal"
-	"syscall"
+	"strconv"
 	"time"
 
 	"github.com/antonmisa/1cctl_cli/config"
+	e "github.com/antonmisa/1cctl_cli/internal/common/clierror"
 	"github.com/antonmisa/1cctl_cli/internal/controller/cli"
+	"github.com/antonmisa/1cctl_cli/internal/entity"
 	"github.com/antonmisa/1cctl_cli/internal/usecase"
-	ucbackup "github.com/antonmisa/1cctl_cli/internal/usecase/backup"
-	ucpipe "github.com/antonmisa/1cctl_cli/internal/usecase/pipe"
 	"github.com/antonmisa/1cctl_cli/pkg/logger"
-	"github.com/antonmisa/1cctl_cli/pkg/pipe"
 )
 
 var (
@@ -23,62 +21,179 @@ var (
 	ErrEmptyClusterConnection = errors.New("app - RunCLI - empty cluster connection string")
 )
 
-func Run(cfg *config.Config, clusterConnection, clusterName, clusterAdmin, clusterPwd, infobase, infobaseUser, infobasePwd, outputPath string) {
+// targetFlags - infobase with credentials given by flags, profile or inventory entry.
+type targetFlags struct {
+	clusterConnection string
+	alias             string
+	profile           string
+	p                 cli.InfobaseParams
+}
 
-	l, err := logger.New(cfg.Log.Path, cfg.Log.Level)
-	if err != nil {
-		l.Fatal(fmt.Errorf("app - RunCLI - logger.New: %w", err))
+func (f *targetFlags) register(fs *flag.FlagSet, cfg *config.Config, infobaseUser string) {
+	fs.StringVar(&f.clusterConnection, "clusterConnection", "localhost:1545", "cluster host:port to connect")
+	fs.StringVar(&f.alias, "cluster", "", "cluster alias from config inventory, its settings are used for flags not given")
+	fs.StringVar(&f.profile, "profile", "", "backup profile from config, its settings are used for flags not given")
+	fs.StringVar(&f.p.ClusterName, "clusterName", "localhost:1541", "cluster host:port to operate on")
+	fs.StringVar(&f.p.AgentAdmin, "agentAdmin", cfg.Agent.Admin, "central server admin name")
+	fs.StringVar(&f.p.AgentPwd, "agentPwd", cfg.Agent.Pwd, "central server admin password")
+	fs.StringVar(&f.p.ClusterAdmin, "clusterAdmin", "", "cluster admin name")
+	fs.StringVar(&f.p.ClusterPwd, "clusterPwd", "", "cluster password")
+	fs.StringVar(&f.p.Infobase, "infobase", "", "infobase name")
+	fs.StringVar(&f.p.InfobaseUser, "infobaseUser", infobaseUser, "infobase admin name")
+	fs.StringVar(&f.p.InfobasePwd, "infobasePwd", "", "infobase password")
+
+	RegisterPwdSources(fs)
+}
+
+// apply - fills flags which are not set explicitly from profile, inventory entry and password sources.
+func (f *targetFlags) apply(cfg *config.Config, fs *flag.FlagSet) error {
+	if err := ApplyProfile(cfg, fs, f.profile); err != nil {
+		return e.Wrap(e.ErrConfig, err)
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
+	if err := ApplyCluster(cfg, fs, f.alias); err != nil {
+		return e.Wrap(e.ErrConfig, err)
+	}
 
-	// Waiting signal
-	interrupt := make(chan os.Signal, 1)
-	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	return e.Wrap(e.ErrConfig, ResolvePasswords(context.Background(), cfg, fs))
+}
 
-	go func() {
-		s := <-interrupt
-		l.Info("app - RunCLI - signal: " + s.String())
-		cancel()
-	}()
+// lockPolicy - lock of infobase during backup or restore by config.
+func lockPolicy(cfg *config.Config, infobase string) entity.LockPolicy {
+	lock := cfg.Lock.For(infobase)
 
-	if clusterConnection == "" {
-		l.Fatal(ErrEmptyClusterConnection) //nolint:goerr13 // high level error
+	return entity.LockPolicy{
+		Code:              cfg.App.LockCode,
+		Message:           lock.Message,
+		Window:            lock.ExpectedDuration + lock.Margin,
+		ScheduledJobsDeny: !lock.KeepScheduledJobs,
 	}
+}
 
-	if clusterName == "" && infobase == "" {
-		l.Fatal(ErrEmptyClusterOrInfobase) //nolint:goerr13 // high level error
+// exclusiveOptions - how users are warned and sessions are dropped before designer runs.
+func exclusiveOptions(cfg *config.Config) (*entity.GracefulTermination, entity.DrainOptions) {
+	var graceful *entity.GracefulTermination
+
+	if cfg.Graceful.Enabled {
+		graceful = &entity.GracefulTermination{
+			GracePeriod:  cfg.Graceful.GracePeriod,
+			PollInterval: cfg.Graceful.PollInterval,
+			HardDeadline: cfg.Graceful.HardDeadline,
+			Message:      cfg.Graceful.Message,
+			ErrorMessage: cfg.Graceful.ErrorMessage,
+		}
 	}
 
-	p, err := pipe.New(cfg.App.PathToRAC)
-	if err != nil {
-		l.Fatal(fmt.Errorf("app - RunCLI - pipe.New: %w", err))
+	drain := entity.DrainOptions{
+		Timeout:  cfg.Drain.Timeout,
+		Interval: cfg.Drain.Interval,
 	}
 
-	ctrlPipe := ucpipe.New(p, clusterConnection)
+	if cfg.Graceful.Enabled {
+		drain.Message = cfg.Graceful.ErrorMessage
+	}
 
-	ctrlBackup, err := ucbackup.New(cfg.App.PathTo1C)
+	return graceful, drain
+}
 
-	if err != nil {
-		l.Fatal(fmt.Errorf("app - RunCLI - ucbackup.New: %w", err))
+// RunBackup - makes a backup, returns exit code of process by category of failure, see clierror.
+func RunBackup(cfg *config.Config, args []string) int {
+	var (
+		f          targetFlags
+		outputPath string
+	)
+
+	fs := newFlagSet("backup")
+
+	f.register(fs, cfg, "robot")
+	fs.StringVar(&outputPath, "output", "", "directory backup move to")
+
+	if err := fs.Parse(args); err != nil {
+		return flagsCode(err)
+	}
+
+	if err := f.apply(cfg, fs); err != nil {
+		return fail(err)
 	}
 
-	ucCtrl := usecase.New(ctrlPipe, ctrlBackup)
+	p := f.p
 
-	ctrl := cli.New(ctx, ucCtrl)
+	return runCtrl(cfg, f.clusterConnection, true, func(ctx context.Context, l logger.Interface, uc usecase.Ctrl, ctrl *cli.Ctrl1CCLI) error {
+		if p.ClusterName == "" && p.Infobase == "" {
+			return e.Wrap(e.ErrConfig, ErrEmptyClusterOrInfobase)
+		}
 
-	now := time.Now()
+		now := time.Now()
 
-	l.Info("app - RunCLI - start")
+		l.Info("app - RunCLI - start")
 
-	err = ctrl.Backup(clusterName, infobase,
-		clusterAdmin, clusterPwd,
-		infobaseUser, infobasePwd,
-		cfg.App.LockCode, outputPath)
+		graceful, drain := exclusiveOptions(cfg)
 
-	if err != nil {
-		l.Fatal(err)
+		// Agent credentials are rarely given on command line, they are the same for all clusters of central server
+		if p.AgentAdmin == "" {
+			p.AgentAdmin = cfg.Agent.Admin
+
+			// Unless it is resolved from source
+			if p.AgentPwd == "" {
+				p.AgentPwd = cfg.Agent.Pwd
+			}
+		}
+
+		env := map[string]string{
+			"BACKUP_INFOBASE": p.Infobase,
+			"BACKUP_OUTPUT":   outputPath,
+		}
+
+		if err := runHook(ctx, cfg.Hooks.Before, env); err != nil {
+			return e.Wrap(e.ErrHookFailed, fmt.Errorf("app - RunCLI - before hook: %w", err))
+		}
+
+		err := ctrl.Backup(p.ClusterName, p.Infobase,
+			p.AgentAdmin, p.AgentPwd,
+			p.ClusterAdmin, p.ClusterPwd,
+			p.InfobaseUser, p.InfobasePwd,
+			lockPolicy(cfg, p.Infobase), outputPath,
+			graceful, drain)
+
+		if err == nil {
+			prune(l, uc, outputPath, p.Infobase, entity.RetentionPolicy{
+				Keep:   cfg.Retention.Keep,
+				MaxAge: cfg.Retention.MaxAge,
+			})
+		}
+
+		env["BACKUP_EXIT_CODE"] = strconv.Itoa(e.Code(err))
+
+		if hookErr := runHook(ctx, cfg.Hooks.After, env); hookErr != nil {
+			hookErr = fmt.Errorf("app - RunCLI - after hook: %w", hookErr)
+
+			// Failure of backup tells more than failure of hook
+			if err != nil {
+				l.Warn(hookErr.Error())
+			} else {
+				err = e.Wrap(e.ErrHookFailed, hookErr)
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+
+		l.Info("app - RunCLI - succefully end, time taken: %s", time.Since(now).String())
+
+		return nil
+	})
+}
+
+// prune - removes backups expired by retention policy, backup is successful anyway.
+func prune(l logger.Interface, c usecase.Ctrl, outputPath, infobase string, policy entity.RetentionPolicy) {
+	removed, err := c.PruneBackups(outputPath, infobase, policy)
+
+	for _, b := range removed {
+		l.Info("app - RunCLI - expired backup removed: %s", b.Path)
 	}
 
-	l.Info("app - RunCLI - succefully end, time taken: %s", time.Since(now).String())
+	if err != nil {
+		l.Warn(fmt.Errorf("app - RunCLI - prune: %w", err).Error())
+	}
 }
@@ -0,0 +1,45 @@
+package app
+
+import (
+	"context"
+
+	"github.com/antonmisa/1cctl_cli/config"
+	"github.com/antonmisa/1cctl_cli/internal/controller/cli"
+	"github.com/antonmisa/1cctl_cli/internal/usecase"
+	"github.com/antonmisa/1cctl_cli/pkg/logger"
+)
+
+// RunClusters - clusters command group: list.
+func RunClusters(cfg *config.Config, args []string) int {
+	var (
+		clusterConnection string
+		p                 cli.ClusterParams
+		inventory         inventoryFlags
+	)
+
+	_, fs, err := subcommand("clusters", args, "list")
+	if err != nil {
+		return fail(err)
+	}
+
+	registerCluster(fs, cfg, &clusterConnection, &p)
+	inventory.register(fs)
+
+	if err = fs.Parse(args[1:]); err != nil {
+		return flagsCode(err)
+	}
+
+	if err = inventory.apply(cfg, fs); err != nil {
+		return fail(err)
+	}
+
+	if inventory.all {
+		return runFleet(cfg, func(fleet *cli.Fleet) error {
+			return fleet.ClustersList(p)
+		})
+	}
+
+	return runCtrl(cfg, clusterConnection, false, func(_ context.Context, _ logger.Interface, _ usecase.Ctrl, ctrl *cli.Ctrl1CCLI) error {
+		return ctrl.ClustersList(p)
+	})
+}
@@ -0,0 +1,392 @@
+package app
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/antonmisa/1cctl_cli/config"
+	e "github.com/antonmisa/1cctl_cli/internal/common/clierror"
+)
+
+var ErrUnknownCommand = errors.New("app - unknown command")
+
+// help - what command does and how it is used, printed by -h -.
+type help struct {
+	summary  string
+	examples []string
+}
+
+// _help - help of commands and subcommands by their full names.
+var _help = map[string]help{
+	"backup": {
+		summary: "Denies sessions of infobase, drops them, dumps infobase by designer and gives lock back.",
+		examples: []string{
+			"ctrl backup --clusterConnection srv-1c:1545 --clusterName srv-1c:1541 --infobase Buh --infobaseUser robot --infobasePwdFrom env:BUH_PWD --output D:/backup",
+			"ctrl backup --profile buh_prod",
+		},
+	},
+	"restore": {
+		summary: "Replaces infobase by dump: sessions are denied and dropped the same way as for backup.",
+		examples: []string{
+			"ctrl restore --profile buh_prod --input D:/backup/01_02_2024_03_00_00_Buh.dt",
+			"ctrl restore --cluster prod --infobase Buh_test --infobaseUser robot --input Buh.dt --yes",
+		},
+	},
+	"lock": {
+		summary: "Denies new sessions and scheduled jobs of infobase, open sessions are kept.",
+		examples: []string{
+			"ctrl lock --cluster prod --infobase Buh --window 2h --message \"Maintenance till {to}\"",
+		},
+	},
+	"unlock": {
+		summary: "Allows sessions and scheduled jobs of infobase.",
+		examples: []string{
+			"ctrl unlock --cluster prod --infobase Buh",
+		},
+	},
+	"clusters": {summary: "Clusters of central server."},
+	"clusters list": {
+		summary: "Lists clusters of central server.",
+		examples: []string{
+			"ctrl clusters list --clusterConnection srv-1c:1545",
+			"ctrl clusters list --all-clusters",
+		},
+	},
+	"infobases": {summary: "Infobases of cluster."},
+	"infobases list": {
+		summary: "Lists infobases of cluster.",
+		examples: []string{
+			"ctrl infobases list --cluster prod -o json",
+			"ctrl infobases list --all-clusters --columns name",
+		},
+	},
+	"infobases show": {
+		summary: "Shows full properties of infobase, infobase credentials are required.",
+		examples: []string{
+			"ctrl infobases show --cluster prod --infobase Buh --infobaseUser admin --infobasePwdFrom prompt",
+		},
+	},
+	"sessions": {summary: "Sessions of cluster or infobase."},
+	"sessions list": {
+		summary: "Lists sessions matching filters.",
+		examples: []string{
+			"ctrl sessions list --cluster prod --infobase Buh --idle 30m",
+			"ctrl sessions list --all-clusters --app-id Designer",
+			"ctrl sessions list --cluster prod --columns user,host,last-active --sort -last-active",
+			"ctrl sessions list --cluster prod -o json",
+		},
+	},
+	"sessions kill": {
+		summary: "Terminates sessions matching filters, asks for confirmation unless --yes is given.",
+		examples: []string{
+			"ctrl sessions kill --cluster prod --infobase Buh --user Ivanov --message \"Infobase is updated\"",
+			"ctrl sessions kill --cluster prod --idle 2h --dry-run",
+		},
+	},
+	"connections": {summary: "Connections of cluster or infobase."},
+	"connections list": {
+		summary: "Lists connections matching filters.",
+		examples: []string{
+			"ctrl connections list --cluster prod --infobase Buh",
+		},
+	},
+	"connections disconnect": {
+		summary: "Breaks connections matching filters, asks for confirmation unless --yes is given.",
+		examples: []string{
+			"ctrl connections disconnect --cluster prod --app-id COMConnection --min-duration 4h",
+		},
+	},
+	"processes": {summary: "Working processes of cluster."},
+	"processes list": {
+		summary: "Lists working processes of cluster.",
+		examples: []string{
+			"ctrl processes list --all-clusters",
+		},
+	},
+	"servers": {summary: "Working servers of cluster."},
+	"servers list": {
+		summary: "Lists working servers of cluster.",
+		examples: []string{
+			"ctrl servers list --cluster prod",
+		},
+	},
+	"doctor": {
+		summary: "Checks environment of backup end to end and prints checklist.",
+		examples: []string{
+			"ctrl doctor --profile buh_prod",
+		},
+	},
+	"secrets": {summary: "Encrypted secrets file referred by secret:// values of config."},
+	"secrets init": {
+		summary: "Creates empty secrets file, key is generated unless given.",
+		examples: []string{
+			"ctrl secrets init --keyFile /etc/1cctl/secrets.key",
+		},
+	},
+	"secrets set": {
+		summary: "Adds or replaces secret, value is read from terminal or first line of stdin.",
+		examples: []string{
+			"ctrl secrets set prod/buh/infobase_pwd",
+		},
+	},
+	"secrets delete": {summary: "Removes secret.", examples: []string{"ctrl secrets delete prod/buh/infobase_pwd"}},
+	"secrets list":   {summary: "Lists references of secrets, values are never printed.", examples: []string{"ctrl secrets list"}},
+	"config":         {summary: "Config file."},
+	"config init": {
+		summary: "Creates config with default settings at CONFIG_PATH (./config.yml).",
+		examples: []string{
+			"CONFIG_PATH=/etc/1cctl/config.yml ctrl config init",
+		},
+	},
+	"config validate": {
+		summary:  "Loads config and reports unknown keys, missing and invalid values.",
+		examples: []string{"ctrl config validate"},
+	},
+	"version": {summary: "Prints version of ctrl.", examples: []string{"ctrl version"}},
+}
+
+// command - top level command, groups list their subcommands -.
+type command struct {
+	name string
+	subs []string
+	run  func(args []string) int
+}
+
+// _commands - commands in order of usage.
+var _commands = []command{
+	{name: "backup", run: withConfig(RunBackup)},
+	{name: "restore", run: withConfig(RunRestore)},
+	{name: "lock", run: withConfig(RunLock)},
+	{name: "unlock", run: withConfig(RunUnlock)},
+	{name: "clusters", subs: []string{"list"}, run: withConfig(RunClusters)},
+	{name: "infobases", subs: []string{"list", "show"}, run: withConfig(RunInfobases)},
+	{name: "sessions", subs: []string{"list", "kill"}, run: withConfig(RunSessions)},
+	{name: "connections", subs: []string{"list", "disconnect"}, run: withConfig(RunConnections)},
+	{name: "processes", subs: []string{"list"}, run: withConfig(RunProcesses)},
+	{name: "servers", subs: []string{"list"}, run: withConfig(RunServers)},
+	{name: "doctor", run: withConfig(RunDoctor)},
+	{name: "secrets", subs: []string{"init", "set", "delete", "list"}, run: RunSecrets},
+	{name: "config", subs: []string{"init", "validate"}, run: RunConfig},
+	{name: "version", run: RunVersion},
+}
+
+// Main - runs command given by args, returns exit code of process.
+// Flags without command make a backup as before commands were introduced, --prepare creates config.
+func Main(args []string) int {
+	// Errors of commands are reported by log, known passwords are redacted from it
+	log.SetOutput(_secrets.Writer(os.Stderr))
+
+	if len(args) == 0 {
+		usage(os.Stderr)
+
+		return e.CodeUsage
+	}
+
+	name := args[0]
+
+	switch {
+	case isHelp(name):
+		usage(os.Stdout)
+
+		return e.CodeOK
+	case name == "help":
+		return helpCommand(args[1:])
+	case strings.HasPrefix(name, "-"):
+		return legacy(args)
+	}
+
+	for _, c := range _commands {
+		if c.name != name {
+			continue
+		}
+
+		// Subcommand is checked before config is loaded for it
+		if len(c.subs) > 0 && (len(args) == 1 || !contains(c.subs, args[1])) {
+			return wrongSubcommand(c, args[1:])
+		}
+
+		return c.run(args[1:])
+	}
+
+	fmt.Fprintf(os.Stderr, "%s: %s\n\n", ErrUnknownCommand, name)
+	usage(os.Stderr)
+
+	return e.CodeUsage
+}
+
+// legacy - flat flags of versions before commands, kept for schedulers set up with them.
+func legacy(args []string) int {
+	for _, arg := range args {
+		if strings.TrimLeft(arg, "-") == "prepare" {
+			return RunConfig([]string{"init"})
+		}
+	}
+
+	fmt.Fprintln(os.Stderr, "flags without command are deprecated, use: ctrl backup [flags]")
+
+	return withConfig(RunBackup)(args)
+}
+
+// helpCommand - help of command or subcommand given by args, usage of all commands otherwise.
+func helpCommand(args []string) int {
+	name := strings.Join(args, " ")
+
+	if _, ok := _help[name]; !ok {
+		usage(os.Stdout)
+
+		return e.CodeOK
+	}
+
+	for _, c := range _commands {
+		if c.name != args[0] {
+			continue
+		}
+
+		if len(c.subs) > 0 && len(args) == 1 {
+			groupUsage(os.Stdout, c)
+
+			return e.CodeOK
+		}
+
+		return c.run(append(args[1:], "-h"))
+	}
+
+	return e.CodeOK
+}
+
+// wrongSubcommand - usage of group, missing or unknown subcommand is usage error, help is not.
+func wrongSubcommand(c command, args []string) int {
+	if len(args) > 0 && isHelp(args[0]) {
+		groupUsage(os.Stdout, c)
+
+		return e.CodeOK
+	}
+
+	if len(args) > 0 {
+		fmt.Fprintf(os.Stderr, "%s: %s %s\n\n", ErrUnknownCommand, c.name, args[0])
+	}
+
+	groupUsage(os.Stderr, c)
+
+	return e.CodeUsage
+}
+
+// usage - commands with their summaries.
+func usage(w io.Writer) {
+	fmt.Fprintf(w, "usage: ctrl <command> [subcommand] [flags]\n\nCommands:\n")
+
+	for _, c := range _commands {
+		fmt.Fprintf(w, "  %-12s %s\n", c.name, _help[c.name].summary)
+	}
+
+	fmt.Fprintf(w, "\nRun \"ctrl help <command>\" or \"ctrl <command> [subcommand] -h\" for flags and examples.\n")
+}
+
+// groupUsage - subcommands of group with their summaries.
+func groupUsage(w io.Writer, c command) {
+	fmt.Fprintf(w, "usage: ctrl %s <%s> [flags]\n\n%s\n\nSubcommands:\n", c.name, strings.Join(c.subs, "|"), _help[c.name].summary)
+
+	for _, sub := range c.subs {
+		fmt.Fprintf(w, "  %-12s %s\n", sub, _help[c.name+" "+sub].summary)
+	}
+}
+
+// newFlagSet - flag set of command, -h prints summary, flags and examples of it.
+// Errors of flags are printed with usage by flag set and returned, see flagsCode.
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+
+	fs.Usage = func() {
+		h := _help[name]
+		w := fs.Output()
+
+		fmt.Fprintf(w, "usage: ctrl %s [flags]\n\n%s\n\nFlags:\n", name, h.summary)
+		fs.PrintDefaults()
+
+		if len(h.examples) > 0 {
+			fmt.Fprintf(w, "\nExamples:\n")
+
+			for _, ex := range h.examples {
+				fmt.Fprintf(w, "  %s\n", ex)
+			}
+		}
+	}
+
+	return fs
+}
+
+// subcommand - checks subcommand name and creates flag set for it.
+func subcommand(group string, args []string, names ...string) (string, *flag.FlagSet, error) {
+	if len(args) > 0 && contains(names, args[0]) {
+		return args[0], newFlagSet(group + " " + args[0]), nil
+	}
+
+	groupUsage(os.Stderr, command{name: group, subs: names})
+
+	return "", nil, e.Wrap(e.ErrUsage, fmt.Errorf("%w: %s %s", ErrUnknownCommand, group, strings.Join(args, " ")))
+}
+
+// flagsCode - exit code of failed parsing of flags: help is asked, or flags are wrong.
+func flagsCode(err error) int {
+	if errors.Is(err, flag.ErrHelp) {
+		return e.CodeOK
+	}
+
+	return e.CodeUsage
+}
+
+// fail - reports err of command failed before its logger is created to log, which redacts passwords,
+// returns exit code by its category.
+func fail(err error) int {
+	var c *e.Category
+
+	if errors.As(err, &c) {
+		log.Printf("%s: %s", c, err)
+	} else {
+		log.Print(err)
+	}
+
+	return e.Code(err)
+}
+
+// withConfig - command run with loaded config, help is printed without it.
+func withConfig(fn func(cfg *config.Config, args []string) int) func(args []string) int {
+	return func(args []string) int {
+		for _, arg := range args {
+			if isHelp(arg) {
+				return fn(&config.Config{}, args)
+			}
+		}
+
+		cfg, err := config.New()
+		if err != nil {
+			return fail(e.Wrap(e.ErrConfig, err))
+		}
+
+		return fn(cfg, args)
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isHelp(arg string) bool {
+	switch arg {
+	case "-h", "-help", "--help":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,246 @@
+package app
+
+import (
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/antonmisa/1cctl_cli/config"
+	e "github.com/antonmisa/1cctl_cli/internal/common/clierror"
+)
+
+func TestCommands(t *testing.T) {
+	cases := []struct {
+		name string
+		args []string
+		code int
+	}{
+		{name: "No command", code: e.CodeUsage},
+		{name: "Unknown command", args: []string{"bogus"}, code: e.CodeUsage},
+		{name: "Missing subcommand", args: []string{"sessions"}, code: e.CodeUsage},
+		{name: "Unknown subcommand", args: []string{"sessions", "bogus"}, code: e.CodeUsage},
+		{name: "Help", args: []string{"--help"}, code: e.CodeOK},
+		{name: "Help of group", args: []string{"help", "sessions"}, code: e.CodeOK},
+		{name: "Group help flag", args: []string{"connections", "-h"}, code: e.CodeOK},
+		{name: "Version", args: []string{"version"}, code: e.CodeOK},
+		{name: "Unknown flag", args: []string{"version", "--bogus"}, code: e.CodeUsage},
+		{name: "Subcommand help", args: []string{"sessions", "list", "-h"}, code: e.CodeOK},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.code, Main(tc.args))
+		})
+	}
+}
+
+func TestCommandsExitCode(t *testing.T) {
+	cases := []struct {
+		name string
+		run  func(cfg *config.Config, args []string) int
+		args []string
+		code int
+	}{
+		{
+			name: "Cluster and all clusters",
+			run:  RunSessions,
+			args: []string{"list", "--cluster", "prod", "--all-clusters"},
+			code: e.CodeUsage,
+		},
+		{
+			name: "Clusters of all clusters and cluster",
+			run:  RunClusters,
+			args: []string{"list", "--cluster", "prod", "--all-clusters"},
+			code: e.CodeUsage,
+		},
+		{
+			name: "Infobases of all clusters, empty inventory",
+			run:  RunInfobases,
+			args: []string{"list", "--all-clusters"},
+			code: e.CodeConfig,
+		},
+		{
+			name: "Kill on all clusters",
+			run:  RunSessions,
+			args: []string{"kill", "--all-clusters"},
+			code: e.CodeUsage,
+		},
+		{
+			name: "Unknown alias",
+			run:  RunConnections,
+			args: []string{"list", "--cluster", "prod"},
+			code: e.CodeConfig,
+		},
+		{
+			name: "Empty inventory",
+			run:  RunProcesses,
+			args: []string{"list", "--all-clusters"},
+			code: e.CodeConfig,
+		},
+		{
+			name: "Unknown profile",
+			run:  RunInfobases,
+			args: []string{"show", "--profile", "buh"},
+			code: e.CodeConfig,
+		},
+		{
+			name: "Unknown subcommand",
+			run:  RunServers,
+			args: []string{"bogus"},
+			code: e.CodeUsage,
+		},
+		{
+			name: "Wrong flag value",
+			run:  RunClusters,
+			args: []string{"list", "--columns"},
+			code: e.CodeUsage,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.code, tc.run(&config.Config{}, tc.args))
+		})
+	}
+}
+
+func TestHelp(t *testing.T) {
+	for _, c := range _commands {
+		require.NotEmpty(t, _help[c.name].summary, c.name)
+
+		if len(c.subs) == 0 {
+			require.NotEmpty(t, _help[c.name].examples, c.name)
+		}
+
+		for _, sub := range c.subs {
+			require.NotEmpty(t, _help[c.name+" "+sub].summary, c.name+" "+sub)
+			require.NotEmpty(t, _help[c.name+" "+sub].examples, c.name+" "+sub)
+		}
+	}
+}
+
+func TestErrorOutputRedacted(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake rac is shell script")
+	}
+
+	const pwd = "s3cret-pwd"
+
+	dir := t.TempDir()
+
+	// Fake rac failing with its arguments, password among them, in error, but telling its version
+	rac := filepath.Join(dir, "rac")
+	script := "#!/bin/sh\n[ \"$1\" = --version ] && echo 8.3.24 && exit 0\necho \"rac: access denied: $*\" >&2\nexit 1\n"
+	require.NoError(t, os.WriteFile(rac, []byte(script), 0o700)) //nolint:gosec // executable
+
+	// Source of cluster password failing with agent password in error, reported before logger is created
+	source := filepath.Join(dir, "pwd")
+	require.NoError(t, os.WriteFile(source, []byte("#!/bin/sh\necho \"vault: "+pwd+" is not a token\" >&2\nexit 1\n"), 0o700)) //nolint:gosec // executable
+
+	logPath := filepath.Join(dir, "log.log")
+
+	cfgPath := filepath.Join(dir, "config.yml")
+	require.NoError(t, os.WriteFile(cfgPath, []byte(`
+app:
+  path_to_rac: `+rac+`
+  path_to_1cs: 1cv8
+  lock_code: "12345"
+logger:
+  level: debug
+  path: `+logPath+`
+retry:
+  attempts: 1
+`), 0o600))
+
+	t.Setenv("CONFIG_PATH", cfgPath)
+
+	// RAS reachable for doctor to run rac
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer ln.Close()
+
+	cases := []struct {
+		name string
+		args []string
+		out  string
+	}{
+		{
+			name: "Logged error",
+			args: []string{"sessions", "list", "--agentAdmin", "admin", "--agentPwd", pwd},
+			out:  "rac: access denied",
+		},
+		{
+			name: "Failed check",
+			args: []string{"doctor", "--clusterConnection", ln.Addr().String(), "--agentAdmin", "admin", "--agentPwd", pwd},
+			out:  "rac: access denied",
+		},
+		{
+			name: "Error before logger",
+			args: []string{"sessions", "list", "--agentAdmin", "admin", "--agentPwd", pwd,
+				"--clusterAdmin", "admin", "--clusterPwdFrom", "cmd:" + source},
+			out: "is not a token",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			stdout, stderr := capture(t)
+
+			require.NotEqual(t, e.CodeOK, Main(tc.args))
+
+			for _, path := range []string{stdout, stderr, logPath} {
+				out, err := os.ReadFile(path)
+				require.NoError(t, err)
+				require.NotContains(t, string(out), pwd, path)
+			}
+
+			stdoutData, err := os.ReadFile(stdout)
+			require.NoError(t, err)
+
+			stderrData, err := os.ReadFile(stderr)
+			require.NoError(t, err)
+
+			require.Contains(t, string(stdoutData)+string(stderrData), tc.out)
+		})
+	}
+}
+
+// capture - redirects stdout, stderr and log of process to files until test ends, returns their paths.
+func capture(t *testing.T) (string, string) {
+	t.Helper()
+
+	files := make([]*os.File, 2)
+
+	for i := range files {
+		f, err := os.CreateTemp(t.TempDir(), "out")
+		require.NoError(t, err)
+
+		files[i] = f
+	}
+
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = files[0], files[1]
+
+	t.Cleanup(func() {
+		os.Stdout, os.Stderr = stdout, stderr
+		log.SetOutput(os.Stderr)
+
+		for _, f := range files {
+			f.Close()
+		}
+	})
+
+	return files[0].Name(), files[1].Name()
+}
@@ -0,0 +1,44 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/antonmisa/1cctl_cli/config"
+	e "github.com/antonmisa/1cctl_cli/internal/common/clierror"
+)
+
+// RunConfig - config command group: init, validate.
+func RunConfig(args []string) int {
+	sub, fs, err := subcommand("config", args, "init", "validate")
+	if err != nil {
+		return fail(err)
+	}
+
+	if err = fs.Parse(args[1:]); err != nil {
+		return flagsCode(err)
+	}
+
+	switch sub {
+	case "init":
+		if err = config.Prepare(); err != nil {
+			if errors.Is(err, os.ErrExist) {
+				err = fmt.Errorf("config already exists, remove it or set CONFIG_PATH: %w", err)
+			}
+
+			return fail(e.Wrap(e.ErrConfig, err))
+		}
+
+		fmt.Println("config is created")
+	case "validate":
+		// Unknown keys, missing and invalid values are reported by loading
+		if _, err = config.New(); err != nil {
+			return fail(e.Wrap(e.ErrConfig, err))
+		}
+
+		fmt.Println("config is valid")
+	}
+
+	return e.CodeOK
+}
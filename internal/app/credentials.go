@@ -0,0 +1,139 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/antonmisa/1cctl_cli/config"
+	"github.com/antonmisa/1cctl_cli/internal/common/credential"
+	"github.com/antonmisa/1cctl_cli/pkg/logger"
+)
+
+// _secrets - passwords known to process, redacted from every log line.
+var _secrets = credential.NewRedactor()
+
+// pwdFlag - password flag with flag of its source and flag of user it belongs to.
+type pwdFlag struct {
+	pwd, from, user string
+	label           string
+	source          func(cfg *config.Config, fs *flag.FlagSet) string // configured source
+}
+
+var _pwdFlags = []pwdFlag{
+	{
+		pwd: "agentPwd", from: "agentPwdFrom", user: "agentAdmin",
+		label: "central server administrator",
+		source: func(cfg *config.Config, _ *flag.FlagSet) string {
+			return cfg.Agent.PwdFrom
+		},
+	},
+	{
+		pwd: "clusterPwd", from: "clusterPwdFrom", user: "clusterAdmin",
+		label: "cluster administrator",
+		source: func(cfg *config.Config, _ *flag.FlagSet) string {
+			return cfg.Credentials.ClusterPwdFrom
+		},
+	},
+	{
+		pwd: "infobasePwd", from: "infobasePwdFrom", user: "infobaseUser",
+		label: "infobase user",
+		source: func(cfg *config.Config, fs *flag.FlagSet) string {
+			return cfg.Credentials.For(flagValue(fs, "infobase")).InfobasePwdFrom
+		},
+	},
+}
+
+// RegisterPwdSources - flags of password sources for password flags defined in fs.
+func RegisterPwdSources(fs *flag.FlagSet) {
+	for _, f := range _pwdFlags {
+		if fs.Lookup(f.pwd) != nil {
+			fs.String(f.from, "", fmt.Sprintf("source of %s password: env:VAR, file:PATH, prompt or cmd:COMMAND", f.label))
+		}
+	}
+}
+
+// ResolvePasswords - fills password flags which are not set from their sources: flag, inventory entry, config.
+// Password is resolved only if its user is given, all passwords are redacted from logs.
+func ResolvePasswords(ctx context.Context, cfg *config.Config, fs *flag.FlagSet) error {
+	for _, f := range _pwdFlags {
+		if fs.Lookup(f.pwd) == nil {
+			continue
+		}
+
+		pwd := flagValue(fs, f.pwd)
+		if pwd != "" {
+			_secrets.Add(pwd)
+
+			continue
+		}
+
+		user := flagValue(fs, f.user)
+
+		// Agent credentials are taken from config by backup if not given
+		if user == "" && f.pwd == "agentPwd" {
+			user = cfg.Agent.Admin
+		}
+
+		source := flagValue(fs, f.from)
+		if source == "" {
+			source = f.source(cfg, fs)
+		}
+
+		if user == "" || source == "" {
+			continue
+		}
+
+		pwd, err := resolvePwd(ctx, source, fmt.Sprintf("%s %s", f.label, user))
+		if err != nil {
+			return fmt.Errorf("app - ResolvePasswords - %s: %w", f.pwd, err)
+		}
+
+		if err = fs.Set(f.pwd, pwd); err != nil {
+			return fmt.Errorf("app - ResolvePasswords - fs.Set: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// resolvePwd - password given by source, it is redacted from logs since then.
+func resolvePwd(ctx context.Context, source string, label string) (string, error) {
+	p, err := credential.Parse(source, label)
+	if err != nil {
+		return "", err
+	}
+
+	pwd, err := p.Secret(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	_secrets.Add(pwd)
+
+	return pwd, nil
+}
+
+// newLogger - logger with known passwords redacted.
+func newLogger(cfg *config.Config) (*logger.Logger, error) {
+	l, err := logger.New(cfg.Log.Path, cfg.Log.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	_secrets.Add(cfg.Secrets()...)
+
+	l.Redact(_secrets.String)
+
+	return l, nil
+}
+
+// flagValue - value of flag, empty if fs has no such flag.
+func flagValue(fs *flag.FlagSet, name string) string {
+	f := fs.Lookup(name)
+	if f == nil {
+		return ""
+	}
+
+	return f.Value.String()
+}
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/antonmisa/1cctl_cli/config"
+	e "github.com/antonmisa/1cctl_cli/internal/common/clierror"
+	"github.com/antonmisa/1cctl_cli/internal/controller/cli"
+	"github.com/antonmisa/1cctl_cli/internal/usecase"
+	ucbackup "github.com/antonmisa/1cctl_cli/internal/usecase/backup"
+	"github.com/antonmisa/1cctl_cli/pkg/logger"
+)
+
+// signalContext - context canceled by interrupt or termination of process.
+func signalContext(l logger.Interface) context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Waiting signal
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		s := <-interrupt
+		l.Info("app - signal: " + s.String())
+		cancel()
+	}()
+
+	return ctx
+}
+
+// newUseCase - usecase of cluster administered by configured engine, designer is added
+// only for commands running it, so others do not require 1cv8 executable.
+func newUseCase(cfg *config.Config, l logger.Interface, clusterConnection string, designer bool) (usecase.Ctrl, error) {
+	newPipe, err := newEngine(cfg, l)
+	if err != nil {
+		return nil, fmt.Errorf("app - newUseCase - newEngine: %w", err)
+	}
+
+	var ctrlBackup usecase.CtrlBackup
+
+	if designer {
+		b, err := ucbackup.New(cfg.App.PathTo1C)
+		if err != nil {
+			return nil, fmt.Errorf("app - newUseCase - ucbackup.New: %w", err)
+		}
+
+		ctrlBackup = b
+	}
+
+	return usecase.New(newPipe(clusterConnection), ctrlBackup), nil
+}
+
+// runCtrl - builds controller of cluster, with designer if it is needed, runs fn by it
+// and returns exit code of process by category of failure, see clierror.
+func runCtrl(cfg *config.Config, clusterConnection string, designer bool,
+	fn func(ctx context.Context, l logger.Interface, uc usecase.Ctrl, ctrl *cli.Ctrl1CCLI) error) int {
+
+	l, err := newLogger(cfg)
+	if err != nil {
+		log.Printf("app - logger.New: %s", err)
+
+		return e.CodeConfig
+	}
+
+	// fail - logs err and tells its exit code
+	fail := func(err error) int {
+		l.Error(err)
+
+		return e.Code(err)
+	}
+
+	if clusterConnection == "" {
+		return fail(e.Wrap(e.ErrConfig, ErrEmptyClusterConnection))
+	}
+
+	ctx := signalContext(l)
+
+	uc, err := newUseCase(cfg, l, clusterConnection, designer)
+	if err != nil {
+		return fail(e.Wrap(e.ErrConfig, err))
+	}
+
+	if err = fn(ctx, l, uc, cli.New(ctx, uc, os.Stdin, _secrets.Writer(os.Stdout))); err != nil {
+		return fail(err)
+	}
+
+	return e.CodeOK
+}
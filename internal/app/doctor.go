@@ -0,0 +1,254 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"regexp"
+	"runtime"
+	"syscall"
+	"time"
+
+	"github.com/antonmisa/1cctl_cli/config"
+	e "github.com/antonmisa/1cctl_cli/internal/common/clierror"
+	"github.com/antonmisa/1cctl_cli/internal/controller/cli"
+	"github.com/antonmisa/1cctl_cli/internal/usecase"
+)
+
+// Names of local checks of doctor.
+const (
+	_checkRAC    = "rac executable"
+	_checkDumper = "1cv8 executable"
+	_checkRAS    = "RAS reachable"
+	_checkOutput = "output directory"
+	_checkLog    = "log file"
+
+	// _racPasswords - rac takes passwords by arguments only, see Credentials of README
+	_racPasswords = "passwords are passed to rac as arguments, other users of host see them in process list," +
+		" run it on dedicated host (engine ras has no such issue, but is read only yet)"
+
+	_dialTimeout = 5 * time.Second
+	_mb          = 1 << 20
+)
+
+var (
+	ErrNotExecutable = errors.New("app - not an executable file")
+	ErrLowFreeSpace  = errors.New("app - not enough free space")
+	ErrEmptyOutput   = errors.New("app - output directory is not given")
+
+	ErrFreeSpaceUnsupported = errors.New("app - free space check is not supported on " + runtime.GOOS)
+)
+
+var _version = regexp.MustCompile(`\d+\.\d+\.\d+\.\d+`)
+
+// doctorFlags - what doctor checks: infobase given as for backup, infobase is not checked if empty,
+// and output directory.
+type doctorFlags struct {
+	targetFlags
+
+	output    string
+	minFreeMB uint64
+}
+
+func (f *doctorFlags) register(fs *flag.FlagSet, cfg *config.Config) {
+	f.targetFlags.register(fs, cfg, "")
+	fs.StringVar(&f.output, "output", "", "directory backups are moved to")
+	fs.Uint64Var(&f.minFreeMB, "min-free-mb", 1024, "free space in output directory required, MB") //nolint:gomnd // default
+}
+
+// RunDoctor - checks environment of backup end to end, prints checklist and
+// returns exit code of first failed check, see clierror.
+func RunDoctor(cfg *config.Config, args []string) int {
+	var f doctorFlags
+
+	fs := newFlagSet("doctor")
+
+	f.register(fs, cfg)
+
+	if err := fs.Parse(args); err != nil {
+		return flagsCode(err)
+	}
+
+	if err := f.apply(cfg, fs); err != nil {
+		return fail(err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	err := cli.RunChecks(ctx, _secrets.Writer(os.Stdout), doctorChecks(ctx, cfg, f))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, cli.ErrChecksFailed)
+	}
+
+	return e.Code(err)
+}
+
+// doctorChecks - local checks, then cluster checks of configured engine, which need RAS to be reachable.
+func doctorChecks(ctx context.Context, cfg *config.Config, f doctorFlags) []cli.Check {
+	var checks []cli.Check
+
+	if cfg.App.Engine == config.EngineRAC || cfg.App.Engine == "" {
+		checks = append(checks, cli.Check{
+			Name: _checkRAC,
+			Run: func(ctx context.Context) (string, error) {
+				return checkRAC(ctx, cfg.App.PathToRAC)
+			},
+		})
+	}
+
+	checks = append(checks,
+		cli.Check{
+			Name: _checkDumper,
+			Run: func(ctx context.Context) (string, error) {
+				// Designer has no version option, it opens window instead
+				return executableVersion(ctx, cfg.App.PathTo1C)
+			},
+		},
+		cli.Check{
+			Name: _checkOutput,
+			Run: func(context.Context) (string, error) {
+				return checkOutput(f.output, f.minFreeMB*_mb)
+			},
+		},
+		cli.Check{
+			Name: _checkLog,
+			Run: func(context.Context) (string, error) {
+				return checkLog(cfg.Log.Path)
+			},
+		},
+		cli.Check{
+			Name: _checkRAS,
+			Run: func(ctx context.Context) (string, error) {
+				return checkReachable(ctx, f.clusterConnection)
+			},
+		},
+	)
+
+	needs := []string{_checkRAS}
+	if cfg.App.Engine == config.EngineRAC || cfg.App.Engine == "" {
+		needs = append(needs, _checkRAC)
+	}
+
+	// Engine is built without logger, retries are not logged by doctor
+	newPipe, err := newEngine(cfg, nil)
+	if err != nil {
+		return append(checks, cli.Check{
+			Name:  "engine",
+			Needs: needs,
+			Run: func(context.Context) (string, error) {
+				return "", e.Wrap(e.ErrConfig, err)
+			},
+		})
+	}
+
+	ctrl := cli.New(ctx, usecase.New(newPipe(f.clusterConnection), nil), os.Stdin, _secrets.Writer(os.Stdout))
+
+	return append(checks, ctrl.ClusterChecks(f.p, needs...)...)
+}
+
+// executableVersion - version of executable told by it for args given, otherwise found in its path,
+// as 1C installs every version to directory named by it.
+func executableVersion(ctx context.Context, path string, args ...string) (string, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return "", e.Wrap(e.ErrConfig, fmt.Errorf("app - executableVersion - os.Stat: %w", err))
+	}
+
+	if fi.IsDir() {
+		return "", e.Wrap(e.ErrConfig, fmt.Errorf("%w: %s", ErrNotExecutable, path))
+	}
+
+	if len(args) > 0 {
+		out, err := exec.CommandContext(ctx, path, args...).Output() //nolint:gosec // path is configured
+		if err != nil {
+			return "", e.Wrap(e.ErrConfig, fmt.Errorf("app - executableVersion - %s: %w", path, err))
+		}
+
+		if v := _version.FindString(string(out)); v != "" {
+			return fmt.Sprintf("%s, version %s", path, v), nil
+		}
+	}
+
+	if v := _version.FindString(path); v != "" {
+		return fmt.Sprintf("%s, version %s", path, v), nil
+	}
+
+	return fmt.Sprintf("%s, version unknown", path), nil
+}
+
+// checkRAC - version of rac with warning about passwords given to it.
+func checkRAC(ctx context.Context, path string) (string, error) {
+	version, err := executableVersion(ctx, path, "--version")
+	if err != nil {
+		return "", err
+	}
+
+	return version + "; " + _racPasswords, nil
+}
+
+// checkReachable - RAS accepts connections at address.
+func checkReachable(ctx context.Context, address string) (string, error) {
+	if address == "" {
+		return "", e.Wrap(e.ErrConfig, ErrEmptyClusterConnection)
+	}
+
+	d := net.Dialer{Timeout: _dialTimeout}
+
+	conn, err := d.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return "", e.Wrap(e.ErrConnectivity, fmt.Errorf("app - checkReachable - net.Dial: %w", err))
+	}
+
+	conn.Close()
+
+	return address, nil
+}
+
+// checkOutput - file can be created in directory, which has minFree bytes free at least.
+func checkOutput(dir string, minFree uint64) (string, error) {
+	if dir == "" {
+		return "", e.Wrap(e.ErrConfig, ErrEmptyOutput)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".1cctl-doctor-*")
+	if err != nil {
+		return "", e.Wrap(e.ErrConfig, fmt.Errorf("app - checkOutput - os.CreateTemp: %w", err))
+	}
+
+	tmp.Close()
+	os.Remove(tmp.Name())
+
+	free, err := freeSpace(dir)
+	if errors.Is(err, ErrFreeSpaceUnsupported) {
+		return fmt.Sprintf("%s is writable, free space is not checked on %s", dir, runtime.GOOS), nil
+	}
+
+	if err != nil {
+		return "", e.Wrap(e.ErrConfig, fmt.Errorf("app - checkOutput - freeSpace: %w", err))
+	}
+
+	if free < minFree {
+		return "", e.Wrap(e.ErrConfig, fmt.Errorf("%w: %s has %d MB free, %d MB required",
+			ErrLowFreeSpace, dir, free/_mb, minFree/_mb))
+	}
+
+	return fmt.Sprintf("%s is writable, %d MB free", dir, free/_mb), nil
+}
+
+// checkLog - log file can be opened the way logger does, it is created if missing.
+func checkLog(path string) (string, error) {
+	lf, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o664) //nolint:gomnd // file mode of logger
+	if err != nil {
+		return "", e.Wrap(e.ErrConfig, fmt.Errorf("app - checkLog - os.OpenFile: %w", err))
+	}
+
+	lf.Close()
+
+	return path + " is writable", nil
+}
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"context"
+	"math"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	e "github.com/antonmisa/1cctl_cli/internal/common/clierror"
+)
+
+func TestLocalChecks(t *testing.T) {
+	dir := t.TempDir()
+
+	exe := filepath.Join(dir, "8.3.22.1709", "1cv8")
+	require.NoError(t, os.MkdirAll(filepath.Dir(exe), 0o755))
+	require.NoError(t, os.WriteFile(exe, nil, 0o600))
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { ln.Close() })
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closed.Close()
+
+	cases := []struct {
+		name    string
+		run     func() (string, error)
+		details string
+		code    int
+	}{
+		{
+			name:    "Version of path",
+			run:     func() (string, error) { return executableVersion(context.Background(), exe) },
+			details: "version 8.3.22.1709",
+		},
+		{
+			name: "Missing executable",
+			run:  func() (string, error) { return executableVersion(context.Background(), filepath.Join(dir, "rac")) },
+			code: e.CodeConfig,
+		},
+		{
+			name: "Directory as executable",
+			run:  func() (string, error) { return executableVersion(context.Background(), dir) },
+			code: e.CodeConfig,
+		},
+		{
+			name:    "Output writable",
+			run:     func() (string, error) { return checkOutput(dir, 0) },
+			details: "is writable",
+		},
+		{
+			name: "Output missing",
+			run:  func() (string, error) { return checkOutput(filepath.Join(dir, "missing"), 0) },
+			code: e.CodeConfig,
+		},
+		{
+			name: "Output without free space",
+			run:  func() (string, error) { return checkOutput(dir, math.MaxUint64) },
+			code: e.CodeConfig,
+		},
+		{
+			name:    "Log writable",
+			run:     func() (string, error) { return checkLog(filepath.Join(dir, "app.log")) },
+			details: "is writable",
+		},
+		{
+			name: "Log directory missing",
+			run:  func() (string, error) { return checkLog(filepath.Join(dir, "missing", "app.log")) },
+			code: e.CodeConfig,
+		},
+		{
+			name:    "RAS reachable",
+			run:     func() (string, error) { return checkReachable(context.Background(), ln.Addr().String()) },
+			details: ln.Addr().String(),
+		},
+		{
+			name: "RAS unreachable",
+			run:  func() (string, error) { return checkReachable(context.Background(), closed.Addr().String()) },
+			code: e.CodeConnectivity,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			details, err := tc.run()
+
+			require.Equal(t, tc.code, e.Code(err), err)
+			require.Contains(t, details, tc.details)
+		})
+	}
+}
+
+func TestCheckRAC(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake rac is shell script")
+	}
+
+	rac := filepath.Join(t.TempDir(), "rac")
+	require.NoError(t, os.WriteFile(rac, []byte("#!/bin/sh\necho 8.3.24.1467\n"), 0o700)) //nolint:gosec // executable
+
+	details, err := checkRAC(context.Background(), rac)
+
+	require.NoError(t, err)
+	require.Contains(t, details, "version 8.3.24.1467")
+	require.Contains(t, details, "see them in process list")
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/antonmisa/1cctl_cli/config"
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+	"github.com/antonmisa/1cctl_cli/internal/usecase"
+	ucpipe "github.com/antonmisa/1cctl_cli/internal/usecase/pipe"
+	ucras "github.com/antonmisa/1cctl_cli/internal/usecase/ras"
+	"github.com/antonmisa/1cctl_cli/pkg/logger"
+	"github.com/antonmisa/1cctl_cli/pkg/pipe"
+)
+
+var ErrUnknownEngine = errors.New("app - unknown engine")
+
+// engine - builds CtrlPipe of clusters by configured engine.
+type engine func(clusterConnection string) usecase.CtrlPipe
+
+func newEngine(cfg *config.Config, l logger.Interface) (engine, error) {
+	loc, err := cfg.App.ServerLocation()
+	if err != nil {
+		return nil, fmt.Errorf("app - newEngine - ServerLocation: %w", err)
+	}
+
+	entity.ServerLocation = loc
+
+	switch cfg.App.Engine {
+	case config.EngineRAC, "":
+		p, err := pipe.New(cfg.App.PathToRAC)
+		if err != nil {
+			return nil, fmt.Errorf("app - newEngine - pipe.New: %w", err)
+		}
+
+		retry := retryPolicies(cfg.Retry)
+
+		return func(clusterConnection string) usecase.CtrlPipe {
+			return ucpipe.New(p, clusterConnection).WithRetry(retry, l)
+		}, nil
+	case config.EngineRAS:
+		return func(clusterConnection string) usecase.CtrlPipe {
+			return ucras.New(clusterConnection)
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEngine, cfg.App.Engine)
+	}
+}
+
+// retryPolicies - retry settings of every rac operation type.
+func retryPolicies(cfg config.Retry) map[string]entity.RetryPolicy {
+	rv := make(map[string]entity.RetryPolicy, len(ucpipe.Operations))
+
+	for _, op := range ucpipe.Operations {
+		r := cfg.For(op)
+
+		rv[op] = entity.RetryPolicy{
+			Attempts: r.Attempts,
+			Initial:  r.Initial,
+			Max:      r.Max,
+			Deadline: r.Deadline,
+		}
+	}
+
+	return rv
+}
@@ -0,0 +1,8 @@
+//go:build !windows && !linux && !darwin && !freebsd
+
+package app
+
+// freeSpace - not implemented for this platform, e.g. openbsd and netbsd have other layout of statfs.
+func freeSpace(_ string) (uint64, error) {
+	return 0, ErrFreeSpaceUnsupported
+}
@@ -0,0 +1,18 @@
+//go:build linux || darwin || freebsd
+
+package app
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// freeSpace - bytes available to user in directory.
+func freeSpace(dir string) (uint64, error) {
+	var st unix.Statfs_t
+
+	if err := unix.Statfs(dir, &st); err != nil {
+		return 0, err
+	}
+
+	return uint64(st.Bavail) * uint64(st.Bsize), nil //nolint:unconvert // types differ by platform
+}
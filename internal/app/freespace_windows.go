@@ -0,0 +1,23 @@
+//go:build windows
+
+package app
+
+import (
+	"golang.org/x/sys/windows"
+)
+
+// freeSpace - bytes available to user in directory.
+func freeSpace(dir string) (uint64, error) {
+	p, err := windows.UTF16PtrFromString(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	var free uint64
+
+	if err = windows.GetDiskFreeSpaceEx(p, &free, nil, nil); err != nil {
+		return 0, err
+	}
+
+	return free, nil
+}
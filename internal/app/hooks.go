@@ -0,0 +1,36 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// runHook - runs hook command with env of backup added, output of failed hook is put to error.
+// Command line is split by spaces, no shell is involved.
+func runHook(ctx context.Context, line string, env map[string]string) error {
+	args := strings.Fields(line)
+	if len(args) == 0 {
+		return nil
+	}
+
+	var out bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...) //nolint:gosec // hook is configured by administrator
+	cmd.Env = os.Environ()
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	for k, v := range env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("app - runHook - %s: %w: %s", args[0], err, strings.TrimSpace(out.String()))
+	}
+
+	return nil
+}
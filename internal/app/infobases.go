@@ -0,0 +1,61 @@
+package app
+
+import (
+	"context"
+
+	"github.com/antonmisa/1cctl_cli/config"
+	"github.com/antonmisa/1cctl_cli/internal/controller/cli"
+	"github.com/antonmisa/1cctl_cli/internal/usecase"
+	"github.com/antonmisa/1cctl_cli/pkg/logger"
+)
+
+// RunInfobases - infobases command group: list, show.
+func RunInfobases(cfg *config.Config, args []string) int {
+	var (
+		f         targetFlags
+		p         cli.ClusterParams
+		inventory inventoryFlags
+	)
+
+	sub, fs, err := subcommand("infobases", args, "list", "show")
+	if err != nil {
+		return fail(err)
+	}
+
+	switch sub {
+	case "list":
+		registerCluster(fs, cfg, &f.clusterConnection, &p)
+		inventory.register(fs)
+	case "show":
+		f.register(fs, cfg, "")
+		registerFormat(fs, &f.p.Format)
+	}
+
+	if err = fs.Parse(args[1:]); err != nil {
+		return flagsCode(err)
+	}
+
+	if sub == "list" {
+		err = inventory.apply(cfg, fs)
+	} else {
+		err = f.apply(cfg, fs)
+	}
+
+	if err != nil {
+		return fail(err)
+	}
+
+	if inventory.all {
+		return runFleet(cfg, func(fleet *cli.Fleet) error {
+			return fleet.InfobasesList(p)
+		})
+	}
+
+	return runCtrl(cfg, f.clusterConnection, false, func(_ context.Context, _ logger.Interface, _ usecase.Ctrl, ctrl *cli.Ctrl1CCLI) error {
+		if sub == "show" {
+			return ctrl.InfobasesShow(f.p)
+		}
+
+		return ctrl.InfobasesList(p)
+	})
+}
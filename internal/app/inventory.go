@@ -0,0 +1,219 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/antonmisa/1cctl_cli/config"
+	e "github.com/antonmisa/1cctl_cli/internal/common/clierror"
+	"github.com/antonmisa/1cctl_cli/internal/controller/cli"
+	"github.com/antonmisa/1cctl_cli/internal/usecase"
+	"github.com/antonmisa/1cctl_cli/pkg/logger"
+)
+
+var (
+	ErrListOnly         = errors.New("app - --all-clusters is supported by list commands only")
+	ErrClusterAmbiguous = errors.New("app - --cluster and --all-clusters are mutually exclusive")
+	ErrEmptyInventory   = errors.New("app - no clusters in config inventory")
+)
+
+// inventoryFlags - selection of clusters from config inventory.
+type inventoryFlags struct {
+	alias string
+	all   bool
+}
+
+func (f *inventoryFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.alias, "cluster", "", "cluster alias from config inventory")
+	fs.BoolVar(&f.all, "all-clusters", false, "list on all clusters of config inventory")
+
+	RegisterPwdSources(fs)
+}
+
+// apply - fills flags which are not set explicitly from inventory entry and password sources.
+func (f *inventoryFlags) apply(cfg *config.Config, fs *flag.FlagSet) error {
+	if f.alias != "" && f.all {
+		return e.Wrap(e.ErrUsage, ErrClusterAmbiguous)
+	}
+
+	if err := ApplyCluster(cfg, fs, f.alias); err != nil {
+		return e.Wrap(e.ErrConfig, err)
+	}
+
+	return e.Wrap(e.ErrConfig, ResolvePasswords(context.Background(), cfg, fs))
+}
+
+// ApplyCluster - takes connection flags from inventory entry of alias, explicitly set flags win.
+func ApplyCluster(cfg *config.Config, fs *flag.FlagSet, alias string) error {
+	if alias == "" {
+		return nil
+	}
+
+	c, err := cfg.ClusterByAlias(alias)
+	if err != nil {
+		return fmt.Errorf("app - ApplyCluster - cfg.ClusterByAlias: %w", err)
+	}
+
+	return setFlags(fs, map[string]string{
+		"clusterConnection": c.RAS,
+		"clusterName":       c.Name,
+		"agentAdmin":        c.AgentAdmin,
+		"agentPwd":          c.AgentPwd,
+		"clusterAdmin":      c.Admin,
+		"clusterPwd":        c.Pwd,
+		"agentPwdFrom":      c.AgentPwdFrom,
+		"clusterPwdFrom":    c.PwdFrom,
+		"output":            c.Output,
+	})
+}
+
+// ApplyProfile - takes backup flags from profile, explicitly set flags win, and lock code, engine,
+// retention and hooks of config from it. Flag cluster is set to inventory alias of profile.
+func ApplyProfile(cfg *config.Config, fs *flag.FlagSet, name string) error {
+	if name == "" {
+		return nil
+	}
+
+	p, err := cfg.ProfileByName(name)
+	if err != nil {
+		return fmt.Errorf("app - ApplyProfile - cfg.ProfileByName: %w", err)
+	}
+
+	cfg.App.LockCode, cfg.App.Engine = p.LockCode, p.Engine
+	cfg.Retention, cfg.Hooks = p.Retention, p.Hooks
+
+	return setFlags(fs, map[string]string{
+		"cluster":           p.Cluster,
+		"clusterConnection": p.RAS,
+		"clusterName":       p.Name,
+		"infobase":          p.Infobase,
+		"agentAdmin":        p.AgentAdmin,
+		"agentPwd":          p.AgentPwd,
+		"agentPwdFrom":      p.AgentPwdFrom,
+		"clusterAdmin":      p.Admin,
+		"clusterPwd":        p.Pwd,
+		"clusterPwdFrom":    p.PwdFrom,
+		"infobaseUser":      p.InfobaseUser,
+		"infobasePwd":       p.InfobasePwd,
+		"infobasePwdFrom":   p.InfobasePwdFrom,
+		"output":            p.Output,
+	})
+}
+
+// setFlags - sets flags of fs which are not set yet, empty values are skipped.
+func setFlags(fs *flag.FlagSet, values map[string]string) error {
+	set := make(map[string]bool)
+
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	for name, value := range values {
+		if value == "" || set[name] || fs.Lookup(name) == nil {
+			continue
+		}
+
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("app - setFlags - fs.Set: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// runFleet - builds controllers of all clusters in inventory, runs fn by them
+// and returns exit code of process by category of failure, see clierror.
+func runFleet(cfg *config.Config, fn func(f *cli.Fleet) error) int {
+	l, err := newLogger(cfg)
+	if err != nil {
+		return fail(e.Wrap(e.ErrConfig, fmt.Errorf("app - logger.New: %w", err)))
+	}
+
+	f, err := newFleet(signalContext(l), cfg, l)
+	if err == nil {
+		err = fn(f)
+	}
+
+	if err != nil {
+		l.Error(err)
+
+		return e.Code(err)
+	}
+
+	return e.CodeOK
+}
+
+// newFleet - builds controllers of all clusters in inventory, each bound to its RAS.
+func newFleet(ctx context.Context, cfg *config.Config, l logger.Interface) (*cli.Fleet, error) {
+	aliases := cfg.Aliases()
+	if len(aliases) == 0 {
+		return nil, e.Wrap(e.ErrConfig, ErrEmptyInventory)
+	}
+
+	newPipe, err := newEngine(cfg, l)
+	if err != nil {
+		return nil, e.Wrap(e.ErrConfig, fmt.Errorf("app - newFleet - newEngine: %w", err))
+	}
+
+	members := make([]cli.Member, 0, len(aliases))
+
+	for _, alias := range aliases {
+		c, err := cfg.ClusterByAlias(alias)
+		if err != nil {
+			return nil, e.Wrap(e.ErrConfig, fmt.Errorf("app - newFleet - cfg.ClusterByAlias: %w", err))
+		}
+
+		if c.RAS == "" {
+			return nil, e.Wrap(e.ErrConfig, fmt.Errorf("%w: %s", ErrEmptyClusterConnection, alias))
+		}
+
+		if err = memberPasswords(ctx, cfg, alias, &c); err != nil {
+			return nil, e.Wrap(e.ErrConfig, err)
+		}
+
+		members = append(members, cli.Member{
+			Alias:        alias,
+			C:            usecase.New(newPipe(c.RAS), nil),
+			ClusterName:  c.Name,
+			AgentAdmin:   c.AgentAdmin,
+			AgentPwd:     c.AgentPwd,
+			ClusterAdmin: c.Admin,
+			ClusterPwd:   c.Pwd,
+		})
+	}
+
+	return cli.NewFleet(ctx, members, _secrets.Writer(os.Stdout)), nil
+}
+
+// memberPasswords - fills passwords of inventory entry which are not set from their sources.
+func memberPasswords(ctx context.Context, cfg *config.Config, alias string, c *config.Cluster) error {
+	if c.AgentAdmin != "" && c.AgentPwd == "" && c.AgentPwdFrom != "" {
+		pwd, err := resolvePwd(ctx, c.AgentPwdFrom, "central server administrator "+c.AgentAdmin)
+		if err != nil {
+			return fmt.Errorf("app - memberPasswords - %s agent: %w", alias, err)
+		}
+
+		c.AgentPwd = pwd
+	}
+
+	source := c.PwdFrom
+	if source == "" {
+		source = cfg.Credentials.ClusterPwdFrom
+	}
+
+	if c.Admin != "" && c.Pwd == "" && source != "" {
+		pwd, err := resolvePwd(ctx, source, "cluster administrator "+c.Admin)
+		if err != nil {
+			return fmt.Errorf("app - memberPasswords - %s cluster: %w", alias, err)
+		}
+
+		c.Pwd = pwd
+	}
+
+	_secrets.Add(c.AgentPwd, c.Pwd)
+
+	return nil
+}
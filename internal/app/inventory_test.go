@@ -0,0 +1,60 @@
+package app
+
+import (
+	"flag"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/antonmisa/1cctl_cli/config"
+)
+
+func TestApplyProfile(t *testing.T) {
+	cfg := &config.Config{
+		App:       config.App{LockCode: "12345", Engine: config.EngineRAC},
+		Retention: config.Retention{Keep: 7},
+		Clusters: map[string]config.Cluster{
+			"prod": {RAS: "srv-1c:1545", Name: "srv-1c:1541", Admin: "admin", Output: "D:/backup/prod"},
+		},
+		Profiles: map[string]config.Profile{
+			"buh_prod": {
+				Cluster:      "prod",
+				Infobase:     "Buh",
+				InfobaseUser: "backup",
+				Output:       "D:/backup/buh",
+				Engine:       config.EngineRAS,
+				Retention:    config.Retention{MaxAge: 720 * time.Hour},
+			},
+		},
+	}
+
+	fs := flag.NewFlagSet("ctrl", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	var cluster, clusterConnection, infobase, infobaseUser, output string
+
+	fs.StringVar(&cluster, "cluster", "", "")
+	fs.StringVar(&clusterConnection, "clusterConnection", "localhost:1545", "")
+	fs.StringVar(&infobase, "infobase", "", "")
+	fs.StringVar(&infobaseUser, "infobaseUser", "robot", "")
+	fs.StringVar(&output, "output", "", "")
+
+	require.NoError(t, fs.Parse([]string{"--output", "E:/adhoc"}))
+
+	require.NoError(t, ApplyProfile(cfg, fs, "buh_prod"))
+	require.NoError(t, ApplyCluster(cfg, fs, cluster))
+
+	require.Equal(t, "prod", cluster)
+	require.Equal(t, "srv-1c:1545", clusterConnection)
+	require.Equal(t, "Buh", infobase)
+	require.Equal(t, "backup", infobaseUser)
+	require.Equal(t, "E:/adhoc", output)
+
+	require.Equal(t, "12345", cfg.App.LockCode)
+	require.Equal(t, config.EngineRAS, cfg.App.Engine)
+	require.Equal(t, config.Retention{Keep: 7, MaxAge: 720 * time.Hour}, cfg.Retention)
+
+	require.ErrorIs(t, ApplyProfile(cfg, fs, "zup"), config.ErrUnknownProfile)
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"time"
+
+	"github.com/antonmisa/1cctl_cli/config"
+	"github.com/antonmisa/1cctl_cli/internal/controller/cli"
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+	"github.com/antonmisa/1cctl_cli/internal/usecase"
+	"github.com/antonmisa/1cctl_cli/pkg/logger"
+)
+
+// lockFlags - infobase to lock and lock settings, settings not given are taken from config
+// after profile is applied, as profile may have lock code of its own.
+type lockFlags struct {
+	targetFlags
+
+	code     string
+	message  string
+	window   time.Duration
+	keepJobs bool
+}
+
+func (f *lockFlags) register(fs *flag.FlagSet, cfg *config.Config) {
+	f.targetFlags.register(fs, cfg, "")
+	fs.StringVar(&f.code, "code", "", "permission code to bypass lock, taken from config or profile if empty")
+	fs.StringVar(&f.message, "message", "", "message shown to users, {infobase}, {from}, {to} are replaced, taken from config if empty")
+	fs.DurationVar(&f.window, "window", 0, "how long sessions are denied, expected duration with margin from config if zero")
+	fs.BoolVar(&f.keepJobs, "keepScheduledJobs", false, "do not deny scheduled jobs")
+}
+
+// policy - lock of config overridden by flags given, to be called after apply.
+func (f *lockFlags) policy(cfg *config.Config) entity.LockPolicy {
+	policy := lockPolicy(cfg, f.p.Infobase)
+
+	policy.ScheduledJobsDeny = policy.ScheduledJobsDeny && !f.keepJobs
+
+	if f.code != "" {
+		policy.Code = f.code
+	}
+
+	if f.message != "" {
+		policy.Message = f.message
+	}
+
+	if f.window > 0 {
+		policy.Window = f.window
+	}
+
+	return policy
+}
+
+// RunLock - denies new sessions of infobase, lock settings not given are taken from config.
+func RunLock(cfg *config.Config, args []string) int {
+	var f lockFlags
+
+	fs := newFlagSet("lock")
+
+	f.register(fs, cfg)
+
+	if err := fs.Parse(args); err != nil {
+		return flagsCode(err)
+	}
+
+	if err := f.apply(cfg, fs); err != nil {
+		return fail(err)
+	}
+
+	policy := f.policy(cfg)
+
+	return runCtrl(cfg, f.clusterConnection, false, func(_ context.Context, _ logger.Interface, _ usecase.Ctrl, ctrl *cli.Ctrl1CCLI) error {
+		return ctrl.Lock(f.p, policy)
+	})
+}
+
+// RunUnlock - allows sessions and scheduled jobs of infobase.
+func RunUnlock(cfg *config.Config, args []string) int {
+	var f targetFlags
+
+	fs := newFlagSet("unlock")
+
+	f.register(fs, cfg, "")
+
+	if err := fs.Parse(args); err != nil {
+		return flagsCode(err)
+	}
+
+	if err := f.apply(cfg, fs); err != nil {
+		return fail(err)
+	}
+
+	return runCtrl(cfg, f.clusterConnection, false, func(_ context.Context, _ logger.Interface, _ usecase.Ctrl, ctrl *cli.Ctrl1CCLI) error {
+		return ctrl.Unlock(f.p)
+	})
+}
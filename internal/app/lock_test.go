@@ -0,0 +1,64 @@
+package app
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/antonmisa/1cctl_cli/config"
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+)
+
+func TestLockPolicy(t *testing.T) {
+	cases := []struct {
+		name string
+		args []string
+		want entity.LockPolicy
+	}{
+		{
+			name: "Config",
+			args: []string{"--infobase", "Zup"},
+			want: entity.LockPolicy{Code: "12345", Message: "Backup", Window: time.Hour, ScheduledJobsDeny: true},
+		},
+		{
+			name: "Code of profile",
+			args: []string{"--profile", "buh_prod"},
+			want: entity.LockPolicy{Code: "777", Message: "Backup", Window: time.Hour, ScheduledJobsDeny: true},
+		},
+		{
+			name: "Flags override profile",
+			args: []string{"--profile", "buh_prod", "--code", "1", "--message", "Update", "--window", "2h", "--keepScheduledJobs"},
+			want: entity.LockPolicy{Code: "1", Message: "Update", Window: 2 * time.Hour},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &config.Config{
+				App:  config.App{LockCode: "12345"},
+				Lock: config.Lock{Message: "Backup", ExpectedDuration: 45 * time.Minute, Margin: 15 * time.Minute},
+				Profiles: map[string]config.Profile{
+					"buh_prod": {Infobase: "Buh", LockCode: "777"},
+				},
+			}
+
+			var f lockFlags
+
+			fs := newFlagSet("lock")
+			fs.SetOutput(io.Discard)
+
+			f.register(fs, cfg)
+
+			require.NoError(t, fs.Parse(tc.args))
+			require.NoError(t, f.apply(cfg, fs))
+
+			require.Equal(t, tc.want, f.policy(cfg))
+		})
+	}
+}
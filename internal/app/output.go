@@ -0,0 +1,33 @@
+package app
+
+import (
+	"flag"
+	"strings"
+
+	"github.com/antonmisa/1cctl_cli/internal/controller/cli"
+)
+
+// registerFormat - output format flag, -o is its short form and --format is kept for scripts written before.
+func registerFormat(fs *flag.FlagSet, format *string) {
+	fs.StringVar(format, "output-format", cli.FormatTable, "output format: table, json, yaml or csv")
+	fs.StringVar(format, "o", cli.FormatTable, "short for --output-format")
+	fs.StringVar(format, "format", cli.FormatTable, "deprecated, use --output-format")
+}
+
+// registerOutput - flags of listing output: format, columns and sorting.
+func registerOutput(fs *flag.FlagSet, o *cli.Output) {
+	registerFormat(fs, &o.Format)
+
+	fs.Func("columns", "comma separated columns of table and csv, e.g. user,host,last-active; all if empty", func(s string) error {
+		o.Columns = nil
+
+		for _, c := range strings.Split(s, ",") {
+			if c = strings.TrimSpace(c); c != "" {
+				o.Columns = append(o.Columns, c)
+			}
+		}
+
+		return nil
+	})
+	fs.StringVar(&o.Sort, "sort", "", "column to sort by, prefixed by - for descending order, e.g. -last-active")
+}
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	"github.com/antonmisa/1cctl_cli/config"
+	e "github.com/antonmisa/1cctl_cli/internal/common/clierror"
+	"github.com/antonmisa/1cctl_cli/internal/controller/cli"
+	"github.com/antonmisa/1cctl_cli/internal/usecase"
+	"github.com/antonmisa/1cctl_cli/pkg/logger"
+)
+
+var ErrEmptyInput = errors.New("app - dump file is not given")
+
+// RunRestore - replaces infobase by dump, returns exit code of process by category of failure, see clierror.
+func RunRestore(cfg *config.Config, args []string) int {
+	var (
+		f     targetFlags
+		input string
+		yes   bool
+	)
+
+	fs := newFlagSet("restore")
+
+	f.register(fs, cfg, "robot")
+	fs.StringVar(&input, "input", "", "dump file infobase is restored from")
+	fs.BoolVar(&yes, "yes", false, "do not ask for confirmation")
+
+	if err := fs.Parse(args); err != nil {
+		return flagsCode(err)
+	}
+
+	if err := f.apply(cfg, fs); err != nil {
+		return fail(err)
+	}
+
+	return runCtrl(cfg, f.clusterConnection, true, func(_ context.Context, _ logger.Interface, _ usecase.Ctrl, ctrl *cli.Ctrl1CCLI) error {
+		if f.p.Infobase == "" {
+			return e.Wrap(e.ErrConfig, ErrEmptyClusterOrInfobase)
+		}
+
+		if input == "" {
+			return e.Wrap(e.ErrConfig, ErrEmptyInput)
+		}
+
+		graceful, drain := exclusiveOptions(cfg)
+
+		return ctrl.Restore(f.p, lockPolicy(cfg, f.p.Infobase), input, graceful, drain, yes)
+	})
+}
@@ -0,0 +1,230 @@
+package app
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/antonmisa/1cctl_cli/config"
+	e "github.com/antonmisa/1cctl_cli/internal/common/clierror"
+	"github.com/antonmisa/1cctl_cli/pkg/secrets"
+)
+
+var (
+	ErrSecretName    = errors.New("app - secret name is required")
+	ErrSecretsExist  = errors.New("app - secrets file already exists")
+	ErrKeyFileExists = errors.New("app - key file already exists")
+)
+
+// secretsFlags - where secrets file and its key are.
+type secretsFlags struct {
+	file    string
+	keyFile string
+}
+
+// register - flags defaulting to app.secrets_path and app.secrets_key_file of config.
+func (f *secretsFlags) register(fs *flag.FlagSet, file, keyFile string) {
+	fs.StringVar(&f.file, "file", file, "encrypted secrets file (config app.secrets_path, env SECRETS_PATH)")
+	fs.StringVar(&f.keyFile, "keyFile", keyFile,
+		"file with key, env "+secrets.EnvKey+" wins (config app.secrets_key_file, env "+secrets.EnvKeyFile+")")
+}
+
+// RunSecrets - secrets command group: init, set, delete, list.
+func RunSecrets(args []string) int {
+	var f secretsFlags
+
+	sub, fs, err := subcommand("secrets", args, "init", "set", "delete", "list")
+	if err != nil {
+		return fail(err)
+	}
+
+	file, keyFile, err := config.SecretsLocation()
+	if err != nil {
+		return fail(e.Wrap(e.ErrConfig, err))
+	}
+
+	f.register(fs, file, keyFile)
+
+	if err = fs.Parse(args[1:]); err != nil {
+		return flagsCode(err)
+	}
+
+	switch sub {
+	case "init":
+		err = secretsInit(f, os.Stdout)
+	case "set":
+		err = secretsEdit(f, fs.Arg(0), func(s *secrets.Store, name string) error {
+			value, err := readSecret(name)
+			if err != nil {
+				return err
+			}
+
+			return s.Set(name, value)
+		})
+	case "delete":
+		err = secretsEdit(f, fs.Arg(0), func(s *secrets.Store, name string) error {
+			if !s.Delete(name) {
+				return fmt.Errorf("%w: %s", secrets.ErrUnknownSecret, name)
+			}
+
+			return nil
+		})
+	case "list":
+		err = secretsList(f, os.Stdout)
+	}
+
+	switch {
+	case err == nil:
+		return e.CodeOK
+	case errors.Is(err, ErrSecretName):
+		return fail(e.Wrap(e.ErrUsage, err))
+	}
+
+	return fail(e.Wrap(e.ErrConfig, err))
+}
+
+// secretsInit - creates empty secrets file, key is generated unless given.
+// New key is written to key file if it is set, printed out otherwise.
+func secretsInit(f secretsFlags, out io.Writer) error {
+	if _, err := os.Stat(f.file); err == nil {
+		return fmt.Errorf("%w: %s", ErrSecretsExist, f.file)
+	}
+
+	key, err := secrets.LoadKey(f.keyFile)
+
+	switch {
+	case err == nil:
+	case errors.Is(err, secrets.ErrNoKey), errors.Is(err, os.ErrNotExist):
+		if key, err = secrets.NewKey(); err != nil {
+			return err
+		}
+
+		if err = writeKey(f.keyFile, key, out); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("app - secretsInit - secrets.LoadKey: %w", err)
+	}
+
+	if err = secrets.New(f.file, key).Save(); err != nil {
+		return fmt.Errorf("app - secretsInit - Save: %w", err)
+	}
+
+	fmt.Fprintf(out, "secrets file %s is created\n", f.file)
+
+	return nil
+}
+
+// writeKey - key to file readable by owner only or to out, if no file is given.
+func writeKey(keyFile string, key secrets.Key, out io.Writer) error {
+	if keyFile == "" {
+		fmt.Fprintf(out, "key, keep it in env %s: %s\n", secrets.EnvKey, key)
+
+		return nil
+	}
+
+	kf, err := os.OpenFile(keyFile, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("%w: %s", ErrKeyFileExists, keyFile)
+		}
+
+		return fmt.Errorf("app - writeKey - os.OpenFile: %w", err)
+	}
+
+	if _, err = fmt.Fprintln(kf, key); err != nil {
+		kf.Close()
+
+		return fmt.Errorf("app - writeKey - write: %w", err)
+	}
+
+	if err = kf.Close(); err != nil {
+		return fmt.Errorf("app - writeKey - close: %w", err)
+	}
+
+	fmt.Fprintf(out, "key is written to %s\n", keyFile)
+
+	return nil
+}
+
+// secretsEdit - opens secrets file, changes secret by fn and saves it back.
+func secretsEdit(f secretsFlags, name string, fn func(s *secrets.Store, name string) error) error {
+	if name == "" {
+		return ErrSecretName
+	}
+
+	s, err := openSecrets(f)
+	if err != nil {
+		return err
+	}
+
+	if err = fn(s, name); err != nil {
+		return err
+	}
+
+	if err = s.Save(); err != nil {
+		return fmt.Errorf("app - secretsEdit - Save: %w", err)
+	}
+
+	return nil
+}
+
+// secretsList - names of secrets, values are never printed.
+func secretsList(f secretsFlags, out io.Writer) error {
+	s, err := openSecrets(f)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range s.Names() {
+		fmt.Fprintln(out, secrets.RefPrefix+name)
+	}
+
+	return nil
+}
+
+func openSecrets(f secretsFlags) (*secrets.Store, error) {
+	key, err := secrets.LoadKey(f.keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("app - openSecrets - secrets.LoadKey: %w", err)
+	}
+
+	s, err := secrets.Open(f.file, key)
+	if err != nil {
+		return nil, fmt.Errorf("app - openSecrets - secrets.Open: %w", err)
+	}
+
+	return s, nil
+}
+
+// readSecret - value typed in terminal without echo, first line of stdin if it is not a terminal.
+func readSecret(name string) (string, error) {
+	fd := int(os.Stdin.Fd())
+
+	if term.IsTerminal(fd) {
+		fmt.Fprintf(os.Stderr, "Value of %s: ", name)
+
+		b, err := term.ReadPassword(fd)
+
+		fmt.Fprintln(os.Stderr)
+
+		if err != nil {
+			return "", fmt.Errorf("app - readSecret - term.ReadPassword: %w", err)
+		}
+
+		return string(b), nil
+	}
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("app - readSecret - ReadString: %w", err)
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
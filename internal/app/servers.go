@@ -0,0 +1,93 @@
+package app
+
+import (
+	"context"
+	"flag"
+
+	"github.com/antonmisa/1cctl_cli/config"
+	"github.com/antonmisa/1cctl_cli/internal/controller/cli"
+	"github.com/antonmisa/1cctl_cli/internal/usecase"
+	"github.com/antonmisa/1cctl_cli/pkg/logger"
+)
+
+// RunProcesses - processes command group: list.
+func RunProcesses(cfg *config.Config, args []string) int {
+	var (
+		clusterConnection string
+		p                 cli.ClusterParams
+		inventory         inventoryFlags
+	)
+
+	_, fs, err := subcommand("processes", args, "list")
+	if err != nil {
+		return fail(err)
+	}
+
+	registerCluster(fs, cfg, &clusterConnection, &p)
+	inventory.register(fs)
+
+	if err = fs.Parse(args[1:]); err != nil {
+		return flagsCode(err)
+	}
+
+	if err = inventory.apply(cfg, fs); err != nil {
+		return fail(err)
+	}
+
+	if inventory.all {
+		return runFleet(cfg, func(fleet *cli.Fleet) error {
+			return fleet.ProcessesList(p)
+		})
+	}
+
+	return runCtrl(cfg, clusterConnection, false, func(_ context.Context, _ logger.Interface, _ usecase.Ctrl, ctrl *cli.Ctrl1CCLI) error {
+		return ctrl.ProcessesList(p)
+	})
+}
+
+// RunServers - servers command group: list.
+func RunServers(cfg *config.Config, args []string) int {
+	var (
+		clusterConnection string
+		p                 cli.ClusterParams
+		inventory         inventoryFlags
+	)
+
+	_, fs, err := subcommand("servers", args, "list")
+	if err != nil {
+		return fail(err)
+	}
+
+	registerCluster(fs, cfg, &clusterConnection, &p)
+	inventory.register(fs)
+
+	if err = fs.Parse(args[1:]); err != nil {
+		return flagsCode(err)
+	}
+
+	if err = inventory.apply(cfg, fs); err != nil {
+		return fail(err)
+	}
+
+	if inventory.all {
+		return runFleet(cfg, func(fleet *cli.Fleet) error {
+			return fleet.ServersList(p)
+		})
+	}
+
+	return runCtrl(cfg, clusterConnection, false, func(_ context.Context, _ logger.Interface, _ usecase.Ctrl, ctrl *cli.Ctrl1CCLI) error {
+		return ctrl.ServersList(p)
+	})
+}
+
+// registerCluster - flags to address cluster.
+func registerCluster(fs *flag.FlagSet, cfg *config.Config, clusterConnection *string, p *cli.ClusterParams) {
+	fs.StringVar(clusterConnection, "clusterConnection", "localhost:1545", "cluster host:port to connect")
+	fs.StringVar(&p.ClusterName, "clusterName", "localhost:1541", "cluster host:port to operate on")
+	fs.StringVar(&p.AgentAdmin, "agentAdmin", cfg.Agent.Admin, "central server admin name")
+	fs.StringVar(&p.AgentPwd, "agentPwd", cfg.Agent.Pwd, "central server admin password")
+	fs.StringVar(&p.ClusterAdmin, "clusterAdmin", "", "cluster admin name")
+	fs.StringVar(&p.ClusterPwd, "clusterPwd", "", "cluster password")
+
+	registerOutput(fs, &p.Output)
+}
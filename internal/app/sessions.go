@@ -0,0 +1,176 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"time"
+
+	"github.com/antonmisa/1cctl_cli/config"
+	e "github.com/antonmisa/1cctl_cli/internal/common/clierror"
+	"github.com/antonmisa/1cctl_cli/internal/controller/cli"
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+	"github.com/antonmisa/1cctl_cli/internal/usecase"
+	"github.com/antonmisa/1cctl_cli/pkg/logger"
+)
+
+// commonFlags - flags shared by sessions and connections commands.
+type commonFlags struct {
+	clusterConnection string
+	clusterName       string
+	agentAdmin        string
+	agentPwd          string
+	clusterAdmin      string
+	clusterPwd        string
+	infobase          string
+	host              string
+	appID             string
+	output            cli.Output
+	dryRun            bool
+	yes               bool
+
+	inventory inventoryFlags
+}
+
+func (f *commonFlags) register(fs *flag.FlagSet, cfg *config.Config) {
+	fs.StringVar(&f.clusterConnection, "clusterConnection", "localhost:1545", "cluster host:port to connect")
+	fs.StringVar(&f.clusterName, "clusterName", "localhost:1541", "cluster host:port to operate on")
+	fs.StringVar(&f.agentAdmin, "agentAdmin", cfg.Agent.Admin, "central server admin name")
+	fs.StringVar(&f.agentPwd, "agentPwd", cfg.Agent.Pwd, "central server admin password")
+	fs.StringVar(&f.clusterAdmin, "clusterAdmin", "", "cluster admin name")
+	fs.StringVar(&f.clusterPwd, "clusterPwd", "", "cluster password")
+	fs.StringVar(&f.infobase, "infobase", "", "infobase name, all infobases of cluster if empty")
+	fs.StringVar(&f.host, "host", "", "filter by client host")
+	fs.StringVar(&f.appID, "app-id", "", "filter by application (1CV8C, Designer, COMConnection, ...)")
+	fs.BoolVar(&f.dryRun, "dry-run", false, "only print what would be done")
+	fs.BoolVar(&f.yes, "yes", false, "do not ask for confirmation")
+
+	registerOutput(fs, &f.output)
+	f.inventory.register(fs)
+}
+
+// RunSessions - sessions command group: list, kill.
+func RunSessions(cfg *config.Config, args []string) int {
+	var (
+		f    commonFlags
+		user string
+		msg  string
+		idle time.Duration
+		dur  time.Duration
+	)
+
+	sub, fs, err := subcommand("sessions", args, "list", "kill")
+	if err != nil {
+		return fail(err)
+	}
+
+	f.register(fs, cfg)
+	fs.StringVar(&user, "user", "", "filter by user name")
+	fs.DurationVar(&idle, "idle", 0, "filter sessions idle at least this long (since last activity), e.g. 30m")
+	fs.DurationVar(&dur, "min-duration", 0, "filter sessions started at least this long ago, e.g. 2h")
+	fs.StringVar(&msg, "message", "", "message shown to users of terminated sessions")
+
+	if err = fs.Parse(args[1:]); err != nil {
+		return flagsCode(err)
+	}
+
+	if err = f.inventory.apply(cfg, fs); err != nil {
+		return fail(err)
+	}
+
+	p := cli.SessionsParams{
+		ClusterName:  f.clusterName,
+		Infobase:     f.infobase,
+		AgentAdmin:   f.agentAdmin,
+		AgentPwd:     f.agentPwd,
+		ClusterAdmin: f.clusterAdmin,
+		ClusterPwd:   f.clusterPwd,
+		Filter: entity.SessionFilter{
+			UserName:    user,
+			Host:        f.host,
+			AppID:       f.appID,
+			IdleFor:     idle,
+			MinDuration: dur,
+		},
+		Message: msg,
+		Output:  f.output,
+		DryRun:  f.dryRun,
+		Yes:     f.yes,
+	}
+
+	if f.inventory.all {
+		if sub != "list" {
+			return fail(e.Wrap(e.ErrUsage, ErrListOnly))
+		}
+
+		return runFleet(cfg, func(fleet *cli.Fleet) error {
+			return fleet.SessionsList(p)
+		})
+	}
+
+	return runCtrl(cfg, f.clusterConnection, false, func(_ context.Context, _ logger.Interface, _ usecase.Ctrl, ctrl *cli.Ctrl1CCLI) error {
+		if sub == "kill" {
+			return ctrl.SessionsKill(p)
+		}
+
+		return ctrl.SessionsList(p)
+	})
+}
+
+// RunConnections - connections command group: list, disconnect.
+func RunConnections(cfg *config.Config, args []string) int {
+	var (
+		f   commonFlags
+		dur time.Duration
+	)
+
+	sub, fs, err := subcommand("connections", args, "list", "disconnect")
+	if err != nil {
+		return fail(err)
+	}
+
+	f.register(fs, cfg)
+	fs.DurationVar(&dur, "min-duration", 0, "filter connections established at least this long ago, e.g. 2h")
+
+	if err = fs.Parse(args[1:]); err != nil {
+		return flagsCode(err)
+	}
+
+	if err = f.inventory.apply(cfg, fs); err != nil {
+		return fail(err)
+	}
+
+	p := cli.ConnectionsParams{
+		ClusterName:  f.clusterName,
+		Infobase:     f.infobase,
+		AgentAdmin:   f.agentAdmin,
+		AgentPwd:     f.agentPwd,
+		ClusterAdmin: f.clusterAdmin,
+		ClusterPwd:   f.clusterPwd,
+		Filter: entity.ConnectionFilter{
+			Host:        f.host,
+			AppID:       f.appID,
+			MinDuration: dur,
+		},
+		Output: f.output,
+		DryRun: f.dryRun,
+		Yes:    f.yes,
+	}
+
+	if f.inventory.all {
+		if sub != "list" {
+			return fail(e.Wrap(e.ErrUsage, ErrListOnly))
+		}
+
+		return runFleet(cfg, func(fleet *cli.Fleet) error {
+			return fleet.ConnectionsList(p)
+		})
+	}
+
+	return runCtrl(cfg, f.clusterConnection, false, func(_ context.Context, _ logger.Interface, _ usecase.Ctrl, ctrl *cli.Ctrl1CCLI) error {
+		if sub == "disconnect" {
+			return ctrl.ConnectionsDisconnect(p)
+		}
+
+		return ctrl.ConnectionsList(p)
+	})
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/debug"
+
+	e "github.com/antonmisa/1cctl_cli/internal/common/clierror"
+)
+
+// Version - version of build, set by -ldflags "-X github.com/antonmisa/1cctl_cli/internal/app.Version=v1.2.3".
+var Version = ""
+
+// RunVersion - prints version of build, revision it is built of and Go version.
+func RunVersion(args []string) int {
+	fs := newFlagSet("version")
+
+	if err := fs.Parse(args); err != nil {
+		return flagsCode(err)
+	}
+
+	fmt.Fprintln(os.Stdout, version())
+
+	return e.CodeOK
+}
+
+// version - version of build, module version or revision of source if it is not set.
+func version() string {
+	v, revision := Version, ""
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if v == "" && info.Main.Version != "(devel)" {
+			v = info.Main.Version
+		}
+
+		for _, s := range info.Settings {
+			if s.Key == "vcs.revision" {
+				revision = s.Value
+			}
+		}
+	}
+
+	if v == "" {
+		v = "dev"
+	}
+
+	if revision != "" {
+		v += " (" + revision + ")"
+	}
+
+	return fmt.Sprintf("ctrl %s %s %s/%s", v, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+}
@@ -0,0 +1,92 @@
+package clierror
+
+import "errors"
+
+// Exit codes of process by category of failure, schedulers rely on them, so they are never renumbered.
+const (
+	CodeOK             = 0
+	CodeUnknown        = 1  // failure of no category
+	CodeConfig         = 2  // config file, flags, paths to executables, names of cluster or infobase
+	CodeConnectivity   = 3  // central server or RAS is unreachable
+	CodeAuth           = 4  // credentials are wrong or have insufficient rights
+	CodeLockFailed     = 5  // sessions are not denied in infobase
+	CodeKillIncomplete = 6  // sessions or connections remain in infobase
+	CodeDumpFailed     = 7  // designer failed to dump infobase
+	CodeUnlockFailed   = 8  // lock of infobase is not given back
+	CodeVerifyFailed   = 9  // dump is reported, but not found
+	CodePartial        = 10 // dump is made, but lock of infobase is not given back
+	CodeHookFailed     = 11 // hook run before or after backup failed
+	CodeRestoreFailed  = 12 // designer failed to restore infobase from dump
+	CodeUsage          = 13 // command line: unknown command or subcommand, wrong flag or its value
+)
+
+// Category - kind of failure with its exit code, matched by errors.Is -.
+type Category struct {
+	name string
+	code int
+}
+
+func (c *Category) Error() string {
+	return c.name
+}
+
+// Code - exit code of category.
+func (c *Category) Code() int {
+	return c.code
+}
+
+var (
+	ErrConfig         = &Category{"config error", CodeConfig}
+	ErrConnectivity   = &Category{"connectivity error", CodeConnectivity}
+	ErrAuth           = &Category{"authentication error", CodeAuth}
+	ErrLockFailed     = &Category{"lock failed", CodeLockFailed}
+	ErrKillIncomplete = &Category{"kill incomplete", CodeKillIncomplete}
+	ErrDumpFailed     = &Category{"dump failed", CodeDumpFailed}
+	ErrUnlockFailed   = &Category{"unlock failed", CodeUnlockFailed}
+	ErrVerifyFailed   = &Category{"verification failed", CodeVerifyFailed}
+	ErrPartial        = &Category{"partial success", CodePartial}
+	ErrHookFailed     = &Category{"hook failed", CodeHookFailed}
+	ErrRestoreFailed  = &Category{"restore failed", CodeRestoreFailed}
+	ErrUsage          = &Category{"usage error", CodeUsage}
+)
+
+// categorized - error of category, its text is kept as is -.
+type categorized struct {
+	category *Category
+	err      error
+}
+
+func (e *categorized) Error() string {
+	return e.err.Error()
+}
+
+func (e *categorized) Unwrap() []error {
+	return []error{e.category, e.err}
+}
+
+// Wrap - err of category c, nil if err is nil.
+func Wrap(c *Category, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &categorized{
+		category: c,
+		err:      err,
+	}
+}
+
+// Code - exit code of err: CodeOK if err is nil, code of its outermost category or CodeUnknown.
+func Code(err error) int {
+	if err == nil {
+		return CodeOK
+	}
+
+	var c *Category
+
+	if errors.As(err, &c) {
+		return c.Code()
+	}
+
+	return CodeUnknown
+}
@@ -0,0 +1,71 @@
+package clierror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCode(t *testing.T) {
+	errCause := errors.New("cause")
+
+	cases := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{
+			name: "Nil",
+			code: CodeOK,
+		},
+		{
+			name: "No category",
+			err:  errCause,
+			code: CodeUnknown,
+		},
+		{
+			name: "Category",
+			err:  Wrap(ErrDumpFailed, errCause),
+			code: CodeDumpFailed,
+		},
+		{
+			name: "Wrapped category",
+			err:  fmt.Errorf("app: %w", Wrap(ErrAuth, errCause)),
+			code: CodeAuth,
+		},
+		{
+			name: "Outermost category",
+			err:  Wrap(ErrConnectivity, Wrap(ErrLockFailed, errCause)),
+			code: CodeConnectivity,
+		},
+		{
+			name: "First of joined",
+			err:  errors.Join(Wrap(ErrUnlockFailed, errCause), Wrap(ErrDumpFailed, errCause)),
+			code: CodeUnlockFailed,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.code, Code(tc.err))
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	errCause := errors.New("cause")
+
+	err := Wrap(ErrConfig, errCause)
+
+	require.EqualError(t, err, "cause")
+	require.ErrorIs(t, err, ErrConfig)
+	require.ErrorIs(t, err, errCause)
+	require.NotErrorIs(t, err, ErrAuth)
+	require.NoError(t, Wrap(ErrConfig, nil))
+}
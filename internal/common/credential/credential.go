@@ -0,0 +1,172 @@
+// Package credential provides passwords from sources other than command line,
+// so they do not show up in shell history and process lists.
+package credential
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// Sources are written as "kind:value", prompt has no value.
+const (
+	SourceEnv     = "env"
+	SourceFile    = "file"
+	SourcePrompt  = "prompt"
+	SourceCommand = "cmd"
+)
+
+var (
+	ErrUnknownSource = errors.New("unknown source of password, want env:VAR, file:PATH, prompt or cmd:COMMAND")
+	ErrEnvNotSet     = errors.New("environment variable is not set")
+	ErrInsecureFile  = errors.New("password file is accessible by group or others")
+	ErrNoTerminal    = errors.New("no terminal to prompt password")
+	ErrEmptyCommand  = errors.New("command is empty")
+	ErrEmptySecret   = errors.New("password is empty")
+)
+
+// Provider - gives password kept somewhere else -.
+type Provider interface {
+	Secret(ctx context.Context) (string, error)
+}
+
+// Parse - provider of source, label tells whose password is asked by prompt.
+func Parse(source string, label string) (Provider, error) {
+	kind, value, _ := strings.Cut(source, ":")
+
+	switch {
+	case kind == SourceEnv && value != "":
+		return Env{Name: value}, nil
+	case kind == SourceFile && value != "":
+		return File{Path: value}, nil
+	case kind == SourcePrompt && value == "":
+		return Prompt{Label: label, In: os.Stdin, Out: os.Stderr}, nil
+	case kind == SourceCommand:
+		return Command{Line: value}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownSource, kind)
+	}
+}
+
+// Env - password in environment variable -.
+type Env struct {
+	Name string
+}
+
+func (p Env) Secret(_ context.Context) (string, error) {
+	v, ok := os.LookupEnv(p.Name)
+	if !ok {
+		return "", fmt.Errorf("credential - Env - %s: %w", p.Name, ErrEnvNotSet)
+	}
+
+	if v == "" {
+		return "", fmt.Errorf("credential - Env - %s: %w", p.Name, ErrEmptySecret)
+	}
+
+	return v, nil
+}
+
+// File - password in first line of file, which must not be accessible by group or others -.
+// Permissions are not checked on Windows, ACLs of file are up to administrator.
+type File struct {
+	Path string
+}
+
+func (p File) Secret(_ context.Context) (string, error) {
+	fi, err := os.Stat(p.Path)
+	if err != nil {
+		return "", fmt.Errorf("credential - File - os.Stat: %w", err)
+	}
+
+	if runtime.GOOS != "windows" && fi.Mode().Perm()&0o077 != 0 {
+		return "", fmt.Errorf("credential - File - %s %s: %w", p.Path, fi.Mode().Perm(), ErrInsecureFile)
+	}
+
+	data, err := os.ReadFile(p.Path)
+	if err != nil {
+		return "", fmt.Errorf("credential - File - os.ReadFile: %w", err)
+	}
+
+	v := firstLine(data)
+	if v == "" {
+		return "", fmt.Errorf("credential - File - %s: %w", p.Path, ErrEmptySecret)
+	}
+
+	return v, nil
+}
+
+// Prompt - password typed in terminal without echo -.
+type Prompt struct {
+	Label string
+	In    *os.File
+	Out   io.Writer
+}
+
+func (p Prompt) Secret(_ context.Context) (string, error) {
+	fd := int(p.In.Fd())
+
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("credential - Prompt - %s: %w", p.Label, ErrNoTerminal)
+	}
+
+	fmt.Fprintf(p.Out, "Password of %s: ", p.Label)
+
+	b, err := term.ReadPassword(fd)
+
+	fmt.Fprintln(p.Out)
+
+	if err != nil {
+		return "", fmt.Errorf("credential - Prompt - term.ReadPassword: %w", err)
+	}
+
+	if len(b) == 0 {
+		return "", fmt.Errorf("credential - Prompt - %s: %w", p.Label, ErrEmptySecret)
+	}
+
+	return string(b), nil
+}
+
+// Command - password printed by command, e.g. password manager, as first line of its output.
+// Line is split by spaces, no shell is involved -.
+type Command struct {
+	Line string
+}
+
+func (p Command) Secret(ctx context.Context) (string, error) {
+	args := strings.Fields(p.Line)
+	if len(args) == 0 {
+		return "", fmt.Errorf("credential - Command: %w", ErrEmptyCommand)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...) //nolint:gosec // command is configured by administrator
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("credential - Command - %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	v := firstLine(stdout.Bytes())
+	if v == "" {
+		return "", fmt.Errorf("credential - Command - %s: %w", args[0], ErrEmptySecret)
+	}
+
+	return v, nil
+}
+
+// firstLine - first line of data without line break.
+func firstLine(data []byte) string {
+	line, _, _ := strings.Cut(string(data), "\n")
+
+	return strings.TrimSuffix(line, "\r")
+}
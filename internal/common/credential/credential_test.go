@@ -0,0 +1,199 @@
+package credential
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// _envEcho - test binary prints value of this variable as password manager would.
+const _envEcho = "CREDENTIAL_TEST_ECHO"
+
+func TestMain(m *testing.M) {
+	if v, ok := os.LookupEnv(_envEcho); ok {
+		fmt.Println(v)
+		fmt.Println("second line is ignored")
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name   string
+		source string
+		want   Provider
+		err    error
+	}{
+		{
+			name:   "Env",
+			source: "env:CLUSTER_PWD",
+			want:   Env{Name: "CLUSTER_PWD"},
+		},
+		{
+			name:   "File",
+			source: "file:C:/secrets/buh.pwd",
+			want:   File{Path: "C:/secrets/buh.pwd"},
+		},
+		{
+			name:   "Command",
+			source: "cmd:pass show 1c/prod",
+			want:   Command{Line: "pass show 1c/prod"},
+		},
+		{
+			name:   "Prompt",
+			source: "prompt",
+			want:   Prompt{Label: "cluster administrator", In: os.Stdin, Out: os.Stderr},
+		},
+		{
+			name:   "Env without name",
+			source: "env:",
+			err:    ErrUnknownSource,
+		},
+		{
+			name:   "Unknown",
+			source: "vault:1c/prod",
+			err:    ErrUnknownSource,
+		},
+		{
+			name:   "Literal",
+			source: "secret",
+			err:    ErrUnknownSource,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p, err := Parse(tc.source, "cluster administrator")
+
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				require.NotContains(t, err.Error(), "1c/prod")
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, p)
+		})
+	}
+}
+
+func TestEnv(t *testing.T) {
+	t.Setenv("CREDENTIAL_TEST_PWD", "p@ss")
+	t.Setenv("CREDENTIAL_TEST_EMPTY", "")
+
+	v, err := Env{Name: "CREDENTIAL_TEST_PWD"}.Secret(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "p@ss", v)
+
+	_, err = Env{Name: "CREDENTIAL_TEST_EMPTY"}.Secret(context.Background())
+	require.ErrorIs(t, err, ErrEmptySecret)
+
+	_, err = Env{Name: "CREDENTIAL_TEST_ABSENT"}.Secret(context.Background())
+	require.ErrorIs(t, err, ErrEnvNotSet)
+}
+
+func TestFile(t *testing.T) {
+	dir := t.TempDir()
+
+	cases := []struct {
+		name string
+		data string
+		perm os.FileMode
+		want string
+		err  error
+	}{
+		{
+			name: "Success",
+			data: "p@ss word\n",
+			perm: 0o600,
+			want: "p@ss word",
+		},
+		{
+			name: "Windows line break",
+			data: "p@ss\r\nnext",
+			perm: 0o400,
+			want: "p@ss",
+		},
+		{
+			name: "Empty",
+			data: "\n",
+			perm: 0o600,
+			err:  ErrEmptySecret,
+		},
+		{
+			name: "Readable by others",
+			data: "p@ss",
+			perm: 0o644,
+			err:  ErrInsecureFile,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if tc.err == ErrInsecureFile && runtime.GOOS == "windows" {
+				t.Skip("permissions are not checked on windows")
+			}
+
+			path := filepath.Join(dir, tc.name)
+			require.NoError(t, os.WriteFile(path, []byte(tc.data), tc.perm))
+			require.NoError(t, os.Chmod(path, tc.perm))
+
+			v, err := File{Path: path}.Secret(context.Background())
+
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, v)
+		})
+	}
+
+	_, err := File{Path: filepath.Join(dir, "absent")}.Secret(context.Background())
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestCommand(t *testing.T) {
+	exe, err := os.Executable()
+	require.NoError(t, err)
+
+	t.Setenv(_envEcho, "p@ss")
+
+	v, err := Command{Line: exe}.Secret(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "p@ss", v)
+
+	_, err = Command{Line: "  "}.Secret(context.Background())
+	require.ErrorIs(t, err, ErrEmptyCommand)
+
+	_, err = Command{Line: filepath.Join(t.TempDir(), "absent")}.Secret(context.Background())
+	require.Error(t, err)
+}
+
+func TestPromptNoTerminal(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "stdin")
+	require.NoError(t, err)
+
+	defer f.Close()
+
+	_, err = Prompt{Label: "infobase user robot", In: f, Out: os.Stderr}.Secret(context.Background())
+	require.ErrorIs(t, err, ErrNoTerminal)
+}
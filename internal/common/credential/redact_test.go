@@ -0,0 +1,66 @@
+package credential
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactor(t *testing.T) {
+	r := NewRedactor()
+
+	require.Equal(t, "rac --cluster-pwd=secret", r.String("rac --cluster-pwd=secret"))
+
+	r.Add("secret", "", "secret-long")
+
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "Single",
+			in:   "rac --cluster-pwd=secret",
+			want: "rac --cluster-pwd=***",
+		},
+		{
+			name: "Longer first",
+			in:   "pwd secret-long and secret",
+			want: "pwd *** and ***",
+		},
+		{
+			name: "No secrets",
+			in:   "cluster list",
+			want: "cluster list",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.want, r.String(tc.in))
+		})
+	}
+}
+
+func TestRedactorWriter(t *testing.T) {
+	t.Parallel()
+
+	r := NewRedactor()
+	r.Add("secret")
+
+	var out bytes.Buffer
+
+	w := r.Writer(&out)
+
+	n, err := fmt.Fprintln(w, "rac: wrong password secret")
+
+	require.NoError(t, err)
+	require.Equal(t, len("rac: wrong password secret\n"), n)
+	require.Equal(t, "rac: wrong password ***\n", out.String())
+}
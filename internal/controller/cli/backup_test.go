@@ -0,0 +1,196 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/antonmisa/1cctl_cli/config"
+	"github.com/antonmisa/1cctl_cli/internal/common/clierror"
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+	"github.com/antonmisa/1cctl_cli/internal/usecase"
+	"github.com/antonmisa/1cctl_cli/internal/usecase/backup"
+	"github.com/antonmisa/1cctl_cli/internal/usecase/backup/designertest"
+	ctrlpipe "github.com/antonmisa/1cctl_cli/internal/usecase/pipe"
+	"github.com/antonmisa/1cctl_cli/internal/usecase/pipe/ractest"
+	"github.com/antonmisa/1cctl_cli/pkg/pipe"
+)
+
+func TestMain(m *testing.M) {
+	designertest.Main()
+	ractest.Main()
+
+	os.Exit(m.Run())
+}
+
+const (
+	_buhID = "b2f4b9e6-3f1d-4c38-8f3a-3d1f6d0b1a01"
+	_zupID = "0e8f4f3e-9d2c-4a8b-b1f5-6c7d8e9f0a02"
+)
+
+type backupArgs struct {
+	clusterAdmin, clusterPwd   string
+	infobaseAdmin, infobasePwd string
+}
+
+// backupWithFakes - runs Backup of Buh infobase with fake rac and designer given by config.
+func backupWithFakes(t *testing.T, cfg *config.Config, a backupArgs) (string, error) {
+	t.Helper()
+
+	p, err := pipe.New(cfg.App.PathToRAC)
+	require.NoError(t, err)
+
+	b, err := backup.New(cfg.App.PathTo1C)
+	require.NoError(t, err)
+
+	uc := usecase.New(ctrlpipe.New(p, "localhost:1545"), b)
+
+	out := t.TempDir()
+	cc := New(context.Background(), uc, strings.NewReader(""), &bytes.Buffer{})
+
+	err = cc.Backup("srv-1c:1541", "buh",
+		"agent", "agent-pwd",
+		a.clusterAdmin, a.clusterPwd,
+		a.infobaseAdmin, a.infobasePwd,
+		entity.LockPolicy{Code: cfg.App.LockCode, Message: "backup of {infobase}", Window: time.Hour},
+		out, nil,
+		entity.DrainOptions{Timeout: 10 * time.Second, Interval: 10 * time.Millisecond})
+
+	return out, err
+}
+
+// fakesConfig - config pointing to fake rac and designer.
+func fakesConfig(rac *ractest.Rac, designer *designertest.Designer) *config.Config {
+	return &config.Config{
+		App: config.App{
+			PathToRAC: rac.Path,
+			PathTo1C:  designer.Path,
+			LockCode:  "backup",
+		},
+	}
+}
+
+func TestBackupIntegration(t *testing.T) {
+	rac := ractest.New(t, "testdata/cluster.yaml")
+	designer := designertest.New(t, "testdata/designer.yaml")
+	before := rac.State(t)
+
+	out, err := backupWithFakes(t, fakesConfig(rac, designer), backupArgs{
+		clusterAdmin: "admin", clusterPwd: "admin-pwd",
+		infobaseAdmin: "backup", infobasePwd: "backup-pwd",
+	})
+	require.NoError(t, err)
+
+	dumps, err := os.ReadDir(out)
+	require.NoError(t, err)
+	require.Len(t, dumps, 1)
+	require.True(t, strings.HasSuffix(dumps[0].Name(), "_Buh.dt"))
+
+	data, err := os.ReadFile(filepath.Join(out, dumps[0].Name()))
+	require.NoError(t, err)
+	require.Equal(t, designertest.Dump(`srv-1c:1541\Buh`), data)
+
+	after := rac.State(t)
+	cl := after.Clusters[0]
+
+	// Sessions and connections of Buh are dropped, others are untouched
+	require.Len(t, cl.Sessions, 1)
+	require.Equal(t, _zupID, cl.Sessions[0].InfobaseID)
+	require.Len(t, cl.Connections, 1)
+	require.Equal(t, _zupID, cl.Connections[0].InfobaseID)
+
+	// Planned maintenance lock is given back as it was
+	require.Equal(t, _buhID, cl.Infobases[0].ID)
+	require.Equal(t, before.Clusters[0].Infobases[0].Lock(), cl.Infobases[0].Lock())
+
+	// Backup lock was applied in between
+	var locked bool
+
+	for _, call := range after.Calls {
+		if strings.Join(call[1:3], " ") == "infobase update" && containsArgs(call, "--permission-code", "backup") {
+			locked = containsArgs(call, "--sessions-deny", "on") && containsArgs(call, "--denied-message", "backup of Buh")
+		}
+	}
+
+	require.True(t, locked)
+}
+
+func TestBackupIntegrationErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		designer string
+		args     backupArgs
+		err      string
+		is       error
+		code     int
+	}{
+		{
+			name: "Cluster admin",
+			args: backupArgs{clusterAdmin: "admin", clusterPwd: "bad", infobaseAdmin: "backup", infobasePwd: "backup-pwd"},
+			err:  ctrlpipe.ErrClusterAuth.Error(),
+			is:   usecase.ErrAuthFailed,
+			code: clierror.CodeAuth,
+		},
+		{
+			name:     "Designer fails",
+			designer: "testdata/designer_fail.yaml",
+			args:     backupArgs{clusterAdmin: "admin", clusterPwd: "admin-pwd", infobaseAdmin: "backup", infobasePwd: "backup-pwd"},
+			err:      backup.ErrDumpFailed.Error(),
+			code:     clierror.CodeDumpFailed,
+		},
+		{
+			name: "Infobase rights",
+			args: backupArgs{clusterAdmin: "admin", clusterPwd: "admin-pwd", infobaseAdmin: "backup", infobasePwd: "bad"},
+			err:  ractest.ErrInfobaseRights.Error(),
+			is:   usecase.ErrInsufficientRights,
+			code: clierror.CodeAuth,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.designer == "" {
+				tc.designer = "testdata/designer.yaml"
+			}
+
+			rac := ractest.New(t, "testdata/cluster.yaml")
+			designer := designertest.New(t, tc.designer)
+			before := rac.State(t)
+
+			out, err := backupWithFakes(t, fakesConfig(rac, designer), tc.args)
+			require.ErrorContains(t, err, tc.err)
+			require.Equal(t, tc.code, clierror.Code(err))
+
+			if tc.is != nil {
+				require.ErrorIs(t, err, tc.is)
+			}
+
+			dumps, err := os.ReadDir(out)
+			require.NoError(t, err)
+			require.Empty(t, dumps)
+
+			// Lock is given back
+			after := rac.State(t)
+			require.Equal(t, before.Clusters[0].Infobases, after.Clusters[0].Infobases)
+		})
+	}
+}
+
+// containsArgs - whether option with value is among arguments of call.
+func containsArgs(call []string, option, value string) bool {
+	for i := 0; i+1 < len(call); i++ {
+		if call[i] == option && call[i+1] == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -2,7 +2,9 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"time"
 
@@ -19,111 +21,225 @@ const (
 type Ctrl1CCLI struct {
 	ctx context.Context
 	c   usecase.Ctrl
+
+	in  io.Reader
+	out io.Writer
 }
 
-func New(ctx context.Context, c usecase.Ctrl) *Ctrl1CCLI {
+func New(ctx context.Context, c usecase.Ctrl, in io.Reader, out io.Writer) *Ctrl1CCLI {
 	return &Ctrl1CCLI{
 		ctx: ctx,
 		c:   c,
+		in:  in,
+		out: out,
 	}
 }
 
 func (cc *Ctrl1CCLI) Backup(clusterName string, infobase string,
+	agentAdmin string, agentPwd string,
 	clusterAdmin string, clusterPwd string,
 	infobaseAdmin string, infobasePwd string,
-	lockCode string, outputPath string) (re error) {
+	policy entity.LockPolicy, outputPath string,
+	graceful *entity.GracefulTermination, drain entity.DrainOptions) error {
+
+	p := InfobaseParams{
+		ClusterName:  clusterName,
+		Infobase:     infobase,
+		AgentAdmin:   agentAdmin,
+		AgentPwd:     agentPwd,
+		ClusterAdmin: clusterAdmin,
+		ClusterPwd:   clusterPwd,
+		InfobaseUser: infobaseAdmin,
+		InfobasePwd:  infobasePwd,
+	}
+
+	return cc.exclusive(p, policy, graceful, drain, func(ctx context.Context, cl entity.Cluster, ib entity.Infobase, infobaseCred entity.Credentials) error {
+		p, err := cc.c.RunBackup(ctx, cl, ib, infobaseCred, policy.Code, outputPath)
+
+		if err != nil {
+			return e.Wrap(e.ErrDumpFailed, fmt.Errorf("cli - Process - cc.c.RunBackup: %w", err))
+		}
+
+		// Check backup exists
+		_, err = os.Stat(p)
+
+		if os.IsNotExist(err) {
+			return e.Wrap(e.ErrVerifyFailed, e.WithText{
+				Txt: fmt.Sprintf("backup file does not exist at: %s", p),
+			})
+		}
+
+		return nil
+	})
+}
+
+// Restore - loads infobase from dump the same way backup is made: sessions are denied and dropped,
+// lock state of infobase is given back afterwards. Infobase is replaced, so it is confirmed unless yes is set.
+func (cc *Ctrl1CCLI) Restore(p InfobaseParams,
+	policy entity.LockPolicy, dumpPath string,
+	graceful *entity.GracefulTermination, drain entity.DrainOptions, yes bool) error {
+
+	if !yes {
+		ok, err := cc.confirm(fmt.Sprintf("Replace infobase %s by %s?", p.Infobase, dumpPath))
+		if err != nil {
+			return fmt.Errorf("cli - Restore - cc.confirm: %w", err)
+		}
+
+		if !ok {
+			fmt.Fprintln(cc.out, "canceled")
+
+			return nil
+		}
+	}
+
+	return cc.exclusive(p, policy, graceful, drain, func(ctx context.Context, cl entity.Cluster, ib entity.Infobase, infobaseCred entity.Credentials) error {
+		err := cc.c.RunRestore(ctx, cl, ib, infobaseCred, policy.Code, dumpPath)
+		if err != nil {
+			return categorize(e.ErrRestoreFailed, fmt.Errorf("cli - Restore - cc.c.RunRestore: %w", err))
+		}
+
+		fmt.Fprintf(cc.out, "infobase %s is restored from %s\n", ib.Name, dumpPath)
+
+		return nil
+	})
+}
+
+// exclusive - runs designer operation fn on infobase nobody works with: sessions are denied, gracefully
+// if it is set, and dropped, lock state of infobase is given back afterwards, always.
+func (cc *Ctrl1CCLI) exclusive(p InfobaseParams,
+	policy entity.LockPolicy,
+	graceful *entity.GracefulTermination, drain entity.DrainOptions,
+	fn func(ctx context.Context, cl entity.Cluster, ib entity.Infobase, infobaseCred entity.Credentials) error) (re error) {
 
 	ctx, cancel := context.WithTimeout(cc.ctx, _defaultOperationTimeout*time.Second)
 	defer cancel()
 
+	agentCred := entity.Credentials{
+		Name: p.AgentAdmin,
+		Pwd:  p.AgentPwd,
+	}
+
 	clusterCred := entity.Credentials{
-		Name: clusterAdmin,
-		Pwd:  clusterPwd,
+		Name: p.ClusterAdmin,
+		Pwd:  p.ClusterPwd,
 	}
 
 	infobaseCred := entity.Credentials{
-		Name: infobaseAdmin,
-		Pwd:  infobasePwd,
+		Name: p.InfobaseUser,
+		Pwd:  p.InfobasePwd,
 	}
 
 	// Check cluster exists
-	cl, err := cc.c.ClusterByName(ctx, clusterName)
+	cl, err := cc.c.ClusterByName(ctx, p.ClusterName, agentCred)
 
 	if err != nil {
-		re = fmt.Errorf("cli - Process - cc.c.ClusterByName: %w", err)
+		re = lookupError(fmt.Errorf("cli - Process - cc.c.ClusterByName: %w", err))
 		return
 	}
 
 	// Check infobase exists in cluster
-	ib, err := cc.c.InfobaseByName(ctx, cl, infobase, clusterCred)
+	ib, err := cc.c.InfobaseByName(ctx, cl, p.Infobase, clusterCred)
+
+	if err != nil {
+		re = lookupError(fmt.Errorf("cli - Process - cc.c.InfobaseByName: %w", err))
+		return
+	}
+
+	// Remember how infobase is locked now, e.g. by planned maintenance, to give it back untouched
+	state, err := cc.c.LockState(ctx, cl, ib, clusterCred, infobaseCred)
 
 	if err != nil {
-		re = fmt.Errorf("cli - Process - cc.c.InfobaseByName: %w", err)
+		re = categorize(e.ErrLockFailed, fmt.Errorf("cli - Process - cc.c.LockState: %w", err))
 		return
 	}
 
 	defer func() {
-		// UnBlock all sessions in infobase, always
+		// Restore lock state of infobase, always
 		c, cncl := context.WithTimeout(context.TODO(), _defaultOperationTimeout*time.Second)
 		defer cncl()
 
-		err = cc.c.EnableSessions(c, cl, ib, clusterCred, infobaseCred, lockCode)
-		if err != nil {
-			re = fmt.Errorf("cli - Process - cc.c.EnableSessions: %w", err)
+		err = cc.c.RestoreLock(c, cl, ib, clusterCred, infobaseCred, state)
+		if err == nil {
+			return
 		}
-	}()
 
-	// Block all new sessions in infobase
-	err = cc.c.DisableSessions(ctx, cl, ib, clusterCred, infobaseCred, lockCode)
+		err = fmt.Errorf("cli - Process - cc.c.RestoreLock: %w", err)
 
-	if err != nil {
-		re = fmt.Errorf("cli - Process - cc.c.DisableSessions: %w", err)
-		return
-	}
+		// Operation succeeded, but infobase is left locked
+		if re == nil {
+			re = e.Wrap(e.ErrPartial, err)
+			return
+		}
 
-	// Get all sessions in infobase
-	sessions, err := cc.c.Sessions(ctx, cl, ib, clusterCred)
+		// Infobase left locked matters more than failure which caused it
+		re = errors.Join(e.Wrap(e.ErrUnlockFailed, err), re)
+	}()
 
-	if err != nil {
-		re = fmt.Errorf("cli - Process - cc.c.Sessions: %w", err)
-		return
-	}
+	if graceful != nil {
+		// Warn users, give them time to leave and drop the rest
+		err = cc.c.TerminateSessions(ctx, cl, ib, clusterCred, infobaseCred, policy, *graceful)
 
-	// Drop all sessions in infobase
-	_ = cc.c.DeleteSessions(ctx, cl, sessions, clusterCred) //nolint:errcheck // do not need errors
+		if err != nil {
+			re = categorize(e.ErrLockFailed, fmt.Errorf("cli - Process - cc.c.TerminateSessions: %w", err))
+			return
+		}
+	} else {
+		// Block all new sessions in infobase
+		err = cc.c.DisableSessions(ctx, cl, ib, clusterCred, infobaseCred, policy)
 
-	// Get all connections in infobase
-	connections, err := cc.c.Connections(ctx, cl, ib, clusterCred)
+		if err != nil {
+			re = categorize(e.ErrLockFailed, fmt.Errorf("cli - Process - cc.c.DisableSessions: %w", err))
+			return
+		}
+	}
+
+	// Drop sessions and connections until infobase is empty, designer would fail on exclusive lock otherwise
+	err = cc.c.Drain(ctx, cl, ib, clusterCred, drain)
 
 	if err != nil {
-		re = fmt.Errorf("cli - Process - cc.c.Connections: %w", err)
+		re = categorize(e.ErrKillIncomplete, fmt.Errorf("cli - Process - cc.c.Drain: %w", err))
 		return
 	}
 
-	// Drop all connections in infobase
-	_ = cc.c.DeleteConnections(ctx, cl, connections, clusterCred) //nolint:errcheck // do not need errors
-
-	// Run backup as long operation
+	// Run designer as long operation
 	cx, cancel := context.WithTimeout(cc.ctx, _defaultBackupTimeout*time.Minute)
 
 	defer cancel()
 
-	p, err := cc.c.RunBackup(cx, cl, ib, infobaseCred, lockCode, outputPath)
+	re = fn(cx, cl, ib, infobaseCred)
 
-	if err != nil {
-		re = fmt.Errorf("cli - Process - cc.c.RunBackup: %w", err)
-		return
+	return
+}
+
+// categorize - err of category of failed step, unless it is caused by credentials or unreachable cluster.
+// Step of nil category, e.g. listing, keeps err without category.
+func categorize(c *e.Category, err error) error {
+	switch {
+	case errors.Is(err, usecase.ErrAuthFailed), errors.Is(err, usecase.ErrInsufficientRights):
+		return e.Wrap(e.ErrAuth, err)
+	case errors.Is(err, usecase.ErrClusterUnreachable):
+		return e.Wrap(e.ErrConnectivity, err)
+	case c == nil:
+		return err
+	default:
+		return e.Wrap(c, err)
 	}
+}
 
-	// Check backup exists
-	_, err = os.Stat(p)
+// renderError - unknown format or column is wrong flag value, so it is usage error.
+func renderError(err error) error {
+	if errors.Is(err, ErrUnknownFormat) || errors.Is(err, ErrUnknownColumn) {
+		return e.Wrap(e.ErrUsage, err)
+	}
 
-	if os.IsNotExist(err) {
-		re = e.WithText{
-			Txt: fmt.Sprintf("backup file does not exist at: %s", p),
-		}
-		return
+	return err
+}
+
+// lookupError - cluster or infobase not found by name is config error, cluster not listed is connectivity one.
+func lookupError(err error) error {
+	if errors.Is(err, usecase.ErrClusterNotFound) || errors.Is(err, usecase.ErrInfobaseNotFound) {
+		return e.Wrap(e.ErrConfig, err)
 	}
 
-	return
+	return categorize(e.ErrConnectivity, err)
 }
@@ -0,0 +1,195 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antonmisa/1cctl_cli/internal/common/clierror"
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+	"github.com/antonmisa/1cctl_cli/internal/usecase"
+	"github.com/antonmisa/1cctl_cli/internal/usecase/mocks"
+)
+
+func TestBackupExitCode(t *testing.T) {
+	errRAS := errors.New("ras failed")
+	unreachable := &usecase.ClusterError{Kind: usecase.ErrClusterUnreachable, Err: errRAS}
+
+	dump := filepath.Join(t.TempDir(), "buh.dt")
+	require.NoError(t, os.WriteFile(dump, []byte("dump"), 0o600))
+
+	cases := []struct {
+		name    string
+		cluster error
+		lock    error
+		drain   error
+		backup  error
+		path    string
+		restore error
+		code    int
+	}{
+		{
+			name: "Success",
+			path: dump,
+			code: clierror.CodeOK,
+		},
+		{
+			name:    "Cluster not found",
+			cluster: usecase.ErrClusterNotFound,
+			code:    clierror.CodeConfig,
+		},
+		{
+			name:    "Unreachable",
+			cluster: unreachable,
+			code:    clierror.CodeConnectivity,
+		},
+		{
+			name: "Lock failed",
+			lock: usecase.ErrLockNotApplied,
+			code: clierror.CodeLockFailed,
+		},
+		{
+			name:  "Kill incomplete",
+			drain: &usecase.DrainError{},
+			code:  clierror.CodeKillIncomplete,
+		},
+		{
+			name:  "Rights lost during drain",
+			drain: &usecase.DrainError{Err: usecase.ErrInsufficientRights},
+			code:  clierror.CodeAuth,
+		},
+		{
+			name:   "Dump failed",
+			backup: errRAS,
+			code:   clierror.CodeDumpFailed,
+		},
+		{
+			name: "Verification failed",
+			path: filepath.Join(t.TempDir(), "missing.dt"),
+			code: clierror.CodeVerifyFailed,
+		},
+		{
+			name:    "Partial",
+			path:    dump,
+			restore: errRAS,
+			code:    clierror.CodePartial,
+		},
+		{
+			name:    "Unlock failed",
+			backup:  errRAS,
+			restore: errRAS,
+			code:    clierror.CodeUnlockFailed,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := mocks.NewCtrl(t)
+
+			c.On("ClusterByName", mock.Anything, "srv", mock.Anything).Return(entity.Cluster{ID: "1"}, tc.cluster).Once()
+
+			if tc.cluster == nil {
+				c.On("InfobaseByName", mock.Anything, mock.Anything, "buh", mock.Anything).Return(entity.Infobase{ID: "2"}, nil).Once()
+				c.On("LockState", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(entity.InfobaseLock{}, nil).Once()
+				c.On("RestoreLock", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.restore).Once()
+				c.On("DisableSessions", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.lock).Once()
+			}
+
+			if tc.cluster == nil && tc.lock == nil {
+				c.On("Drain", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.drain).Once()
+			}
+
+			if tc.cluster == nil && tc.lock == nil && tc.drain == nil {
+				c.On("RunBackup", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.path, tc.backup).Once()
+			}
+
+			cc := New(context.Background(), c, strings.NewReader(""), &bytes.Buffer{})
+
+			err := cc.Backup("srv", "buh", "", "", "", "", "", "", entity.LockPolicy{}, t.TempDir(), nil, entity.DrainOptions{})
+
+			require.Equal(t, tc.code, clierror.Code(err), err)
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	errDesigner := errors.New("designer failed")
+
+	cases := []struct {
+		name    string
+		in      string
+		yes     bool
+		restore error
+		calls   bool
+		out     string
+		code    int
+	}{
+		{
+			name:  "Success",
+			yes:   true,
+			calls: true,
+			out:   "infobase buh is restored from buh.dt",
+			code:  clierror.CodeOK,
+		},
+		{
+			name:  "Confirmed",
+			in:    "y\n",
+			calls: true,
+			code:  clierror.CodeOK,
+		},
+		{
+			name: "Canceled",
+			in:   "n\n",
+			out:  "canceled",
+			code: clierror.CodeOK,
+		},
+		{
+			name:    "Restore failed",
+			yes:     true,
+			calls:   true,
+			restore: errDesigner,
+			code:    clierror.CodeRestoreFailed,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := mocks.NewCtrl(t)
+
+			if tc.calls {
+				c.On("ClusterByName", mock.Anything, "srv", mock.Anything).Return(entity.Cluster{ID: "1"}, nil).Once()
+				c.On("InfobaseByName", mock.Anything, mock.Anything, "buh", mock.Anything).Return(entity.Infobase{ID: "2", Name: "buh"}, nil).Once()
+				c.On("LockState", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(entity.InfobaseLock{}, nil).Once()
+				c.On("DisableSessions", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+				c.On("Drain", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+				c.On("RunRestore", mock.Anything, mock.Anything, mock.Anything, mock.Anything, "12345", "buh.dt").Return(tc.restore).Once()
+				c.On("RestoreLock", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+			}
+
+			var out bytes.Buffer
+
+			cc := New(context.Background(), c, strings.NewReader(tc.in), &out)
+
+			err := cc.Restore(InfobaseParams{ClusterName: "srv", Infobase: "buh"},
+				entity.LockPolicy{Code: "12345"}, "buh.dt", nil, entity.DrainOptions{}, tc.yes)
+
+			require.Equal(t, tc.code, clierror.Code(err), err)
+			require.Contains(t, out.String(), tc.out)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+)
+
+// ClustersList - prints clusters of central server, cluster name of params is not used.
+func (cc *Ctrl1CCLI) ClustersList(p ClusterParams) error {
+	ctx, cancel := context.WithTimeout(cc.ctx, _defaultOperationTimeout*time.Second)
+	defer cancel()
+
+	clusters, err := cc.clusters(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	err = renderClusters(cc.out, p.Output, clusters)
+	if err != nil {
+		return renderError(fmt.Errorf("cli - ClustersList - renderClusters: %w", err))
+	}
+
+	return nil
+}
+
+// clusters - getting clusters of central server.
+func (cc *Ctrl1CCLI) clusters(ctx context.Context, p ClusterParams) ([]entity.Cluster, error) {
+	agentCred, _ := p.credentials()
+
+	clusters, err := cc.c.Clusters(ctx, agentCred)
+	if err != nil {
+		return nil, categorize(nil, fmt.Errorf("cli - clusters - cc.c.Clusters: %w", err))
+	}
+
+	return clusters, nil
+}
@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	e "github.com/antonmisa/1cctl_cli/internal/common/clierror"
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+)
+
+// Names of cluster checks, other checks may depend on them.
+const (
+	CheckCluster      = "cluster found"
+	CheckClusterCred  = "cluster credentials"
+	CheckInfobase     = "infobase found"
+	_checkStatusPass  = "PASS"
+	_checkStatusFail  = "FAIL"
+	_checkStatusSkip  = "SKIP"
+	_checkSkippedText = "skipped, failed: "
+)
+
+// ErrChecksFailed - some checks of doctor failed.
+var ErrChecksFailed = errors.New("checks failed")
+
+// Check - step of doctor, Run tells details of passed step, it is skipped if any of Needs did not pass -.
+type Check struct {
+	Name  string
+	Needs []string
+	Run   func(ctx context.Context) (string, error)
+}
+
+// ClusterChecks - cluster is found, cluster credentials work and infobase is found, each one needs previous.
+// Infobase is not checked if it is empty. First check needs checks given, e.g. reachability of RAS.
+func (cc *Ctrl1CCLI) ClusterChecks(p InfobaseParams, needs ...string) []Check {
+	agentCred := entity.Credentials{
+		Name: p.AgentAdmin,
+		Pwd:  p.AgentPwd,
+	}
+
+	clusterCred := entity.Credentials{
+		Name: p.ClusterAdmin,
+		Pwd:  p.ClusterPwd,
+	}
+
+	var cl entity.Cluster
+
+	checks := []Check{
+		{
+			Name:  CheckCluster,
+			Needs: needs,
+			Run: func(ctx context.Context) (string, error) {
+				var err error
+
+				cl, err = cc.c.ClusterByName(ctx, p.ClusterName, agentCred)
+				if err != nil {
+					return "", lookupError(fmt.Errorf("cli - ClusterChecks - cc.c.ClusterByName: %w", err))
+				}
+
+				return fmt.Sprintf("%s:%s %s", cl.Host, cl.Port, cl.Name), nil
+			},
+		},
+		{
+			Name:  CheckClusterCred,
+			Needs: []string{CheckCluster},
+			Run: func(ctx context.Context) (string, error) {
+				sessions, err := cc.c.Sessions(ctx, cl, entity.Infobase{}, clusterCred)
+				if err != nil {
+					return "", categorize(e.ErrAuth, fmt.Errorf("cli - ClusterChecks - cc.c.Sessions: %w", err))
+				}
+
+				return fmt.Sprintf("administrator %q, %d sessions in cluster", clusterCred.Name, len(sessions)), nil
+			},
+		},
+	}
+
+	if p.Infobase == "" {
+		return checks
+	}
+
+	return append(checks, Check{
+		Name:  CheckInfobase,
+		Needs: []string{CheckClusterCred},
+		Run: func(ctx context.Context) (string, error) {
+			ib, err := cc.c.InfobaseByName(ctx, cl, p.Infobase, clusterCred)
+			if err != nil {
+				return "", lookupError(fmt.Errorf("cli - ClusterChecks - cc.c.InfobaseByName: %w", err))
+			}
+
+			return fmt.Sprintf("%s (%s)", ib.Name, ib.ID), nil
+		},
+	})
+}
+
+// RunChecks - runs checks in order and prints checklist, failures are returned joined,
+// so exit code tells category of the first one.
+func RunChecks(ctx context.Context, w io.Writer, checks []Check) error {
+	ctx, cancel := context.WithTimeout(ctx, _defaultOperationTimeout*time.Second)
+	defer cancel()
+
+	passed := make(map[string]bool, len(checks))
+
+	var errs []error
+
+	for _, c := range checks {
+		if failed := unmet(c.Needs, passed); failed != "" {
+			fmt.Fprintf(w, "[%s] %s: %s%s\n", _checkStatusSkip, c.Name, _checkSkippedText, failed)
+
+			continue
+		}
+
+		details, err := c.Run(ctx)
+		if err != nil {
+			fmt.Fprintf(w, "[%s] %s: %s\n", _checkStatusFail, c.Name, err)
+
+			errs = append(errs, fmt.Errorf("%s: %w", c.Name, err))
+
+			continue
+		}
+
+		passed[c.Name] = true
+
+		fmt.Fprintf(w, "[%s] %s: %s\n", _checkStatusPass, c.Name, details)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrChecksFailed, errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// unmet - first of needs which did not pass, empty if all did.
+func unmet(needs []string, passed map[string]bool) string {
+	for _, n := range needs {
+		if !passed[n] {
+			return n
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antonmisa/1cctl_cli/internal/common/clierror"
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+	"github.com/antonmisa/1cctl_cli/internal/usecase"
+	"github.com/antonmisa/1cctl_cli/internal/usecase/mocks"
+)
+
+func TestRunChecks(t *testing.T) {
+	errAuth := &usecase.ClusterError{Kind: usecase.ErrAuthFailed, Err: errors.New("wrong password")}
+
+	cases := []struct {
+		name     string
+		ras      error
+		cluster  error
+		sessions error
+		infobase error
+		out      []string
+		code     int
+	}{
+		{
+			name: "All passed",
+			out:  []string{"[PASS] ras", "[PASS] cluster found", "[PASS] cluster credentials: administrator \"admin\", 2 sessions", "[PASS] infobase found: buh (2)"},
+			code: clierror.CodeOK,
+		},
+		{
+			name: "RAS unreachable",
+			ras:  clierror.Wrap(clierror.ErrConnectivity, errors.New("refused")),
+			out:  []string{"[FAIL] ras: refused", "[SKIP] cluster found: skipped, failed: ras", "[SKIP] infobase found: skipped, failed: cluster credentials"},
+			code: clierror.CodeConnectivity,
+		},
+		{
+			name:    "Cluster not found",
+			cluster: usecase.ErrClusterNotFound,
+			out:     []string{"[FAIL] cluster found: ", "[SKIP] cluster credentials"},
+			code:    clierror.CodeConfig,
+		},
+		{
+			name:     "Wrong cluster credentials",
+			sessions: errAuth,
+			out:      []string{"[PASS] cluster found", "[FAIL] cluster credentials", "[SKIP] infobase found"},
+			code:     clierror.CodeAuth,
+		},
+		{
+			name:     "Infobase not found",
+			infobase: usecase.ErrInfobaseNotFound,
+			out:      []string{"[PASS] cluster credentials", "[FAIL] infobase found"},
+			code:     clierror.CodeConfig,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := mocks.NewCtrl(t)
+
+			if tc.ras == nil {
+				c.On("ClusterByName", mock.Anything, "srv", entity.Credentials{Name: "agent"}).
+					Return(entity.Cluster{ID: "1", Host: "srv", Port: "1541"}, tc.cluster).Once()
+			}
+
+			if tc.ras == nil && tc.cluster == nil {
+				c.On("Sessions", mock.Anything, entity.Cluster{ID: "1", Host: "srv", Port: "1541"}, entity.Infobase{}, entity.Credentials{Name: "admin", Pwd: "pwd"}).
+					Return([]entity.Session{{ID: "1"}, {ID: "2"}}, tc.sessions).Once()
+			}
+
+			if tc.ras == nil && tc.cluster == nil && tc.sessions == nil {
+				c.On("InfobaseByName", mock.Anything, mock.Anything, "buh", mock.Anything).
+					Return(entity.Infobase{ID: "2", Name: "buh"}, tc.infobase).Once()
+			}
+
+			cc := New(context.Background(), c, strings.NewReader(""), &bytes.Buffer{})
+
+			checks := append([]Check{{
+				Name: "ras",
+				Run: func(context.Context) (string, error) {
+					return "", tc.ras
+				},
+			}}, cc.ClusterChecks(InfobaseParams{
+				ClusterName:  "srv",
+				Infobase:     "buh",
+				AgentAdmin:   "agent",
+				ClusterAdmin: "admin",
+				ClusterPwd:   "pwd",
+			}, "ras")...)
+
+			var out bytes.Buffer
+
+			err := RunChecks(context.Background(), &out, checks)
+
+			require.Equal(t, tc.code, clierror.Code(err), err)
+
+			for _, line := range tc.out {
+				require.Contains(t, out.String(), line)
+			}
+
+			if err != nil {
+				require.ErrorIs(t, err, ErrChecksFailed)
+			}
+		})
+	}
+}
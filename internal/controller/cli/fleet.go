@@ -0,0 +1,210 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/antonmisa/1cctl_cli/internal/usecase"
+)
+
+// ErrClustersFailed - some clusters of fan-out listing failed, results of others are printed anyway.
+var ErrClustersFailed = errors.New("listing failed on some clusters")
+
+// Member - cluster of inventory with use case bound to its RAS -.
+type Member struct {
+	Alias string
+	C     usecase.Ctrl
+
+	ClusterName  string
+	AgentAdmin   string
+	AgentPwd     string
+	ClusterAdmin string
+	ClusterPwd   string
+}
+
+// Fleet - runs listing commands on all clusters of inventory concurrently,
+// results are labeled by cluster alias -.
+type Fleet struct {
+	ctx     context.Context
+	members []Member
+	out     io.Writer
+}
+
+func NewFleet(ctx context.Context, members []Member, out io.Writer) *Fleet {
+	return &Fleet{
+		ctx:     ctx,
+		members: members,
+		out:     out,
+	}
+}
+
+// clusterItems - json and yaml representation of results of one cluster -.
+type clusterItems struct {
+	Cluster string `json:"cluster"`
+	Items   any    `json:"items"`
+}
+
+type listing func(ctx context.Context, cc *Ctrl1CCLI, m Member) ([][]string, any, error)
+
+// ClustersList - prints clusters of central servers of all inventory entries.
+func (f *Fleet) ClustersList(p ClusterParams) error {
+	return f.list(p.Output, KindClusters, _clustersHeader, func(ctx context.Context, cc *Ctrl1CCLI, m Member) ([][]string, any, error) {
+		clusters, err := cc.clusters(ctx, m.params())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return sorted(p.Output, _clustersHeader, clustersRows(clusters), clustersV1(clusters))
+	})
+}
+
+// InfobasesList - prints infobases of all clusters.
+func (f *Fleet) InfobasesList(p ClusterParams) error {
+	return f.list(p.Output, KindInfobases, _infobasesHeader, func(ctx context.Context, cc *Ctrl1CCLI, m Member) ([][]string, any, error) {
+		infobases, err := cc.infobases(ctx, m.params())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return sorted(p.Output, _infobasesHeader, infobasesRows(infobases), infobasesV1(infobases))
+	})
+}
+
+// SessionsList - prints sessions matching filter on all clusters.
+func (f *Fleet) SessionsList(p SessionsParams) error {
+	return f.list(p.Output, KindSessions, _sessionsHeader, func(ctx context.Context, cc *Ctrl1CCLI, m Member) ([][]string, any, error) {
+		p := p
+		p.ClusterName, p.AgentAdmin, p.AgentPwd, p.ClusterAdmin, p.ClusterPwd = m.ClusterName, m.AgentAdmin, m.AgentPwd, m.ClusterAdmin, m.ClusterPwd
+
+		_, sessions, err := cc.sessions(ctx, p)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return sorted(p.Output, _sessionsHeader, sessionsRows(sessions), sessionsV1(sessions))
+	})
+}
+
+// ConnectionsList - prints connections matching filter on all clusters.
+func (f *Fleet) ConnectionsList(p ConnectionsParams) error {
+	return f.list(p.Output, KindConnections, _connectionsHeader, func(ctx context.Context, cc *Ctrl1CCLI, m Member) ([][]string, any, error) {
+		p := p
+		p.ClusterName, p.AgentAdmin, p.AgentPwd, p.ClusterAdmin, p.ClusterPwd = m.ClusterName, m.AgentAdmin, m.AgentPwd, m.ClusterAdmin, m.ClusterPwd
+
+		_, connections, err := cc.connections(ctx, p)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return sorted(p.Output, _connectionsHeader, connectionsRows(connections), connectionsV1(connections))
+	})
+}
+
+// ProcessesList - prints working processes of all clusters.
+func (f *Fleet) ProcessesList(p ClusterParams) error {
+	return f.list(p.Output, KindProcesses, _processesHeader, func(ctx context.Context, cc *Ctrl1CCLI, m Member) ([][]string, any, error) {
+		processes, err := cc.processes(ctx, m.params())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return sorted(p.Output, _processesHeader, processesRows(processes), processesV1(processes))
+	})
+}
+
+// ServersList - prints working servers of all clusters.
+func (f *Fleet) ServersList(p ClusterParams) error {
+	return f.list(p.Output, KindServers, _serversHeader, func(ctx context.Context, cc *Ctrl1CCLI, m Member) ([][]string, any, error) {
+		servers, err := cc.servers(ctx, m.params())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return sorted(p.Output, _serversHeader, serversRows(servers), serversV1(servers))
+	})
+}
+
+// list - runs fn on every cluster concurrently and renders results in order of members,
+// failed clusters are reported by error after results of others. Rows are sorted within
+// cluster, so table and csv are grouped by cluster the same way json and yaml are.
+func (f *Fleet) list(o Output, kind string, header []string, fn listing) error {
+	labeledHeader := append([]string{"CLUSTER"}, header...)
+
+	// Sorting and columns are checked before clusters are queried
+	if _, _, err := o.sortColumn(header); err != nil {
+		return renderError(fmt.Errorf("cli - Fleet - o.sortColumn: %w", err))
+	}
+
+	if _, _, err := o.selected(labeledHeader, nil); err != nil {
+		return renderError(fmt.Errorf("cli - Fleet - o.selected: %w", err))
+	}
+
+	ctx, cancel := context.WithTimeout(f.ctx, _defaultOperationTimeout*time.Second)
+	defer cancel()
+
+	rows := make([][][]string, len(f.members))
+	items := make([]any, len(f.members))
+	errs := make([]error, len(f.members))
+
+	var wg sync.WaitGroup
+
+	for i := range f.members {
+		i := i
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			cc := New(ctx, f.members[i].C, nil, io.Discard)
+
+			rows[i], items[i], errs[i] = fn(ctx, cc, f.members[i])
+		}()
+	}
+
+	wg.Wait()
+
+	labeled := make([][]string, 0, len(f.members))
+	results := make([]clusterItems, 0, len(f.members))
+	failed := make([]error, 0)
+
+	for i := range f.members {
+		alias := f.members[i].Alias
+
+		if errs[i] != nil {
+			failed = append(failed, fmt.Errorf("%s: %w", alias, errs[i]))
+			continue
+		}
+
+		for _, row := range rows[i] {
+			labeled = append(labeled, append([]string{alias}, row...))
+		}
+
+		results = append(results, clusterItems{Cluster: alias, Items: items[i]})
+	}
+
+	err := o.render(f.out, kind, labeledHeader, labeled, results)
+	if err != nil {
+		return renderError(fmt.Errorf("cli - Fleet - render: %w", err))
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%w: %w", ErrClustersFailed, errors.Join(failed...))
+	}
+
+	return nil
+}
+
+func (m Member) params() ClusterParams {
+	return ClusterParams{
+		ClusterName:  m.ClusterName,
+		AgentAdmin:   m.AgentAdmin,
+		AgentPwd:     m.AgentPwd,
+		ClusterAdmin: m.ClusterAdmin,
+		ClusterPwd:   m.ClusterPwd,
+	}
+}
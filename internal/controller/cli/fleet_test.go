@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+	"github.com/antonmisa/1cctl_cli/internal/usecase"
+	"github.com/antonmisa/1cctl_cli/internal/usecase/mocks"
+	ctrlpipe "github.com/antonmisa/1cctl_cli/internal/usecase/pipe"
+	"github.com/antonmisa/1cctl_cli/internal/usecase/pipe/ractest"
+	"github.com/antonmisa/1cctl_cli/pkg/pipe"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFleetProcessesList(t *testing.T) {
+	prod := mocks.NewCtrl(t)
+
+	prod.On("ClusterByName", mock.Anything, "srv-1:1541", entity.Credentials{Name: "agent"}).
+		Return(entity.Cluster{ID: "1"}, nil).
+		Once()
+
+	prod.On("Processes", mock.Anything, entity.Cluster{ID: "1"}, entity.Credentials{Name: "admin"}).
+		Return([]entity.Process{{ID: "p1", Host: "srv-1", PID: 10}}, nil).
+		Once()
+
+	test := mocks.NewCtrl(t)
+
+	test.On("ClusterByName", mock.Anything, "srv-2:1541", entity.Credentials{}).
+		Return(entity.Cluster{}, errors.New("ras is down")).
+		Once()
+
+	var out bytes.Buffer
+
+	f := NewFleet(context.Background(), []Member{
+		{Alias: "prod", C: prod, ClusterName: "srv-1:1541", AgentAdmin: "agent", ClusterAdmin: "admin"},
+		{Alias: "test", C: test, ClusterName: "srv-2:1541"},
+	}, &out)
+
+	err := f.ProcessesList(ClusterParams{Output: Output{Format: FormatCSV}})
+
+	require.ErrorIs(t, err, ErrClustersFailed)
+	require.ErrorContains(t, err, "test: ")
+	require.ErrorContains(t, err, "ras is down")
+
+	require.Equal(t, "CLUSTER,ID,HOST,PORT,PID,ENABLED,RUNNING,MEMORY KB,CONNECTIONS,AVAILABLE PERF,STARTED\n"+
+		"prod,p1,srv-1,0,10,false,false,0,0,0,\n", out.String())
+}
+
+func TestFleetSessionsListJSON(t *testing.T) {
+	prod := mocks.NewCtrl(t)
+
+	prod.On("ClusterByName", mock.Anything, "srv-1:1541", entity.Credentials{}).
+		Return(entity.Cluster{ID: "1"}, nil).
+		Once()
+
+	prod.On("Sessions", mock.Anything, entity.Cluster{ID: "1"}, entity.Infobase{}, entity.Credentials{}).
+		Return([]entity.Session{{ID: "s1", UserName: "ivanov"}, {ID: "s2", UserName: "petrov"}}, nil).
+		Once()
+
+	var out bytes.Buffer
+
+	f := NewFleet(context.Background(), []Member{
+		{Alias: "prod", C: prod, ClusterName: "srv-1:1541"},
+	}, &out)
+
+	err := f.SessionsList(SessionsParams{
+		Output: Output{Format: FormatJSON},
+		Filter: entity.SessionFilter{UserName: "ivanov"},
+	})
+
+	require.NoError(t, err)
+	require.Contains(t, out.String(), `"schema": "1cctl/v1"`)
+	require.Contains(t, out.String(), `"kind": "sessions"`)
+	require.Contains(t, out.String(), `"cluster": "prod"`)
+	require.Contains(t, out.String(), `"id": "s1"`)
+	require.NotContains(t, out.String(), `"id": "s2"`)
+}
+
+func TestFleetUnknownColumn(t *testing.T) {
+	// Clusters are not queried, mock fails on any call
+	prod := mocks.NewCtrl(t)
+
+	f := NewFleet(context.Background(), []Member{{Alias: "prod", C: prod}}, io.Discard)
+
+	require.ErrorIs(t, f.ServersList(ClusterParams{Output: Output{Sort: "cluster"}}), ErrUnknownColumn)
+	require.ErrorIs(t, f.ServersList(ClusterParams{Output: Output{Columns: []string{"cluster", "pid"}}}), ErrUnknownColumn)
+}
+
+// fleetWithFakes - fleet of two inventory entries served by fake rac, prod needs agent and cluster administrators.
+func fleetWithFakes(t *testing.T, out io.Writer) (*ractest.Rac, *Fleet) {
+	t.Helper()
+
+	rac := ractest.New(t, "testdata/fleet.yaml")
+
+	p, err := pipe.New(rac.Path)
+	require.NoError(t, err)
+
+	return rac, NewFleet(context.Background(), []Member{
+		{
+			Alias:        "prod",
+			C:            usecase.New(ctrlpipe.New(p, "srv-1c:1545"), nil),
+			ClusterName:  "srv-1c:1541",
+			AgentAdmin:   "agent",
+			AgentPwd:     "agent-pwd",
+			ClusterAdmin: "admin",
+			ClusterPwd:   "admin-pwd",
+		},
+		{
+			Alias:       "test",
+			C:           usecase.New(ctrlpipe.New(p, "srv-test:1545"), nil),
+			ClusterName: "srv-test:1541",
+			AgentAdmin:  "agent",
+			AgentPwd:    "agent-pwd",
+		},
+	}, out)
+}
+
+func TestFleetClustersList(t *testing.T) {
+	var out bytes.Buffer
+
+	rac, f := fleetWithFakes(t, &out)
+
+	err := f.ClustersList(ClusterParams{Output: Output{Format: FormatCSV, Columns: []string{"cluster", "host", "name"}}})
+
+	require.NoError(t, err)
+	require.Equal(t, "CLUSTER,HOST,NAME\n"+
+		"prod,srv-1c,Main cluster\nprod,srv-test,Test cluster\n"+
+		"test,srv-1c,Main cluster\ntest,srv-test,Test cluster\n", out.String())
+
+	// every entry is listed by its own RAS
+	var ras []string
+	for _, call := range rac.State(t).Calls {
+		ras = append(ras, call[0])
+	}
+
+	require.ElementsMatch(t, []string{"srv-1c:1545", "srv-test:1545"}, ras)
+}
+
+func TestFleetInfobasesList(t *testing.T) {
+	cases := []struct {
+		name string
+		o    Output
+		out  []string
+	}{
+		{
+			name: "Csv",
+			o:    Output{Format: FormatCSV, Columns: []string{"cluster", "name"}, Sort: "name"},
+			out:  []string{"CLUSTER,NAME\nprod,Buh\nprod,Zup\ntest,Buh_test\n"},
+		},
+		{
+			name: "Json",
+			o:    Output{Format: FormatJSON},
+			out:  []string{`"kind": "infobases"`, `"cluster": "prod"`, `"cluster": "test"`, `"name": "Buh_test"`},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			_, f := fleetWithFakes(t, &out)
+
+			require.NoError(t, f.InfobasesList(ClusterParams{Output: tc.o}))
+
+			for _, s := range tc.out {
+				require.Contains(t, out.String(), s)
+			}
+		})
+	}
+}
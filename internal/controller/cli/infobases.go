@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	e "github.com/antonmisa/1cctl_cli/internal/common/clierror"
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+)
+
+// InfobaseParams - parameters of infobases commands -.
+type InfobaseParams struct {
+	ClusterName  string
+	Infobase     string
+	AgentAdmin   string
+	AgentPwd     string
+	ClusterAdmin string
+	ClusterPwd   string
+	InfobaseUser string
+	InfobasePwd  string
+
+	Format string
+}
+
+// InfobasesShow - prints full properties of infobase.
+func (cc *Ctrl1CCLI) InfobasesShow(p InfobaseParams) error {
+	ctx, cancel := context.WithTimeout(cc.ctx, _defaultOperationTimeout*time.Second)
+	defer cancel()
+
+	agentCred := entity.Credentials{
+		Name: p.AgentAdmin,
+		Pwd:  p.AgentPwd,
+	}
+
+	clusterCred := entity.Credentials{
+		Name: p.ClusterAdmin,
+		Pwd:  p.ClusterPwd,
+	}
+
+	infobaseCred := entity.Credentials{
+		Name: p.InfobaseUser,
+		Pwd:  p.InfobasePwd,
+	}
+
+	cl, ib, err := cc.target(ctx, p.ClusterName, p.Infobase, agentCred, clusterCred)
+	if err != nil {
+		return err
+	}
+
+	if ib == (entity.Infobase{}) {
+		return e.Wrap(e.ErrConfig, fmt.Errorf("cli - InfobasesShow: %w", ErrInfobaseRequired))
+	}
+
+	info, err := cc.c.InfobaseInfo(ctx, cl, ib, clusterCred, infobaseCred)
+	if err != nil {
+		return categorize(nil, fmt.Errorf("cli - InfobasesShow - cc.c.InfobaseInfo: %w", err))
+	}
+
+	err = renderInfobaseInfo(cc.out, p.Format, info)
+	if err != nil {
+		return renderError(fmt.Errorf("cli - InfobasesShow - renderInfobaseInfo: %w", err))
+	}
+
+	return nil
+}
+
+// InfobasesList - prints infobases of cluster.
+func (cc *Ctrl1CCLI) InfobasesList(p ClusterParams) error {
+	ctx, cancel := context.WithTimeout(cc.ctx, _defaultOperationTimeout*time.Second)
+	defer cancel()
+
+	infobases, err := cc.infobases(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	err = renderInfobases(cc.out, p.Output, infobases)
+	if err != nil {
+		return renderError(fmt.Errorf("cli - InfobasesList - renderInfobases: %w", err))
+	}
+
+	return nil
+}
+
+// infobases - getting infobases of cluster.
+func (cc *Ctrl1CCLI) infobases(ctx context.Context, p ClusterParams) ([]entity.Infobase, error) {
+	agentCred, clusterCred := p.credentials()
+
+	cl, err := cc.c.ClusterByName(ctx, p.ClusterName, agentCred)
+	if err != nil {
+		return nil, lookupError(fmt.Errorf("cli - infobases - cc.c.ClusterByName: %w", err))
+	}
+
+	infobases, err := cc.c.Infobases(ctx, cl, clusterCred)
+	if err != nil {
+		return nil, categorize(nil, fmt.Errorf("cli - infobases - cc.c.Infobases: %w", err))
+	}
+
+	return infobases, nil
+}
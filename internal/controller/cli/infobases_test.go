@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antonmisa/1cctl_cli/internal/common/clierror"
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+	"github.com/antonmisa/1cctl_cli/internal/usecase"
+	"github.com/antonmisa/1cctl_cli/internal/usecase/mocks"
+)
+
+func TestInfobasesShow(t *testing.T) {
+	errRAS := errors.New("ras failed")
+
+	info := entity.InfobaseInfo{
+		ID:             "2",
+		Name:           "Buh",
+		DBMS:           "PostgreSQL",
+		DBServer:       "db-1c",
+		SessionsDeny:   true,
+		DeniedMessage:  "Planned Maintenance",
+		PermissionCode: "Maint",
+	}
+
+	cases := []struct {
+		name     string
+		infobase string
+		cluster  error
+		lookup   error
+		err      error
+		out      []string
+		code     int
+	}{
+		{
+			name:     "Properties",
+			infobase: "buh",
+			out: []string{
+				"PROPERTY,VALUE\nid,2\nname,Buh\n",
+				"dbms,PostgreSQL\ndb-server,db-1c\n",
+				"sessions-deny,true\n",
+				"denied-message,Planned Maintenance\n",
+				"permission-code,Maint\n",
+			},
+			code: clierror.CodeOK,
+		},
+		{
+			name: "No infobase",
+			code: clierror.CodeConfig,
+		},
+		{
+			name:     "Cluster not found",
+			infobase: "buh",
+			cluster:  usecase.ErrClusterNotFound,
+			code:     clierror.CodeConfig,
+		},
+		{
+			name:     "Infobase not found",
+			infobase: "buh",
+			lookup:   usecase.ErrInfobaseNotFound,
+			code:     clierror.CodeConfig,
+		},
+		{
+			name:     "Wrong password",
+			infobase: "buh",
+			err:      &usecase.ClusterError{Kind: usecase.ErrAuthFailed, Err: errRAS},
+			code:     clierror.CodeAuth,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := mocks.NewCtrl(t)
+
+			c.On("ClusterByName", mock.Anything, "srv", mock.Anything).Return(entity.Cluster{ID: "1"}, tc.cluster).Once()
+
+			if tc.infobase != "" && tc.cluster == nil {
+				c.On("InfobaseByName", mock.Anything, entity.Cluster{ID: "1"}, "buh", mock.Anything).Return(entity.Infobase{ID: "2", Name: "Buh"}, tc.lookup).Once()
+			}
+
+			if tc.infobase != "" && tc.cluster == nil && tc.lookup == nil {
+				c.On("InfobaseInfo", mock.Anything, entity.Cluster{ID: "1"}, entity.Infobase{ID: "2", Name: "Buh"},
+					mock.Anything, entity.Credentials{Name: "robot", Pwd: "robot-pwd"}).Return(info, tc.err).Once()
+			}
+
+			var out bytes.Buffer
+
+			cc := New(context.Background(), c, strings.NewReader(""), &out)
+
+			err := cc.InfobasesShow(InfobaseParams{
+				ClusterName:  "srv",
+				Infobase:     tc.infobase,
+				InfobaseUser: "robot",
+				InfobasePwd:  "robot-pwd",
+				Format:       FormatCSV,
+			})
+
+			require.Equal(t, tc.code, clierror.Code(err), err)
+
+			for _, s := range tc.out {
+				require.Contains(t, out.String(), s)
+			}
+
+			if tc.code != clierror.CodeOK {
+				require.Empty(t, out.String())
+			}
+		})
+	}
+}
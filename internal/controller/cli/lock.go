@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	e "github.com/antonmisa/1cctl_cli/internal/common/clierror"
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+)
+
+// Lock - denies new sessions of infobase by policy, sessions already open are kept.
+func (cc *Ctrl1CCLI) Lock(p InfobaseParams, policy entity.LockPolicy) error {
+	ctx, cancel := context.WithTimeout(cc.ctx, _defaultOperationTimeout*time.Second)
+	defer cancel()
+
+	cl, ib, clusterCred, infobaseCred, err := cc.lockTarget(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	err = cc.c.DisableSessions(ctx, cl, ib, clusterCred, infobaseCred, policy)
+	if err != nil {
+		return categorize(e.ErrLockFailed, fmt.Errorf("cli - Lock - cc.c.DisableSessions: %w", err))
+	}
+
+	fmt.Fprintf(cc.out, "infobase %s is locked for %s\n", ib.Name, policy.Window)
+
+	return nil
+}
+
+// Unlock - allows sessions and scheduled jobs of infobase, lock message and code are cleared.
+func (cc *Ctrl1CCLI) Unlock(p InfobaseParams) error {
+	ctx, cancel := context.WithTimeout(cc.ctx, _defaultOperationTimeout*time.Second)
+	defer cancel()
+
+	cl, ib, clusterCred, infobaseCred, err := cc.lockTarget(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	err = cc.c.RestoreLock(ctx, cl, ib, clusterCred, infobaseCred, entity.InfobaseLock{InfobaseID: ib.ID})
+	if err != nil {
+		return categorize(e.ErrUnlockFailed, fmt.Errorf("cli - Unlock - cc.c.RestoreLock: %w", err))
+	}
+
+	fmt.Fprintf(cc.out, "infobase %s is unlocked\n", ib.Name)
+
+	return nil
+}
+
+// lockTarget - cluster and infobase to lock with credentials, infobase is required.
+func (cc *Ctrl1CCLI) lockTarget(ctx context.Context, p InfobaseParams) (entity.Cluster, entity.Infobase, entity.Credentials, entity.Credentials, error) {
+	agentCred := entity.Credentials{
+		Name: p.AgentAdmin,
+		Pwd:  p.AgentPwd,
+	}
+
+	clusterCred := entity.Credentials{
+		Name: p.ClusterAdmin,
+		Pwd:  p.ClusterPwd,
+	}
+
+	infobaseCred := entity.Credentials{
+		Name: p.InfobaseUser,
+		Pwd:  p.InfobasePwd,
+	}
+
+	if p.Infobase == "" {
+		return entity.Cluster{}, entity.Infobase{}, clusterCred, infobaseCred, e.Wrap(e.ErrConfig, fmt.Errorf("cli - lockTarget: %w", ErrInfobaseRequired))
+	}
+
+	cl, ib, err := cc.target(ctx, p.ClusterName, p.Infobase, agentCred, clusterCred)
+	if err != nil {
+		return entity.Cluster{}, entity.Infobase{}, clusterCred, infobaseCred, err
+	}
+
+	return cl, ib, clusterCred, infobaseCred, nil
+}
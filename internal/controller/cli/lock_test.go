@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antonmisa/1cctl_cli/internal/common/clierror"
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+	"github.com/antonmisa/1cctl_cli/internal/usecase"
+	"github.com/antonmisa/1cctl_cli/internal/usecase/mocks"
+)
+
+func TestLockUnlock(t *testing.T) {
+	errRAS := errors.New("ras failed")
+	policy := entity.LockPolicy{Code: "12345", Window: time.Hour}
+
+	cases := []struct {
+		name     string
+		infobase string
+		lookup   error
+		err      error
+		unlock   bool
+		code     int
+	}{
+		{
+			name:     "Lock",
+			infobase: "buh",
+			code:     clierror.CodeOK,
+		},
+		{
+			name:     "Unlock",
+			infobase: "buh",
+			unlock:   true,
+			code:     clierror.CodeOK,
+		},
+		{
+			name: "No infobase",
+			code: clierror.CodeConfig,
+		},
+		{
+			name:     "Infobase not found",
+			infobase: "buh",
+			lookup:   usecase.ErrInfobaseNotFound,
+			code:     clierror.CodeConfig,
+		},
+		{
+			name:     "Lock failed",
+			infobase: "buh",
+			err:      errRAS,
+			code:     clierror.CodeLockFailed,
+		},
+		{
+			name:     "Unlock failed",
+			infobase: "buh",
+			unlock:   true,
+			err:      errRAS,
+			code:     clierror.CodeUnlockFailed,
+		},
+		{
+			name:     "Wrong password",
+			infobase: "buh",
+			err:      &usecase.ClusterError{Kind: usecase.ErrAuthFailed, Err: errRAS},
+			code:     clierror.CodeAuth,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := mocks.NewCtrl(t)
+
+			if tc.infobase != "" {
+				c.On("ClusterByName", mock.Anything, "srv", mock.Anything).Return(entity.Cluster{ID: "1"}, nil).Once()
+				c.On("InfobaseByName", mock.Anything, mock.Anything, "buh", mock.Anything).Return(entity.Infobase{ID: "2", Name: "buh"}, tc.lookup).Once()
+			}
+
+			if tc.infobase != "" && tc.lookup == nil {
+				if tc.unlock {
+					c.On("RestoreLock", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, entity.InfobaseLock{InfobaseID: "2"}).Return(tc.err).Once()
+				} else {
+					c.On("DisableSessions", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, policy).Return(tc.err).Once()
+				}
+			}
+
+			cc := New(context.Background(), c, strings.NewReader(""), &bytes.Buffer{})
+
+			p := InfobaseParams{ClusterName: "srv", Infobase: tc.infobase}
+
+			var err error
+
+			if tc.unlock {
+				err = cc.Unlock(p)
+			} else {
+				err = cc.Lock(p, policy)
+			}
+
+			require.Equal(t, tc.code, clierror.Code(err), err)
+		})
+	}
+}
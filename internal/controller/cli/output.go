@@ -0,0 +1,455 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+)
+
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatCSV   = "csv"
+	FormatYAML  = "yaml"
+
+	// SchemaVersion - version of json and yaml documents of listings, it is changed
+	// only if fields are removed or change their meaning, new fields may be added within version.
+	SchemaVersion = "1cctl/v1"
+
+	_formatTime = "2006-01-02 15:04:05"
+)
+
+// Kinds of listings, they tell what items of json and yaml document are.
+const (
+	KindClusters    = "clusters"
+	KindInfobases   = "infobases"
+	KindSessions    = "sessions"
+	KindConnections = "connections"
+	KindProcesses   = "processes"
+	KindServers     = "servers"
+)
+
+var (
+	ErrUnknownFormat    = errors.New("unknown output format")
+	ErrUnknownColumn    = errors.New("unknown column")
+	ErrInfobaseRequired = errors.New("infobase name is required")
+)
+
+// Output - how listing is printed: format, columns of table and csv, column to sort by,
+// prefixed by "-" for descending order -.
+type Output struct {
+	Format  string
+	Columns []string
+	Sort    string
+}
+
+// document - json and yaml representation of listing, its layout is versioned by schema -.
+type document struct {
+	Schema string `json:"schema"`
+	Kind   string `json:"kind"`
+	Items  any    `json:"items"`
+}
+
+var (
+	_clustersHeader    = []string{"ID", "HOST", "PORT", "NAME"}
+	_infobasesHeader   = []string{"ID", "NAME", "DESCRIPTION"}
+	_sessionsHeader    = []string{"ID", "SID", "INFOBASE", "USER", "HOST", "APP", "STARTED", "LAST ACTIVE"}
+	_connectionsHeader = []string{"ID", "CID", "INFOBASE", "HOST", "APP", "CONNECTED", "SESSION"}
+	_processesHeader   = []string{"ID", "HOST", "PORT", "PID", "ENABLED", "RUNNING", "MEMORY KB", "CONNECTIONS", "AVAILABLE PERF", "STARTED"}
+	_serversHeader     = []string{"ID", "NAME", "HOST", "PORT", "PORT RANGE", "USING", "MEMORY LIMIT", "CONNECTIONS LIMIT"}
+)
+
+// render - writes rows in table or csv format, v is used as is for json and yaml formats.
+func render(w io.Writer, format string, header []string, rows [][]string, v any) error {
+	switch strings.ToLower(format) {
+	case FormatTable, "":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0) //nolint:gomnd // padding between columns
+
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+		for i := range rows {
+			fmt.Fprintln(tw, strings.Join(rows[i], "\t"))
+		}
+
+		return tw.Flush()
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(v)
+	case FormatYAML:
+		return encodeYAML(w, v)
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+
+		if err := cw.WriteAll(rows); err != nil {
+			return err
+		}
+
+		return cw.Error()
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+}
+
+// encodeYAML - writes v with the same keys and order of fields as json has, items are tagged for json only.
+func encodeYAML(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	// JSON is YAML, its flow style is dropped to get usual block one
+	var node yaml.Node
+
+	if err = yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+
+	blockStyle(&node)
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2) //nolint:gomnd // the same indent as of json
+
+	if err = enc.Encode(&node); err != nil {
+		return err
+	}
+
+	return enc.Close()
+}
+
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+
+	for _, n := range node.Content {
+		blockStyle(n)
+	}
+}
+
+// renderList - sorts rows with items they are made of and renders them: json and yaml get
+// all fields of items in versioned document, table and csv get selected columns of rows.
+func renderList[T any](w io.Writer, o Output, kind string, header []string, rows [][]string, items []T) error {
+	rows, items, err := sorted(o, header, rows, items)
+	if err != nil {
+		return err
+	}
+
+	return o.render(w, kind, header, rows, items)
+}
+
+func (o Output) render(w io.Writer, kind string, header []string, rows [][]string, items any) error {
+	switch strings.ToLower(o.Format) {
+	case FormatJSON, FormatYAML:
+		return render(w, o.Format, header, rows, document{Schema: SchemaVersion, Kind: kind, Items: items})
+	}
+
+	header, rows, err := o.selected(header, rows)
+	if err != nil {
+		return err
+	}
+
+	return render(w, o.Format, header, rows, nil)
+}
+
+// selected - header and rows reduced to columns of output in their order, all columns if none are given.
+func (o Output) selected(header []string, rows [][]string) ([]string, [][]string, error) {
+	if len(o.Columns) == 0 {
+		return header, rows, nil
+	}
+
+	indexes := make([]int, 0, len(o.Columns))
+
+	for _, name := range o.Columns {
+		i, err := columnIndex(header, name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		indexes = append(indexes, i)
+	}
+
+	pick := func(row []string) []string {
+		picked := make([]string, 0, len(indexes))
+
+		for _, i := range indexes {
+			picked = append(picked, row[i])
+		}
+
+		return picked
+	}
+
+	picked := make([][]string, 0, len(rows))
+
+	for i := range rows {
+		picked = append(picked, pick(rows[i]))
+	}
+
+	return pick(header), picked, nil
+}
+
+// sortColumn - index of column to sort by and whether order is descending, -1 if sorting is not asked.
+func (o Output) sortColumn(header []string) (int, bool, error) {
+	if o.Sort == "" {
+		return -1, false, nil
+	}
+
+	name := strings.TrimPrefix(o.Sort, "-")
+
+	i, err := columnIndex(header, name)
+	if err != nil {
+		return -1, false, err
+	}
+
+	return i, name != o.Sort, nil
+}
+
+// sorted - rows and items they are made of in order of sort column, equal rows keep their order.
+func sorted[T any](o Output, header []string, rows [][]string, items []T) ([][]string, []T, error) {
+	col, desc, err := o.sortColumn(header)
+	if err != nil || col < 0 {
+		return rows, items, err
+	}
+
+	order := make([]int, len(rows))
+
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		x, y := rows[order[a]][col], rows[order[b]][col]
+
+		if desc {
+			x, y = y, x
+		}
+
+		return less(x, y)
+	})
+
+	sortedRows := make([][]string, 0, len(order))
+	sortedItems := make([]T, 0, len(order))
+
+	for _, i := range order {
+		sortedRows = append(sortedRows, rows[i])
+		sortedItems = append(sortedItems, items[i])
+	}
+
+	return sortedRows, sortedItems, nil
+}
+
+// less - numbers are compared as numbers, other values as text, times are formatted to be so.
+func less(x, y string) bool {
+	a, errX := strconv.Atoi(x)
+	b, errY := strconv.Atoi(y)
+
+	if errX == nil && errY == nil {
+		return a < b
+	}
+
+	return x < y
+}
+
+// columnIndex - column of header by name, case is ignored and "-" or "_" match space: last-active is LAST ACTIVE.
+func columnIndex(header []string, name string) (int, error) {
+	key := columnKey(name)
+
+	for i := range header {
+		if columnKey(header[i]) == key {
+			return i, nil
+		}
+	}
+
+	return -1, fmt.Errorf("%w: %s, known are: %s", ErrUnknownColumn, name, strings.Join(header, ", "))
+}
+
+func columnKey(name string) string {
+	return strings.NewReplacer("-", " ", "_", " ").Replace(strings.ToUpper(strings.TrimSpace(name)))
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(_formatTime)
+}
+
+func renderSessions(w io.Writer, o Output, sessions []entity.Session) error {
+	return renderList(w, o, KindSessions, _sessionsHeader, sessionsRows(sessions), sessionsV1(sessions))
+}
+
+func sessionsRows(sessions []entity.Session) [][]string {
+	rows := make([][]string, 0, len(sessions))
+
+	for i := range sessions {
+		s := &sessions[i]
+
+		rows = append(rows, []string{
+			s.ID,
+			strconv.Itoa(s.SID),
+			s.InfobaseID,
+			s.UserName,
+			s.Host,
+			s.AppID,
+			formatTime(s.Started),
+			formatTime(s.LastActive),
+		})
+	}
+
+	return rows
+}
+
+func renderConnections(w io.Writer, o Output, connections []entity.Connection) error {
+	return renderList(w, o, KindConnections, _connectionsHeader, connectionsRows(connections), connectionsV1(connections))
+}
+
+func connectionsRows(connections []entity.Connection) [][]string {
+	rows := make([][]string, 0, len(connections))
+
+	for i := range connections {
+		c := &connections[i]
+
+		rows = append(rows, []string{
+			c.ID,
+			strconv.Itoa(c.CID),
+			c.InfobaseID,
+			c.Host,
+			c.AppID,
+			formatTime(c.Connected),
+			strconv.Itoa(c.SID),
+		})
+	}
+
+	return rows
+}
+
+func renderInfobaseInfo(w io.Writer, format string, info entity.InfobaseInfo) error {
+	header := []string{"PROPERTY", "VALUE"}
+
+	rows := [][]string{
+		{"id", info.ID},
+		{"name", info.Name},
+		{"descr", info.Desc},
+		{"dbms", info.DBMS},
+		{"db-server", info.DBServer},
+		{"db-name", info.DBName},
+		{"db-user", info.DBUser},
+		{"locale", info.Locale},
+		{"date-offset", strconv.Itoa(info.DateOffset)},
+		{"security-level", strconv.Itoa(info.SecLevel)},
+		{"license-distribution", info.LicenseDistribution},
+		{"sessions-deny", strconv.FormatBool(info.SessionsDeny)},
+		{"denied-from", formatTime(info.DeniedFrom)},
+		{"denied-to", formatTime(info.DeniedTo)},
+		{"denied-message", info.DeniedMessage},
+		{"denied-parameter", info.DeniedParameter},
+		{"permission-code", info.PermissionCode},
+		{"scheduled-jobs-deny", strconv.FormatBool(info.ScheduledJobsDeny)},
+		{"external-session-manager-connection-string", info.ExtSessionMgrConn},
+		{"external-session-manager-required", strconv.FormatBool(info.ExtSessionMgrRequired)},
+		{"security-profile-name", info.SecProfile},
+		{"safe-mode-security-profile-name", info.SafeModeSecProfile},
+		{"reserve-working-processes", strconv.FormatBool(info.ReserveProcesses)},
+	}
+
+	return render(w, format, header, rows, infobaseInfoV1(info))
+}
+
+func renderProcesses(w io.Writer, o Output, processes []entity.Process) error {
+	return renderList(w, o, KindProcesses, _processesHeader, processesRows(processes), processesV1(processes))
+}
+
+func processesRows(processes []entity.Process) [][]string {
+	rows := make([][]string, 0, len(processes))
+
+	for i := range processes {
+		p := &processes[i]
+
+		rows = append(rows, []string{
+			p.ID,
+			p.Host,
+			strconv.Itoa(p.Port),
+			strconv.Itoa(p.PID),
+			strconv.FormatBool(p.Enabled),
+			strconv.FormatBool(p.Running),
+			strconv.Itoa(p.Memory),
+			strconv.Itoa(p.Connections),
+			strconv.Itoa(p.AvailPerf),
+			formatTime(p.Started),
+		})
+	}
+
+	return rows
+}
+
+func renderServers(w io.Writer, o Output, servers []entity.Server) error {
+	return renderList(w, o, KindServers, _serversHeader, serversRows(servers), serversV1(servers))
+}
+
+func serversRows(servers []entity.Server) [][]string {
+	rows := make([][]string, 0, len(servers))
+
+	for i := range servers {
+		s := &servers[i]
+
+		rows = append(rows, []string{
+			s.ID,
+			s.Name,
+			s.Host,
+			strconv.Itoa(s.Port),
+			s.PortRange,
+			s.Using,
+			strconv.Itoa(s.MemoryLimit),
+			strconv.Itoa(s.ConnectionsLimit),
+		})
+	}
+
+	return rows
+}
+
+func renderClusters(w io.Writer, o Output, clusters []entity.Cluster) error {
+	return renderList(w, o, KindClusters, _clustersHeader, clustersRows(clusters), clustersV1(clusters))
+}
+
+func clustersRows(clusters []entity.Cluster) [][]string {
+	rows := make([][]string, 0, len(clusters))
+
+	for i := range clusters {
+		c := &clusters[i]
+
+		rows = append(rows, []string{c.ID, c.Host, c.Port, c.Name})
+	}
+
+	return rows
+}
+
+func renderInfobases(w io.Writer, o Output, infobases []entity.Infobase) error {
+	return renderList(w, o, KindInfobases, _infobasesHeader, infobasesRows(infobases), infobasesV1(infobases))
+}
+
+func infobasesRows(infobases []entity.Infobase) [][]string {
+	rows := make([][]string, 0, len(infobases))
+
+	for i := range infobases {
+		ib := &infobases[i]
+
+		rows = append(rows, []string{ib.ID, ib.Name, ib.Desc})
+	}
+
+	return rows
+}
@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+)
+
+var _update = flag.Bool("update", false, "rewrite golden documents of testdata/golden")
+
+func TestRenderSessions(t *testing.T) {
+	started := time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC)
+
+	sessions := []entity.Session{
+		{ID: "s1", SID: 10, UserName: "petrov", Host: "pc-1", Started: started},
+		{ID: "s2", SID: 2, UserName: "ivanov", Host: "pc-2", Started: started.Add(time.Hour)},
+		{ID: "s3", SID: 7, UserName: "sidorov", Host: "pc-1"},
+	}
+
+	cases := []struct {
+		name string
+		o    Output
+		out  string
+		err  error
+	}{
+		{
+			name: "Table of columns",
+			o:    Output{Columns: []string{"user", "HOST"}},
+			out:  "USER     HOST\npetrov   pc-1\nivanov   pc-2\nsidorov  pc-1\n",
+		},
+		{
+			name: "Numbers sorted as numbers",
+			o:    Output{Format: FormatCSV, Columns: []string{"sid"}, Sort: "sid"},
+			out:  "SID\n2\n7\n10\n",
+		},
+		{
+			name: "Descending order",
+			o:    Output{Format: FormatCSV, Columns: []string{"id", "started"}, Sort: "-started"},
+			out:  "ID,STARTED\ns2,2024-02-01 10:00:00\ns1,2024-02-01 09:00:00\ns3,\n",
+		},
+		{
+			name: "Equal rows keep order",
+			o:    Output{Format: FormatCSV, Columns: []string{"id"}, Sort: "host"},
+			out:  "ID\ns1\ns3\ns2\n",
+		},
+		{
+			name: "Unknown sort column",
+			o:    Output{Sort: "memory"},
+			err:  ErrUnknownColumn,
+		},
+		{
+			name: "Unknown column",
+			o:    Output{Columns: []string{"id", "pid"}},
+			err:  ErrUnknownColumn,
+		},
+		{
+			name: "Unknown format",
+			o:    Output{Format: "xml"},
+			err:  ErrUnknownFormat,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var out bytes.Buffer
+
+			err := renderSessions(&out, tc.o, sessions)
+
+			require.ErrorIs(t, err, tc.err)
+
+			if tc.err == nil {
+				require.Equal(t, tc.out, out.String())
+			}
+		})
+	}
+}
+
+// TestRenderDocuments - json and yaml documents of every kind are compared with golden ones as a whole,
+// as they are the contract of schema 1cctl/v1. Run with -update to rewrite them after intended change.
+func TestRenderDocuments(t *testing.T) {
+	at := time.Date(2024, 2, 1, 9, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+
+	cases := []struct {
+		name   string
+		render func(w *bytes.Buffer, format string) error
+	}{
+		{
+			name: "clusters",
+			render: func(w *bytes.Buffer, format string) error {
+				return renderClusters(w, Output{Format: format}, []entity.Cluster{
+					{ID: "c1", Host: "srv-1c", Port: "1541", Name: "Main", LBMode: "performance"},
+				})
+			},
+		},
+		{
+			name: "infobases",
+			render: func(w *bytes.Buffer, format string) error {
+				return renderInfobases(w, Output{Format: format}, []entity.Infobase{
+					{ID: "ib1", Name: "accounting", Desc: "Бухгалтерия"},
+					{ID: "ib2", Name: "hrm"},
+				})
+			},
+		},
+		{
+			name: "infobase",
+			render: func(w *bytes.Buffer, format string) error {
+				return renderInfobaseInfo(w, format, entity.InfobaseInfo{
+					ID: "ib1", Name: "accounting", DBMS: "PostgreSQL", DBServer: "db-1", DBName: "accounting",
+					DBUser: "postgres", SessionsDeny: true, DeniedFrom: at, DeniedMessage: "Backup is in progress",
+					PermissionCode: "Backup",
+				})
+			},
+		},
+		{
+			name: "sessions",
+			render: func(w *bytes.Buffer, format string) error {
+				return renderSessions(w, Output{Format: format}, []entity.Session{
+					{
+						ID: "s1", SID: 10, InfobaseID: "ib1", ConnectionID: "cn1", ProcessID: "p1",
+						UserName: "petrov", Host: "pc-1", AppID: "1CV8C", Loc: "ru_RU", Started: at, LastActive: at,
+					},
+				})
+			},
+		},
+		{
+			name: "connections",
+			render: func(w *bytes.Buffer, format string) error {
+				return renderConnections(w, Output{Format: format}, []entity.Connection{
+					{ID: "cn1", CID: 3, InfobaseID: "ib1", ProcessID: "p1", Host: "pc-1", AppID: "1CV8C", Connected: at, SID: 10},
+				})
+			},
+		},
+		{
+			name: "processes",
+			render: func(w *bytes.Buffer, format string) error {
+				return renderProcesses(w, Output{Format: format}, []entity.Process{
+					{ID: "p1", Host: "srv-1c", Port: 1560, PID: 4242, Enabled: true, Running: true, Started: at, Use: "used"},
+				})
+			},
+		},
+		{
+			name: "servers",
+			render: func(w *bytes.Buffer, format string) error {
+				return renderServers(w, Output{Format: format}, []entity.Server{
+					{ID: "sv1", Host: "srv-1c", Port: 1540, PortRange: "1560:1591", Name: "Central", Using: "main"},
+				})
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		for _, format := range []string{FormatJSON, FormatYAML} {
+			format := format
+
+			t.Run(tc.name+"."+format, func(t *testing.T) {
+				t.Parallel()
+
+				var out bytes.Buffer
+
+				require.NoError(t, tc.render(&out, format))
+
+				golden := filepath.Join("testdata", "golden", tc.name+"."+format)
+
+				if *_update {
+					require.NoError(t, os.WriteFile(golden, out.Bytes(), 0o644)) //nolint:gosec // test data
+				}
+
+				want, err := os.ReadFile(golden)
+
+				require.NoError(t, err)
+				require.Equal(t, string(want), out.String())
+			})
+		}
+	}
+}
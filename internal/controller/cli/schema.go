@@ -0,0 +1,224 @@
+package cli
+
+import (
+	"time"
+
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+)
+
+// Items of json and yaml documents of schema 1cctl/v1. Keys are rac property names in snake case,
+// references to other objects end with _id. Fields are the same as of entities, so they are converted
+// to each other and new field of entity fails build until it is named here.
+
+// clusterV1 - cluster -.
+type clusterV1 struct {
+	ID            string `json:"id"`
+	Host          string `json:"host"`
+	Port          string `json:"port"`
+	Name          string `json:"name"`
+	Exp           int    `json:"expiration_timeout"`
+	LT            int    `json:"lifetime_limit"`
+	MaxMemSize    int    `json:"max_memory_size"`
+	MaxMemTimeLim int    `json:"max_memory_time_limit"`
+	SecLevel      int    `json:"security_level"`
+	SesFTLevel    int    `json:"session_fault_tolerance_level"`
+	LBMode        string `json:"load_balancing_mode"`
+	ErrCountTh    int    `json:"errors_count_threshold"`
+	KillPP        int    `json:"kill_problem_processes"`
+}
+
+// infobaseV1 - infobase of list -.
+type infobaseV1 struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Desc string `json:"description"`
+}
+
+// infobaseInfoV1 - properties of infobase shown by infobases show -.
+type infobaseInfoV1 struct {
+	ID                    string    `json:"id"`
+	Name                  string    `json:"name"`
+	Desc                  string    `json:"description"`
+	DBMS                  string    `json:"dbms"`
+	DBServer              string    `json:"db_server"`
+	DBName                string    `json:"db_name"`
+	DBUser                string    `json:"db_user"`
+	Locale                string    `json:"locale"`
+	DateOffset            int       `json:"date_offset"`
+	SecLevel              int       `json:"security_level"`
+	LicenseDistribution   string    `json:"license_distribution"`
+	SessionsDeny          bool      `json:"sessions_deny"`
+	DeniedFrom            time.Time `json:"denied_from"`
+	DeniedTo              time.Time `json:"denied_to"`
+	DeniedMessage         string    `json:"denied_message"`
+	DeniedParameter       string    `json:"denied_parameter"`
+	PermissionCode        string    `json:"permission_code"`
+	ScheduledJobsDeny     bool      `json:"scheduled_jobs_deny"`
+	ExtSessionMgrConn     string    `json:"external_session_manager_connection_string"`
+	ExtSessionMgrRequired bool      `json:"external_session_manager_required"`
+	SecProfile            string    `json:"security_profile_name"`
+	SafeModeSecProfile    string    `json:"safe_mode_security_profile_name"`
+	ReserveProcesses      bool      `json:"reserve_working_processes"`
+}
+
+// sessionV1 - session -.
+type sessionV1 struct {
+	ID             string    `json:"id"`
+	SID            int       `json:"number"`
+	InfobaseID     string    `json:"infobase_id"`
+	ConnectionID   string    `json:"connection_id"`
+	ProcessID      string    `json:"process_id"`
+	UserName       string    `json:"user_name"`
+	Host           string    `json:"host"`
+	AppID          string    `json:"app_id"`
+	Loc            string    `json:"locale"`
+	Started        time.Time `json:"started_at"`
+	LastActive     time.Time `json:"last_active_at"`
+	Hibernate      string    `json:"hibernate"`
+	HiberTime      int       `json:"passive_session_hibernate_time"`
+	HiberTermTime  int       `json:"hibernate_session_terminate_time"`
+	BlockedDB      int       `json:"blocked_by_dbms"`
+	BlockedLS      int       `json:"blocked_by_ls"`
+	Bytes          int       `json:"bytes_all"`
+	Bytes5m        int       `json:"bytes_last_5min"`
+	Calls          int       `json:"calls_all"`
+	Calls5m        int       `json:"calls_last_5min"`
+	BytesDB        int       `json:"dbms_bytes_all"`
+	BytesDB5m      int       `json:"dbms_bytes_last_5min"`
+	DBProcInfo     string    `json:"db_proc_info"`
+	DBProc         int       `json:"db_proc_took"`
+	DBProcAt       string    `json:"db_proc_took_at"`
+	Duration       int       `json:"duration_all"`
+	DurationDB     int       `json:"duration_all_dbms"`
+	DurationCur    int       `json:"duration_current"`
+	DurationCurDB  int       `json:"duration_current_dbms"`
+	Duration5m     int       `json:"duration_last_5min"`
+	DurationDB5m   int       `json:"duration_last_5min_dbms"`
+	MemoryCur      int       `json:"memory_current"`
+	Memory5m       int       `json:"memory_last_5min"`
+	Memory         int       `json:"memory_total"`
+	ReadCur        int       `json:"read_current"`
+	Read5m         int       `json:"read_last_5min"`
+	Read           int       `json:"read_total"`
+	WriteCur       int       `json:"write_current"`
+	Write5m        int       `json:"write_last_5min"`
+	Write          int       `json:"write_total"`
+	DurationSvcCur int       `json:"duration_current_service"`
+	DurationSvc5m  int       `json:"duration_last_5min_service"`
+	DurationSvc    int       `json:"duration_all_service"`
+	Svc            string    `json:"current_service_name"`
+	CPUCur         int       `json:"cpu_time_current"`
+	CPU5m          int       `json:"cpu_time_last_5min"`
+	CPU            int       `json:"cpu_time_total"`
+	Sep            string    `json:"data_separation"`
+}
+
+// connectionV1 - connection -.
+type connectionV1 struct {
+	ID         string    `json:"id"`
+	CID        int       `json:"number"`
+	InfobaseID string    `json:"infobase_id"`
+	ProcessID  string    `json:"process_id"`
+	Host       string    `json:"host"`
+	AppID      string    `json:"app_id"`
+	Connected  time.Time `json:"connected_at"`
+	SID        int       `json:"session_number"`
+	Blocked    int       `json:"blocked_by_ls"`
+}
+
+// processV1 - working process -.
+type processV1 struct {
+	ID          string    `json:"id"`
+	Host        string    `json:"host"`
+	Port        int       `json:"port"`
+	PID         int       `json:"pid"`
+	Enabled     bool      `json:"enabled"`
+	Running     bool      `json:"running"`
+	Started     time.Time `json:"started_at"`
+	Use         string    `json:"use"`
+	AvailPerf   int       `json:"available_performance"`
+	Capacity    int       `json:"capacity"`
+	Connections int       `json:"connections"`
+	Memory      int       `json:"memory_size"`
+	MemExcess   int       `json:"memory_excess_time"`
+	Selection   int       `json:"selection_size"`
+	Reserve     bool      `json:"reserve"`
+}
+
+// serverV1 - working server -.
+type serverV1 struct {
+	ID                string `json:"id"`
+	Host              string `json:"host"`
+	Port              int    `json:"port"`
+	PortRange         string `json:"port_range"`
+	Name              string `json:"name"`
+	Using             string `json:"using"`
+	DedicateManagers  string `json:"dedicate_managers"`
+	InfobasesLimit    int    `json:"infobases_limit"`
+	MemoryLimit       int    `json:"memory_limit"`
+	ConnectionsLimit  int    `json:"connections_limit"`
+	ClusterPort       int    `json:"cluster_port"`
+	CriticalMemory    int    `json:"critical_total_memory"`
+	SafeProcessMemory int    `json:"safe_working_processes_memory_limit"`
+	SafeCallMemory    int    `json:"safe_call_memory_limit"`
+}
+
+func clustersV1(clusters []entity.Cluster) []clusterV1 {
+	rv := make([]clusterV1, 0, len(clusters))
+
+	for i := range clusters {
+		rv = append(rv, clusterV1(clusters[i]))
+	}
+
+	return rv
+}
+
+func infobasesV1(infobases []entity.Infobase) []infobaseV1 {
+	rv := make([]infobaseV1, 0, len(infobases))
+
+	for i := range infobases {
+		rv = append(rv, infobaseV1(infobases[i]))
+	}
+
+	return rv
+}
+
+func sessionsV1(sessions []entity.Session) []sessionV1 {
+	rv := make([]sessionV1, 0, len(sessions))
+
+	for i := range sessions {
+		rv = append(rv, sessionV1(sessions[i]))
+	}
+
+	return rv
+}
+
+func connectionsV1(connections []entity.Connection) []connectionV1 {
+	rv := make([]connectionV1, 0, len(connections))
+
+	for i := range connections {
+		rv = append(rv, connectionV1(connections[i]))
+	}
+
+	return rv
+}
+
+func processesV1(processes []entity.Process) []processV1 {
+	rv := make([]processV1, 0, len(processes))
+
+	for i := range processes {
+		rv = append(rv, processV1(processes[i]))
+	}
+
+	return rv
+}
+
+func serversV1(servers []entity.Server) []serverV1 {
+	rv := make([]serverV1, 0, len(servers))
+
+	for i := range servers {
+		rv = append(rv, serverV1(servers[i]))
+	}
+
+	return rv
+}
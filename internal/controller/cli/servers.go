@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+)
+
+// ClusterParams - parameters of cluster wide commands -.
+type ClusterParams struct {
+	ClusterName  string
+	AgentAdmin   string
+	AgentPwd     string
+	ClusterAdmin string
+	ClusterPwd   string
+
+	Output
+}
+
+// ProcessesList - prints working processes of cluster.
+func (cc *Ctrl1CCLI) ProcessesList(p ClusterParams) error {
+	ctx, cancel := context.WithTimeout(cc.ctx, _defaultOperationTimeout*time.Second)
+	defer cancel()
+
+	processes, err := cc.processes(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	err = renderProcesses(cc.out, p.Output, processes)
+	if err != nil {
+		return renderError(fmt.Errorf("cli - ProcessesList - renderProcesses: %w", err))
+	}
+
+	return nil
+}
+
+// ServersList - prints working servers of cluster.
+func (cc *Ctrl1CCLI) ServersList(p ClusterParams) error {
+	ctx, cancel := context.WithTimeout(cc.ctx, _defaultOperationTimeout*time.Second)
+	defer cancel()
+
+	servers, err := cc.servers(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	err = renderServers(cc.out, p.Output, servers)
+	if err != nil {
+		return renderError(fmt.Errorf("cli - ServersList - renderServers: %w", err))
+	}
+
+	return nil
+}
+
+// processes - getting working processes of cluster.
+func (cc *Ctrl1CCLI) processes(ctx context.Context, p ClusterParams) ([]entity.Process, error) {
+	agentCred, clusterCred := p.credentials()
+
+	cl, err := cc.c.ClusterByName(ctx, p.ClusterName, agentCred)
+	if err != nil {
+		return nil, lookupError(fmt.Errorf("cli - processes - cc.c.ClusterByName: %w", err))
+	}
+
+	processes, err := cc.c.Processes(ctx, cl, clusterCred)
+	if err != nil {
+		return nil, categorize(nil, fmt.Errorf("cli - processes - cc.c.Processes: %w", err))
+	}
+
+	return processes, nil
+}
+
+// servers - getting working servers of cluster.
+func (cc *Ctrl1CCLI) servers(ctx context.Context, p ClusterParams) ([]entity.Server, error) {
+	agentCred, clusterCred := p.credentials()
+
+	cl, err := cc.c.ClusterByName(ctx, p.ClusterName, agentCred)
+	if err != nil {
+		return nil, lookupError(fmt.Errorf("cli - servers - cc.c.ClusterByName: %w", err))
+	}
+
+	servers, err := cc.c.Servers(ctx, cl, clusterCred)
+	if err != nil {
+		return nil, categorize(nil, fmt.Errorf("cli - servers - cc.c.Servers: %w", err))
+	}
+
+	return servers, nil
+}
+
+func (p ClusterParams) credentials() (agentCred, clusterCred entity.Credentials) {
+	agentCred = entity.Credentials{
+		Name: p.AgentAdmin,
+		Pwd:  p.AgentPwd,
+	}
+
+	clusterCred = entity.Credentials{
+		Name: p.ClusterAdmin,
+		Pwd:  p.ClusterPwd,
+	}
+
+	return agentCred, clusterCred
+}
@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antonmisa/1cctl_cli/internal/common/clierror"
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+	"github.com/antonmisa/1cctl_cli/internal/usecase"
+	"github.com/antonmisa/1cctl_cli/internal/usecase/mocks"
+)
+
+func TestProcessesList(t *testing.T) {
+	errRAS := errors.New("ras failed")
+	started := time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC)
+
+	processes := []entity.Process{
+		{ID: "p1", Host: "srv-1", Port: 1560, PID: 4242, Enabled: true, Running: true, Memory: 2048, Connections: 3, Started: started},
+		{ID: "p2", Host: "srv-2", Port: 1561, PID: 512, Enabled: true, Memory: 4096},
+	}
+
+	cases := []struct {
+		name    string
+		o       Output
+		cluster error
+		err     error
+		out     string
+		code    int
+	}{
+		{
+			name: "All columns",
+			o:    Output{Format: FormatCSV},
+			out: "ID,HOST,PORT,PID,ENABLED,RUNNING,MEMORY KB,CONNECTIONS,AVAILABLE PERF,STARTED\n" +
+				"p1,srv-1,1560,4242,true,true,2048,3,0,2024-02-01 09:00:00\n" +
+				"p2,srv-2,1561,512,true,false,4096,0,0,\n",
+			code: clierror.CodeOK,
+		},
+		{
+			name: "Sorted by memory",
+			o:    Output{Format: FormatCSV, Columns: []string{"pid", "memory-kb"}, Sort: "-memory-kb"},
+			out:  "PID,MEMORY KB\n512,4096\n4242,2048\n",
+			code: clierror.CodeOK,
+		},
+		{
+			name:    "Cluster not found",
+			cluster: usecase.ErrClusterNotFound,
+			code:    clierror.CodeConfig,
+		},
+		{
+			name: "Wrong password",
+			err:  &usecase.ClusterError{Kind: usecase.ErrAuthFailed, Err: errRAS},
+			code: clierror.CodeAuth,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := mocks.NewCtrl(t)
+
+			c.On("ClusterByName", mock.Anything, "srv", entity.Credentials{Name: "agent"}).Return(entity.Cluster{ID: "1"}, tc.cluster).Once()
+
+			if tc.cluster == nil {
+				c.On("Processes", mock.Anything, entity.Cluster{ID: "1"}, entity.Credentials{Name: "admin"}).Return(processes, tc.err).Once()
+			}
+
+			var out bytes.Buffer
+
+			cc := New(context.Background(), c, strings.NewReader(""), &out)
+
+			err := cc.ProcessesList(ClusterParams{ClusterName: "srv", AgentAdmin: "agent", ClusterAdmin: "admin", Output: tc.o})
+
+			require.Equal(t, tc.code, clierror.Code(err), err)
+			require.Equal(t, tc.out, out.String())
+		})
+	}
+}
+
+func TestServersList(t *testing.T) {
+	errRAS := errors.New("ras failed")
+
+	servers := []entity.Server{
+		{ID: "sv1", Name: "Central", Host: "srv-1", Port: 1540, PortRange: "1560:1591", Using: "main", ConnectionsLimit: 128},
+		{ID: "sv2", Name: "Reserve", Host: "srv-2", Port: 1540, PortRange: "1560:1591", Using: "normal"},
+	}
+
+	cases := []struct {
+		name    string
+		o       Output
+		cluster error
+		err     error
+		out     string
+		code    int
+	}{
+		{
+			name: "All columns",
+			o:    Output{Format: FormatCSV},
+			out: "ID,NAME,HOST,PORT,PORT RANGE,USING,MEMORY LIMIT,CONNECTIONS LIMIT\n" +
+				"sv1,Central,srv-1,1540,1560:1591,main,0,128\n" +
+				"sv2,Reserve,srv-2,1540,1560:1591,normal,0,0\n",
+			code: clierror.CodeOK,
+		},
+		{
+			name: "Columns",
+			o:    Output{Columns: []string{"name", "using"}, Sort: "-name"},
+			out:  "NAME     USING\nReserve  normal\nCentral  main\n",
+			code: clierror.CodeOK,
+		},
+		{
+			name:    "Unreachable",
+			cluster: &usecase.ClusterError{Kind: usecase.ErrClusterUnreachable, Err: errRAS},
+			code:    clierror.CodeConnectivity,
+		},
+		{
+			name: "Unknown column",
+			o:    Output{Columns: []string{"pid"}},
+			code: clierror.CodeUsage,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := mocks.NewCtrl(t)
+
+			c.On("ClusterByName", mock.Anything, "srv", entity.Credentials{}).Return(entity.Cluster{ID: "1"}, tc.cluster).Once()
+
+			if tc.cluster == nil {
+				c.On("Servers", mock.Anything, entity.Cluster{ID: "1"}, entity.Credentials{}).Return(servers, tc.err).Once()
+			}
+
+			var out bytes.Buffer
+
+			cc := New(context.Background(), c, strings.NewReader(""), &out)
+
+			err := cc.ServersList(ClusterParams{ClusterName: "srv", Output: tc.o})
+
+			require.Equal(t, tc.code, clierror.Code(err), err)
+			require.Equal(t, tc.out, out.String())
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	e "github.com/antonmisa/1cctl_cli/internal/common/clierror"
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+)
+
+// SessionsParams - parameters of sessions commands -.
+type SessionsParams struct {
+	ClusterName  string
+	Infobase     string
+	AgentAdmin   string
+	AgentPwd     string
+	ClusterAdmin string
+	ClusterPwd   string
+
+	Filter  entity.SessionFilter
+	Message string
+
+	Output
+
+	DryRun bool
+	Yes    bool
+}
+
+// ConnectionsParams - parameters of connections commands -.
+type ConnectionsParams struct {
+	ClusterName  string
+	Infobase     string
+	AgentAdmin   string
+	AgentPwd     string
+	ClusterAdmin string
+	ClusterPwd   string
+
+	Filter entity.ConnectionFilter
+	Output
+
+	DryRun bool
+	Yes    bool
+}
+
+// SessionsList - prints sessions matching filter.
+func (cc *Ctrl1CCLI) SessionsList(p SessionsParams) error {
+	ctx, cancel := context.WithTimeout(cc.ctx, _defaultOperationTimeout*time.Second)
+	defer cancel()
+
+	_, sessions, err := cc.sessions(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	err = renderSessions(cc.out, p.Output, sessions)
+	if err != nil {
+		return renderError(fmt.Errorf("cli - SessionsList - renderSessions: %w", err))
+	}
+
+	return nil
+}
+
+// SessionsKill - terminates sessions matching filter.
+func (cc *Ctrl1CCLI) SessionsKill(p SessionsParams) error {
+	ctx, cancel := context.WithTimeout(cc.ctx, _defaultOperationTimeout*time.Second)
+	defer cancel()
+
+	cl, sessions, err := cc.sessions(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	if len(sessions) == 0 {
+		fmt.Fprintln(cc.out, "no sessions matched")
+
+		return nil
+	}
+
+	err = renderSessions(cc.out, p.Output, sessions)
+	if err != nil {
+		return renderError(fmt.Errorf("cli - SessionsKill - renderSessions: %w", err))
+	}
+
+	if p.DryRun {
+		fmt.Fprintf(cc.out, "dry run: %d session(s) would be terminated\n", len(sessions))
+
+		return nil
+	}
+
+	if !p.Yes {
+		ok, err := cc.confirm(fmt.Sprintf("Terminate %d session(s)?", len(sessions)))
+		if err != nil {
+			return fmt.Errorf("cli - SessionsKill - cc.confirm: %w", err)
+		}
+
+		if !ok {
+			fmt.Fprintln(cc.out, "canceled")
+
+			return nil
+		}
+	}
+
+	clusterCred := entity.Credentials{
+		Name: p.ClusterAdmin,
+		Pwd:  p.ClusterPwd,
+	}
+
+	err = cc.c.DeleteSessions(ctx, cl, sessions, clusterCred, p.Message)
+	if err != nil {
+		return categorize(e.ErrKillIncomplete, fmt.Errorf("cli - SessionsKill - cc.c.DeleteSessions: %w", err))
+	}
+
+	fmt.Fprintf(cc.out, "%d session(s) terminated\n", len(sessions))
+
+	return nil
+}
+
+// ConnectionsList - prints connections matching filter.
+func (cc *Ctrl1CCLI) ConnectionsList(p ConnectionsParams) error {
+	ctx, cancel := context.WithTimeout(cc.ctx, _defaultOperationTimeout*time.Second)
+	defer cancel()
+
+	_, connections, err := cc.connections(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	err = renderConnections(cc.out, p.Output, connections)
+	if err != nil {
+		return renderError(fmt.Errorf("cli - ConnectionsList - renderConnections: %w", err))
+	}
+
+	return nil
+}
+
+// ConnectionsDisconnect - breaks connections matching filter.
+func (cc *Ctrl1CCLI) ConnectionsDisconnect(p ConnectionsParams) error {
+	ctx, cancel := context.WithTimeout(cc.ctx, _defaultOperationTimeout*time.Second)
+	defer cancel()
+
+	cl, connections, err := cc.connections(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	if len(connections) == 0 {
+		fmt.Fprintln(cc.out, "no connections matched")
+
+		return nil
+	}
+
+	err = renderConnections(cc.out, p.Output, connections)
+	if err != nil {
+		return renderError(fmt.Errorf("cli - ConnectionsDisconnect - renderConnections: %w", err))
+	}
+
+	if p.DryRun {
+		fmt.Fprintf(cc.out, "dry run: %d connection(s) would be disconnected\n", len(connections))
+
+		return nil
+	}
+
+	if !p.Yes {
+		ok, err := cc.confirm(fmt.Sprintf("Disconnect %d connection(s)?", len(connections)))
+		if err != nil {
+			return fmt.Errorf("cli - ConnectionsDisconnect - cc.confirm: %w", err)
+		}
+
+		if !ok {
+			fmt.Fprintln(cc.out, "canceled")
+
+			return nil
+		}
+	}
+
+	clusterCred := entity.Credentials{
+		Name: p.ClusterAdmin,
+		Pwd:  p.ClusterPwd,
+	}
+
+	err = cc.c.DeleteConnections(ctx, cl, connections, clusterCred)
+	if err != nil {
+		return categorize(e.ErrKillIncomplete, fmt.Errorf("cli - ConnectionsDisconnect - cc.c.DeleteConnections: %w", err))
+	}
+
+	fmt.Fprintf(cc.out, "%d connection(s) disconnected\n", len(connections))
+
+	return nil
+}
+
+// sessions - getting sessions of cluster or infobase and applying filter.
+func (cc *Ctrl1CCLI) sessions(ctx context.Context, p SessionsParams) (entity.Cluster, []entity.Session, error) {
+	clusterCred := entity.Credentials{
+		Name: p.ClusterAdmin,
+		Pwd:  p.ClusterPwd,
+	}
+
+	agentCred := entity.Credentials{
+		Name: p.AgentAdmin,
+		Pwd:  p.AgentPwd,
+	}
+
+	cl, ib, err := cc.target(ctx, p.ClusterName, p.Infobase, agentCred, clusterCred)
+	if err != nil {
+		return entity.Cluster{}, nil, err
+	}
+
+	sessions, err := cc.c.Sessions(ctx, cl, ib, clusterCred)
+	if err != nil {
+		return entity.Cluster{}, nil, categorize(nil, fmt.Errorf("cli - sessions - cc.c.Sessions: %w", err))
+	}
+
+	return cl, p.Filter.Filter(sessions, time.Now()), nil
+}
+
+// connections - getting connections of cluster or infobase and applying filter.
+func (cc *Ctrl1CCLI) connections(ctx context.Context, p ConnectionsParams) (entity.Cluster, []entity.Connection, error) {
+	clusterCred := entity.Credentials{
+		Name: p.ClusterAdmin,
+		Pwd:  p.ClusterPwd,
+	}
+
+	agentCred := entity.Credentials{
+		Name: p.AgentAdmin,
+		Pwd:  p.AgentPwd,
+	}
+
+	cl, ib, err := cc.target(ctx, p.ClusterName, p.Infobase, agentCred, clusterCred)
+	if err != nil {
+		return entity.Cluster{}, nil, err
+	}
+
+	connections, err := cc.c.Connections(ctx, cl, ib, clusterCred)
+	if err != nil {
+		return entity.Cluster{}, nil, categorize(nil, fmt.Errorf("cli - connections - cc.c.Connections: %w", err))
+	}
+
+	return cl, p.Filter.Filter(connections, time.Now()), nil
+}
+
+// target - getting cluster and optional infobase by names.
+func (cc *Ctrl1CCLI) target(ctx context.Context, clusterName, infobase string, agentCred, clusterCred entity.Credentials) (entity.Cluster, entity.Infobase, error) {
+	cl, err := cc.c.ClusterByName(ctx, clusterName, agentCred)
+	if err != nil {
+		return entity.Cluster{}, entity.Infobase{}, lookupError(fmt.Errorf("cli - target - cc.c.ClusterByName: %w", err))
+	}
+
+	if infobase == "" {
+		return cl, entity.Infobase{}, nil
+	}
+
+	ib, err := cc.c.InfobaseByName(ctx, cl, infobase, clusterCred)
+	if err != nil {
+		return entity.Cluster{}, entity.Infobase{}, lookupError(fmt.Errorf("cli - target - cc.c.InfobaseByName: %w", err))
+	}
+
+	return cl, ib, nil
+}
+
+// confirm - asks user a question, only explicit yes is accepted.
+func (cc *Ctrl1CCLI) confirm(question string) (bool, error) {
+	fmt.Fprintf(cc.out, "%s [y/N]: ", question)
+
+	line, err := bufio.NewReader(cc.in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, err
+	}
+
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
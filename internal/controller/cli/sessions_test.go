@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antonmisa/1cctl_cli/internal/common/clierror"
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+	"github.com/antonmisa/1cctl_cli/internal/usecase/mocks"
+)
+
+func TestSessionsKill(t *testing.T) {
+	errRAS := errors.New("ras failed")
+
+	sessions := []entity.Session{
+		{ID: "s1", SID: 1, UserName: "Ivanov", AppID: "1CV8C"},
+		{ID: "s2", SID: 2, UserName: "Petrov", AppID: "Designer"},
+	}
+
+	cases := []struct {
+		name   string
+		dryRun bool
+		yes    bool
+		in     string
+		kill   bool
+		err    error
+		out    string
+		code   int
+	}{
+		{
+			name:   "Dry run",
+			dryRun: true,
+			in:     "y\n",
+			out:    "dry run: 1 session(s) would be terminated\n",
+			code:   clierror.CodeOK,
+		},
+		{
+			name: "Declined",
+			in:   "n\n",
+			out:  "Terminate 1 session(s)? [y/N]: canceled\n",
+			code: clierror.CodeOK,
+		},
+		{
+			name: "No answer",
+			out:  "Terminate 1 session(s)? [y/N]: canceled\n",
+			code: clierror.CodeOK,
+		},
+		{
+			name: "Accepted",
+			in:   "yes\n",
+			kill: true,
+			out:  "Terminate 1 session(s)? [y/N]: 1 session(s) terminated\n",
+			code: clierror.CodeOK,
+		},
+		{
+			name: "Without confirmation",
+			yes:  true,
+			kill: true,
+			out:  "1 session(s) terminated\n",
+			code: clierror.CodeOK,
+		},
+		{
+			name: "Kill incomplete",
+			yes:  true,
+			kill: true,
+			err:  errRAS,
+			code: clierror.CodeKillIncomplete,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := mocks.NewCtrl(t)
+
+			c.On("ClusterByName", mock.Anything, "srv", mock.Anything).Return(entity.Cluster{ID: "1"}, nil).Once()
+			c.On("Sessions", mock.Anything, entity.Cluster{ID: "1"}, entity.Infobase{}, mock.Anything).Return(sessions, nil).Once()
+
+			if tc.kill {
+				c.On("DeleteSessions", mock.Anything, entity.Cluster{ID: "1"}, sessions[1:], mock.Anything, "bye").Return(tc.err).Once()
+			}
+
+			var out bytes.Buffer
+
+			cc := New(context.Background(), c, strings.NewReader(tc.in), &out)
+
+			err := cc.SessionsKill(SessionsParams{
+				ClusterName: "srv",
+				Filter:      entity.SessionFilter{AppID: "Designer"},
+				Message:     "bye",
+				Output:      Output{Columns: []string{"user"}},
+				DryRun:      tc.dryRun,
+				Yes:         tc.yes,
+			})
+
+			require.Equal(t, tc.code, clierror.Code(err), err)
+
+			if tc.err == nil {
+				require.Equal(t, "USER\nPetrov\n"+tc.out, out.String())
+			}
+		})
+	}
+}
+
+func TestConnectionsDisconnect(t *testing.T) {
+	errRAS := errors.New("ras failed")
+
+	connections := []entity.Connection{
+		{ID: "c1", CID: 1, Host: "pc-1", AppID: "1CV8C"},
+		{ID: "c2", CID: 2, Host: "pc-2", AppID: "COMConnection"},
+	}
+
+	cases := []struct {
+		name       string
+		dryRun     bool
+		yes        bool
+		in         string
+		disconnect bool
+		err        error
+		out        string
+		code       int
+	}{
+		{
+			name:   "Dry run",
+			dryRun: true,
+			in:     "y\n",
+			out:    "dry run: 1 connection(s) would be disconnected\n",
+			code:   clierror.CodeOK,
+		},
+		{
+			name: "Declined",
+			in:   "no\n",
+			out:  "Disconnect 1 connection(s)? [y/N]: canceled\n",
+			code: clierror.CodeOK,
+		},
+		{
+			name:       "Accepted",
+			in:         "Y\n",
+			disconnect: true,
+			out:        "Disconnect 1 connection(s)? [y/N]: 1 connection(s) disconnected\n",
+			code:       clierror.CodeOK,
+		},
+		{
+			name:       "Without confirmation",
+			yes:        true,
+			disconnect: true,
+			out:        "1 connection(s) disconnected\n",
+			code:       clierror.CodeOK,
+		},
+		{
+			name:       "Disconnect incomplete",
+			yes:        true,
+			disconnect: true,
+			err:        errRAS,
+			code:       clierror.CodeKillIncomplete,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := mocks.NewCtrl(t)
+
+			c.On("ClusterByName", mock.Anything, "srv", mock.Anything).Return(entity.Cluster{ID: "1"}, nil).Once()
+			c.On("InfobaseByName", mock.Anything, entity.Cluster{ID: "1"}, "buh", mock.Anything).Return(entity.Infobase{ID: "2"}, nil).Once()
+			c.On("Connections", mock.Anything, entity.Cluster{ID: "1"}, entity.Infobase{ID: "2"}, mock.Anything).Return(connections, nil).Once()
+
+			if tc.disconnect {
+				c.On("DeleteConnections", mock.Anything, entity.Cluster{ID: "1"}, connections[1:], mock.Anything).Return(tc.err).Once()
+			}
+
+			var out bytes.Buffer
+
+			cc := New(context.Background(), c, strings.NewReader(tc.in), &out)
+
+			err := cc.ConnectionsDisconnect(ConnectionsParams{
+				ClusterName: "srv",
+				Infobase:    "buh",
+				Filter:      entity.ConnectionFilter{AppID: "COMConnection"},
+				Output:      Output{Columns: []string{"host"}},
+				DryRun:      tc.dryRun,
+				Yes:         tc.yes,
+			})
+
+			require.Equal(t, tc.code, clierror.Code(err), err)
+
+			if tc.err == nil {
+				require.Equal(t, "HOST\npc-2\n"+tc.out, out.String())
+			}
+		})
+	}
+}
@@ -28,6 +28,47 @@ type Infobase struct {
 	Desc string `json:"desc"  rac:"descr"      example:"some comments"`
 }
 
+// InfobaseInfo - full infobase properties, infobase credentials are required to get them -.
+type InfobaseInfo struct {
+	ID                    string    `json:"id"          rac:"infobase"                                    example:"UUID like"`
+	Name                  string    `json:"name"        rac:"name"                                        example:"name as text"`
+	Desc                  string    `json:"desc"        rac:"descr"                                       example:"some comments"`
+	DBMS                  string    `json:"dbms"        rac:"dbms"                                        example:"MSSQLServer, PostgreSQL"`
+	DBServer              string    `json:"dbsrv"       rac:"db-server"                                   example:"Host of DB server"`
+	DBName                string    `json:"dbname"      rac:"db-name"                                     example:"Name of database"`
+	DBUser                string    `json:"dbuser"      rac:"db-user"                                     example:"Name of DB user"`
+	Locale                string    `json:"loc"         rac:"locale"                                      example:"ru_RU"`
+	DateOffset            int       `json:"dateoff"     rac:"date-offset"                                 example:"2000"`
+	SecLevel              int       `json:"sl"          rac:"security-level"                              example:"int"`
+	LicenseDistribution   string    `json:"lic"         rac:"license-distribution"                        example:"allow/deny"`
+	SessionsDeny          bool      `json:"deny"        rac:"sessions-deny"                               example:"on/off"`
+	DeniedFrom            time.Time `json:"from"        rac:"denied-from"                                 example:"Time lock starts"`
+	DeniedTo              time.Time `json:"to"          rac:"denied-to"                                   example:"Time lock ends"`
+	DeniedMessage         string    `json:"msg"         rac:"denied-message"                              example:"Message shown to users"`
+	DeniedParameter       string    `json:"param"       rac:"denied-parameter"                            example:"Lock parameter"`
+	PermissionCode        string    `json:"code"        rac:"permission-code"                             example:"Code to bypass lock"`
+	ScheduledJobsDeny     bool      `json:"jobs"        rac:"scheduled-jobs-deny"                         example:"on/off"`
+	ExtSessionMgrConn     string    `json:"esmconn"     rac:"external-session-manager-connection-string"  example:"Connection string"`
+	ExtSessionMgrRequired bool      `json:"esmreq"      rac:"external-session-manager-required"           example:"yes/no"`
+	SecProfile            string    `json:"secprof"     rac:"security-profile-name"                       example:"name as text"`
+	SafeModeSecProfile    string    `json:"safeprof"    rac:"safe-mode-security-profile-name"             example:"name as text"`
+	ReserveProcesses      bool      `json:"reserve"     rac:"reserve-working-processes"                   example:"yes/no"`
+}
+
+// Lock - denial settings part of infobase properties.
+func (i InfobaseInfo) Lock() InfobaseLock {
+	return InfobaseLock{
+		InfobaseID:        i.ID,
+		SessionsDeny:      i.SessionsDeny,
+		From:              i.DeniedFrom,
+		To:                i.DeniedTo,
+		Message:           i.DeniedMessage,
+		Parameter:         i.DeniedParameter,
+		Code:              i.PermissionCode,
+		ScheduledJobsDeny: i.ScheduledJobsDeny,
+	}
+}
+
 // Session -.
 type Session struct {
 	ID             string    `json:"id"              rac:"session"     example:"UUID like"`
@@ -92,3 +133,50 @@ type Connection struct {
 	SID        int       `json:"sid"         rac:"session-number"  example:"Int like session number"`
 	Blocked    int       `json:"blocked"     rac:"blocked-by-ls"  example:"Int like blocked by ls"`
 }
+
+// Process - working process (rphost) of cluster -.
+type Process struct {
+	ID          string    `json:"id"         rac:"process"               example:"UUID like"`
+	Host        string    `json:"host"       rac:"host"                  example:"Host of the process"`
+	Port        int       `json:"port"       rac:"port"                  example:"1560"`
+	PID         int       `json:"pid"        rac:"pid"                   example:"Int like"`
+	Enabled     bool      `json:"enabled"    rac:"turned-on"             example:"yes/no"`
+	Running     bool      `json:"running"    rac:"running"               example:"yes/no"`
+	Started     time.Time `json:"started"    rac:"started-at"            example:"Time of start"`
+	Use         string    `json:"use"        rac:"use"                   example:"used, not-used"`
+	AvailPerf   int       `json:"perf"       rac:"available-perfomance"  example:"Int like"`
+	Capacity    int       `json:"capacity"   rac:"capacity"              example:"Int like"`
+	Connections int       `json:"conns"      rac:"connections"           example:"Int like"`
+	Memory      int       `json:"mem"        rac:"memory-size"           example:"Memory in KB"`
+	MemExcess   int       `json:"memexcess"  rac:"memory-excess-time"    example:"Seconds memory limit is exceeded"`
+	Selection   int       `json:"selection"  rac:"selection-size"        example:"Int like"`
+	Reserve     bool      `json:"reserve"    rac:"reserve"               example:"yes/no"`
+}
+
+// Server - working server of cluster -.
+type Server struct {
+	ID                string `json:"id"        rac:"server"                example:"UUID like"`
+	Host              string `json:"host"      rac:"agent-host"            example:"Host of the server"`
+	Port              int    `json:"port"      rac:"agent-port"            example:"1540"`
+	PortRange         string `json:"range"     rac:"port-range"            example:"1560:1591"`
+	Name              string `json:"name"      rac:"name"                  example:"name as text"`
+	Using             string `json:"using"     rac:"using"                 example:"main, normal"`
+	DedicateManagers  string `json:"dedicate"  rac:"dedicate-managers"     example:"all, none"`
+	InfobasesLimit    int    `json:"iblimit"   rac:"infobases-limit"       example:"Int like"`
+	MemoryLimit       int    `json:"memlimit"  rac:"memory-limit"          example:"Int like"`
+	ConnectionsLimit  int    `json:"connlimit" rac:"connections-limit"     example:"Int like"`
+	ClusterPort       int    `json:"clport"    rac:"cluster-port"          example:"1541"`
+	CriticalMemory    int    `json:"critmem"   rac:"critical-total-memory" example:"Int like"`
+	SafeProcessMemory int    `json:"safemem"   rac:"safe-working-processes-memory-limit" example:"Int like"`
+	SafeCallMemory    int    `json:"safecall"  rac:"safe-call-memory-limit" example:"Int like"`
+}
+
+// Manager - cluster manager (rmngr) -.
+type Manager struct {
+	ID    string `json:"id"     rac:"manager"    example:"UUID like"`
+	PID   int    `json:"pid"    rac:"pid"        example:"Int like"`
+	Using string `json:"using"  rac:"using"      example:"main, normal"`
+	Host  string `json:"host"   rac:"host"       example:"Host of the manager"`
+	Port  int    `json:"port"   rac:"main-port"  example:"1541"`
+	Desc  string `json:"desc"   rac:"descr"      example:"some comments"`
+}
@@ -19,25 +19,59 @@ const (
 	_formatDateWoTZ = "2006-01-02T15:04:05"
 )
 
-// Helper func for parsing incominf line of text
+// Helper func for parsing incoming line of text: key is lowercased, value keeps its case and is unquoted
 func GetKeyValue(line string, delimeter rune) (k, v string, err error) {
 	cleanLine := strings.Map(func(r rune) rune {
 		if unicode.IsPrint(r) {
-			return unicode.ToLower(r)
+			return r
 		}
 
 		return -1
 	}, line)
 
 	if pos := strings.IndexRune(cleanLine, delimeter); pos != -1 {
-		return strings.Trim(cleanLine[:pos], " "), strings.Trim(cleanLine[pos+1:], " "), nil
+		return strings.ToLower(strings.Trim(cleanLine[:pos], " ")), unquote(strings.Trim(cleanLine[pos+1:], " ")), nil
 	}
 
 	return "", "", ErrNotFound
 }
 
-// Converting lines of strings to object
-// TODO: use codegen, not reflect - it's slow
+// unquote - rac quotes values having spaces or special characters, quotes inside are doubled
+// or escaped by backslash: "say ""hi""", "say \"hi\"". Unquoted values are returned as is.
+func unquote(v string) string {
+	if len(v) < 2 || v[0] != '"' || v[len(v)-1] != '"' {
+		return v
+	}
+
+	inner := v[1 : len(v)-1]
+
+	var b strings.Builder
+
+	b.Grow(len(inner))
+
+	for i := 0; i < len(inner); i++ {
+		c := inner[i]
+
+		if i+1 < len(inner) && (c == '"' && inner[i+1] == '"' || c == '\\' && (inner[i+1] == '"' || inner[i+1] == '\\')) {
+			i++
+			c = inner[i]
+		}
+
+		b.WriteByte(c)
+	}
+
+	return b.String()
+}
+
+//go:generate go run ../../cmd/racgen
+
+// RACUnmarshaler - record decoding itself from rac output, implementations are generated
+// by cmd/racgen from rac tags, see rac_gen.go -.
+type RACUnmarshaler interface {
+	UnmarshalRAC(lines []string) error
+}
+
+// Converting lines of strings to object by reflection, generated UnmarshalRAC does the same faster
 func Unmarshal(lines []string, v any) error {
 	rt := reflect.TypeOf(v)
 	_ = reflect.New(rt)
@@ -62,17 +96,15 @@ func Unmarshal(lines []string, v any) error {
 
 				switch fv.Interface().(type) {
 				case time.Time:
-					t, err := time.ParseInLocation(_formatDateWoTZ, strings.ToUpper(value), time.UTC)
-
-					if err == nil {
+					if t, ok := parseTime(value); ok {
 						fv.Set(reflect.ValueOf(t))
 					}
 				case int:
-					vi, err := strconv.Atoi(value)
-
-					if err == nil {
+					if vi, ok := parseInt(value); ok {
 						fv.SetInt(int64(vi))
 					}
+				case bool:
+					fv.SetBool(parseBool(value))
 				default:
 					fv.Set(reflect.ValueOf(value))
 				}
@@ -88,3 +120,45 @@ func Unmarshal(lines []string, v any) error {
 
 	return nil
 }
+
+// ServerLocation - time zone of cluster servers: rac and RAS give their local wall clock time without zone.
+// Local by default, as 1cctl usually runs next to the cluster, set by app.server_time_zone of config.
+var ServerLocation = time.Local
+
+// ServerTime - wall clock time t, decoded as UTC, in ServerLocation, zero time stays zero.
+func ServerTime(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), ServerLocation)
+}
+
+// WallClock - t on wall clock of ServerLocation labeled as UTC to be encoded, reverse of ServerTime.
+func WallClock(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+
+	t = t.In(ServerLocation)
+
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// parseTime - rac time has no time zone and is read in ServerLocation, false if value is not a time.
+func parseTime(value string) (time.Time, bool) {
+	t, err := time.ParseInLocation(_formatDateWoTZ, strings.ToUpper(value), ServerLocation)
+
+	return t, err == nil
+}
+
+func parseInt(value string) (int, bool) {
+	v, err := strconv.Atoi(value)
+
+	return v, err == nil
+}
+
+// parseBool - rac prints flags as on/off or yes/no.
+func parseBool(value string) bool {
+	return strings.EqualFold(value, "on") || strings.EqualFold(value, "yes")
+}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
@@ -44,6 +45,41 @@ func TestGetKeyValue(t *testing.T) {
 			k:         "id",
 			v:         "test value",
 		},
+		{
+			name:      "Case of value preserved",
+			line:      "name : Бухгалтерия Main",
+			delimeter: ':',
+			k:         "name",
+			v:         "Бухгалтерия Main",
+		},
+		{
+			name:      "Key lowercased",
+			line:      "Name : x",
+			delimeter: ':',
+			k:         "name",
+			v:         "x",
+		},
+		{
+			name:      "Quoted w doubled quotes and colon",
+			line:      `denied-message : "closed: till ""10:00"""`,
+			delimeter: ':',
+			k:         "denied-message",
+			v:         `closed: till "10:00"`,
+		},
+		{
+			name:      "Quoted w escaped quotes",
+			line:      `descr : "say \"hi\" \\ bye"`,
+			delimeter: ':',
+			k:         "descr",
+			v:         `say "hi" \ bye`,
+		},
+		{
+			name:      "Single quote char",
+			line:      `descr : "`,
+			delimeter: ':',
+			k:         "descr",
+			v:         `"`,
+		},
 		{
 			name:      "Not found",
 			line:      " id - test",
@@ -78,20 +114,24 @@ func TestGetKeyValue(t *testing.T) {
 	}
 }
 
-func TestUnmarshal(t *testing.T) {
-	type args struct {
-		lines []string
-		v     any
-	}
-	cases := []struct {
-		name string
-		args args
-		res  any
-		err  error
-	}{
+type unmarshalArgs struct {
+	lines []string
+	v     any
+}
+
+type unmarshalCase struct {
+	name string
+	args unmarshalArgs
+	res  any
+	err  error
+}
+
+// unmarshalCases - shared by reflection and generated decoders, they must agree on each.
+func unmarshalCases() []unmarshalCase {
+	return []unmarshalCase{
 		{
 			name: "OK cluster",
-			args: args{
+			args: unmarshalArgs{
 				lines: []string{
 					"cluster:    test",
 					"host: test",
@@ -105,7 +145,7 @@ func TestUnmarshal(t *testing.T) {
 		},
 		{
 			name: "Not found cluster",
-			args: args{
+			args: unmarshalArgs{
 				lines: []string{
 					"test:    test",
 					"ghost: test",
@@ -117,7 +157,7 @@ func TestUnmarshal(t *testing.T) {
 		},
 		{
 			name: "OK infobase",
-			args: args{
+			args: unmarshalArgs{
 				lines: []string{
 					"infobase:    test",
 					"name: test1",
@@ -133,7 +173,7 @@ func TestUnmarshal(t *testing.T) {
 		},
 		{
 			name: "Not found infobase",
-			args: args{
+			args: unmarshalArgs{
 				lines: []string{
 					"test:    test",
 					"ghost: test",
@@ -145,7 +185,7 @@ func TestUnmarshal(t *testing.T) {
 		},
 		{
 			name: "OK session",
-			args: args{
+			args: unmarshalArgs{
 				lines: []string{
 					"session:    test",
 					"host: test1",
@@ -156,12 +196,12 @@ func TestUnmarshal(t *testing.T) {
 			res: Session{
 				ID:      "test",
 				Host:    "test1",
-				Started: time.Date(2023, time.August, 8, 10, 48, 43, 0, time.UTC),
+				Started: time.Date(2023, time.August, 8, 10, 48, 43, 0, ServerLocation),
 			},
 		},
 		{
 			name: "Not found session",
-			args: args{
+			args: unmarshalArgs{
 				lines: []string{
 					"test:    test",
 					"ghost: test",
@@ -173,7 +213,7 @@ func TestUnmarshal(t *testing.T) {
 		},
 		{
 			name: "OK connection",
-			args: args{
+			args: unmarshalArgs{
 				lines: []string{
 					"connection:    test",
 					"host: test1",
@@ -187,7 +227,7 @@ func TestUnmarshal(t *testing.T) {
 		},
 		{
 			name: "Not found connection",
-			args: args{
+			args: unmarshalArgs{
 				lines: []string{
 					"test:    test",
 					"ghost: test",
@@ -197,8 +237,33 @@ func TestUnmarshal(t *testing.T) {
 			res: Connection{},
 			err: ErrNotFound,
 		},
+		{
+			name: "OK infobase lock",
+			args: unmarshalArgs{
+				lines: []string{
+					"infobase:    test",
+					"sessions-deny: on",
+					"denied-from: 2023-08-08T10:48:43",
+					"denied-to:",
+					"denied-message: closed",
+					"permission-code: 123",
+					"scheduled-jobs-deny: off",
+				},
+				v: InfobaseLock{},
+			},
+			res: InfobaseLock{
+				InfobaseID:   "test",
+				SessionsDeny: true,
+				From:         time.Date(2023, time.August, 8, 10, 48, 43, 0, ServerLocation),
+				Message:      "closed",
+				Code:         "123",
+			},
+		},
 	}
-	for _, tc := range cases {
+}
+
+func TestUnmarshal(t *testing.T) {
+	for _, tc := range unmarshalCases() {
 		tc := tc
 
 		t.Run(tc.name, func(t *testing.T) {
@@ -226,6 +291,11 @@ func TestUnmarshal(t *testing.T) {
 				var v Connection
 				err = Unmarshal(tc.args.lines, &v)
 
+				require.Equal(t, v, tc.res)
+			case InfobaseLock:
+				var v InfobaseLock
+				err = Unmarshal(tc.args.lines, &v)
+
 				require.Equal(t, v, tc.res)
 			default:
 			}
@@ -238,3 +308,117 @@ func TestUnmarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmarshalRAC(t *testing.T) {
+	for _, tc := range unmarshalCases() {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			typ := reflect.TypeOf(tc.args.v)
+
+			byReflect := reflect.New(typ)
+			errReflect := Unmarshal(tc.args.lines, byReflect.Interface())
+
+			generated := reflect.New(typ)
+			errGenerated := generated.Interface().(RACUnmarshaler).UnmarshalRAC(tc.args.lines)
+
+			require.Equal(t, tc.res, generated.Elem().Interface())
+			require.Equal(t, byReflect.Elem().Interface(), generated.Elem().Interface())
+			require.Equal(t, errReflect, errGenerated)
+		})
+	}
+}
+
+// sessionLines - full record of rac session list.
+func sessionLines() []string {
+	return []string{
+		"session                          : 11111111-2222-4333-8444-555555555555",
+		"session-id                       : 3",
+		"infobase                         : 6f3c2b1a-0e9d-4c8b-a7f6-5e4d3c2b1a00",
+		"connection                       : abcdefab-cdef-4abc-8def-abcdefabcdef",
+		"process                          : 01234567-89ab-4cde-8f01-23456789abcd",
+		"user-name                        : Иванов",
+		"host                             : pc-01",
+		"app-id                           : 1CV8C",
+		"locale                           : ru_RU",
+		"started-at                       : 2023-08-08T09:00:00",
+		"last-active-at                   : 2023-08-08T10:30:00",
+		"hibernate                        : no",
+		"passive-session-hibernate-time   : 1200",
+		"hibernate-session-terminate-time : 86400",
+		"blocked-by-dbms                  : 0",
+		"blocked-by-ls                    : 0",
+		"bytes-all                        : 1024",
+		"bytes-last-5min                  : 512",
+		"calls-all                        : 10",
+		"calls-last-5min                  : 5",
+		"dbms-bytes-all                   : 0",
+		"dbms-bytes-last-5min             : 0",
+		"db-proc-info                     :",
+		"db-proc-took                     : 0",
+		"db-proc-took-at                  :",
+		"duration-all                     : 100",
+		"duration-all-dbms                : 50",
+		"duration-current                 : 0",
+		"duration-current-dbms            : 0",
+		"duration-last-5min               : 20",
+		"duration-last-5min-dbms          : 10",
+		"memory-current                   : 0",
+		"memory-last-5min                 : 0",
+		"memory-total                     : 4096",
+		"read-current                     : 0",
+		"read-last-5min                   : 0",
+		"read-total                       : 0",
+		"write-current                    : 0",
+		"write-last-5min                  : 0",
+		"write-total                      : 0",
+		"duration-current-service         : 0",
+		"duration-last-5min-service       : 0",
+		"duration-all-service             : 0",
+		"current-service-name             :",
+		"cpu-time-current                 : 0",
+		"cpu-time-last-5min               : 0",
+		"cpu-time-total                   : 15",
+		"data-separation                  : ''",
+	}
+}
+
+func TestUnmarshalRACSession(t *testing.T) {
+	t.Parallel()
+
+	var byReflect, generated Session
+
+	require.NoError(t, Unmarshal(sessionLines(), &byReflect))
+	require.NoError(t, generated.UnmarshalRAC(sessionLines()))
+	require.Equal(t, byReflect, generated)
+}
+
+func BenchmarkUnmarshalSession(b *testing.B) {
+	lines := sessionLines()
+
+	b.Run("Reflect", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			var s Session
+
+			if err := Unmarshal(lines, &s); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("Generated", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			var s Session
+
+			if err := s.UnmarshalRAC(lines); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
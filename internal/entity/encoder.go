@@ -0,0 +1,117 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrUnsupportedType = errors.New("unsupported field type")
+
+// Marshal - rendering object as rac record, one "key : value" line per rac tagged field
+// in order of declaration. Unmarshal of the result gives the same object.
+func Marshal(v any) ([]string, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedType, v)
+	}
+
+	rt := rv.Type()
+	width := keyWidth(rt)
+	lines := make([]string, 0, rt.NumField())
+
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+
+		key, ok := f.Tag.Lookup(_key)
+		if !ok {
+			continue
+		}
+
+		var value string
+
+		switch fv := rv.Field(i).Interface().(type) {
+		case time.Time:
+			value = formatTime(fv)
+		case int:
+			value = strconv.Itoa(fv)
+		case bool:
+			value = formatBool(fv, f.Tag.Get("example"))
+		case string:
+			value = quote(fv)
+		default:
+			return nil, fmt.Errorf("%w: %s %T", ErrUnsupportedType, f.Name, fv)
+		}
+
+		lines = append(lines, fmt.Sprintf("%-*s : %s", width, key, value))
+	}
+
+	return lines, nil
+}
+
+// MarshalList - writing records as rac does: separated by blank line.
+func MarshalList[T any](w io.Writer, list []T) error {
+	for i := range list {
+		lines, err := Marshal(&list[i])
+		if err != nil {
+			return err
+		}
+
+		if _, err = io.WriteString(w, strings.Join(lines, "\n")+"\n\n"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// keyWidth - rac aligns values by the longest key of record.
+func keyWidth(rt reflect.Type) int {
+	width := 0
+
+	for i := 0; i < rt.NumField(); i++ {
+		if key, ok := rt.Field(i).Tag.Lookup(_key); ok && len(key) > width {
+			width = len(key)
+		}
+	}
+
+	return width
+}
+
+// formatTime - zero time is printed empty as rac does for unset dates, other on wall clock of ServerLocation.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.In(ServerLocation).Format(_formatDateWoTZ)
+}
+
+// formatBool - flag is printed as on/off or yes/no, as documented by example tag of field.
+func formatBool(b bool, example string) string {
+	yes, no := "yes", "no"
+	if example == "on/off" {
+		yes, no = "on", "off"
+	}
+
+	if b {
+		return yes
+	}
+
+	return no
+}
+
+// quote - opposite of unquote, values having spaces or quotes are quoted.
+func quote(v string) string {
+	if !strings.ContainsAny(v, " \"") {
+		return v
+	}
+
+	r := strings.NewReplacer(`\`, `\\`, `"`, `""`)
+
+	return `"` + r.Replace(v) + `"`
+}
@@ -0,0 +1,174 @@
+package entity
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshal(t *testing.T) {
+	cases := []struct {
+		name  string
+		v     any
+		lines []string
+		err   error
+	}{
+		{
+			name: "Infobase",
+			v:    Infobase{ID: "1", Name: "buh", Desc: `closed: till "10:00"`},
+			lines: []string{
+				"infobase : 1",
+				"name     : buh",
+				`descr    : "closed: till ""10:00"""`,
+			},
+		},
+		{
+			name: "Flags and times",
+			v: &InfobaseLock{
+				InfobaseID:        "1",
+				SessionsDeny:      true,
+				From:              time.Date(2023, time.August, 8, 22, 0, 0, 0, time.UTC),
+				ScheduledJobsDeny: false,
+			},
+			lines: []string{
+				"infobase            : 1",
+				"sessions-deny       : on",
+				"denied-from         : 2023-08-08T22:00:00",
+				"denied-to           : ",
+				"denied-message      : ",
+				"denied-parameter    : ",
+				"permission-code     : ",
+				"scheduled-jobs-deny : off",
+			},
+		},
+		{
+			name: "Backslash quoted",
+			v:    Infobase{ID: "1", Desc: `C:\bases\buh main`},
+			lines: []string{
+				"infobase : 1",
+				"name     : ",
+				`descr    : "C:\\bases\\buh main"`,
+			},
+		},
+		{
+			name: "Unsupported field",
+			v: struct {
+				F float64 `rac:"f"`
+			}{},
+			err: ErrUnsupportedType,
+		},
+		{
+			name: "Not a struct",
+			v:    "test",
+			err:  ErrUnsupportedType,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			lines, err := Marshal(tc.v)
+
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.lines, lines)
+		})
+	}
+}
+
+func TestMarshalList(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := MarshalList(&buf, []Infobase{{ID: "1", Name: "buh"}, {ID: "2", Name: "zup"}})
+	require.NoError(t, err)
+	require.Equal(t, "infobase : 1\nname     : buh\ndescr    : \n\ninfobase : 2\nname     : zup\ndescr    : \n\n", buf.String())
+}
+
+// TestMarshalRoundTrip - objects filled with random values survive Marshal and both decoders.
+func TestMarshalRoundTrip(t *testing.T) {
+	const iterations = 200
+
+	rnd := rand.New(rand.NewSource(1)) //nolint:gosec // reproducible test data
+
+	cases := []struct {
+		name string
+		new  func() any
+	}{
+		{name: "Cluster", new: func() any { return &Cluster{} }},
+		{name: "Infobase", new: func() any { return &Infobase{} }},
+		{name: "InfobaseInfo", new: func() any { return &InfobaseInfo{} }},
+		{name: "Session", new: func() any { return &Session{} }},
+		{name: "Connection", new: func() any { return &Connection{} }},
+		{name: "Process", new: func() any { return &Process{} }},
+		{name: "Server", new: func() any { return &Server{} }},
+		{name: "Manager", new: func() any { return &Manager{} }},
+		{name: "InfobaseLock", new: func() any { return &InfobaseLock{} }},
+	}
+
+	for _, tc := range cases {
+		for i := 0; i < iterations; i++ {
+			want := tc.new()
+			fillRandom(rnd, want)
+
+			lines, err := Marshal(want)
+			require.NoError(t, err)
+
+			got := tc.new()
+			require.NoError(t, Unmarshal(lines, got), tc.name)
+			require.Equal(t, want, got, "%s: %q", tc.name, lines)
+
+			gen := tc.new()
+			require.NoError(t, gen.(RACUnmarshaler).UnmarshalRAC(lines), tc.name)
+			require.Equal(t, want, gen, "%s: %q", tc.name, lines)
+		}
+	}
+}
+
+// fillRandom - sets rac tagged fields of struct pointed by v to random values rac can print.
+func fillRandom(rnd *rand.Rand, v any) {
+	rv := reflect.ValueOf(v).Elem()
+
+	for i := 0; i < rv.NumField(); i++ {
+		if _, ok := rv.Type().Field(i).Tag.Lookup(_key); !ok {
+			continue
+		}
+
+		switch f := rv.Field(i); f.Interface().(type) {
+		case time.Time:
+			if rnd.Intn(4) > 0 {
+				f.Set(reflect.ValueOf(time.Unix(rnd.Int63n(4e9), 0).In(ServerLocation)))
+			}
+		case int:
+			f.SetInt(rnd.Int63n(1e9) - 1e8)
+		case bool:
+			f.SetBool(rnd.Intn(2) == 1)
+		case string:
+			f.SetString(randomString(rnd))
+		}
+	}
+}
+
+func randomString(rnd *rand.Rand) string {
+	alphabet := []rune(`abcXYZ019 _-.:,"\БухЁё`)
+
+	var b strings.Builder
+
+	for n := rnd.Intn(12); n > 0; n-- {
+		b.WriteRune(alphabet[rnd.Intn(len(alphabet))])
+	}
+
+	return b.String()
+}
@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// SessionFilter - conditions to select sessions, empty fields match anything.
+type SessionFilter struct {
+	UserName    string
+	Host        string
+	AppID       string
+	IdleFor     time.Duration // minimal time since last activity
+	MinDuration time.Duration // minimal time since session start
+}
+
+// Match - checks session against filter at the moment now.
+func (f SessionFilter) Match(s Session, now time.Time) bool {
+	if f.UserName != "" && !strings.EqualFold(f.UserName, s.UserName) {
+		return false
+	}
+
+	if f.Host != "" && !strings.EqualFold(f.Host, s.Host) {
+		return false
+	}
+
+	if f.AppID != "" && !strings.EqualFold(f.AppID, s.AppID) {
+		return false
+	}
+
+	if f.IdleFor > 0 && (s.LastActive.IsZero() || now.Sub(s.LastActive) < f.IdleFor) {
+		return false
+	}
+
+	if f.MinDuration > 0 && (s.Started.IsZero() || now.Sub(s.Started) < f.MinDuration) {
+		return false
+	}
+
+	return true
+}
+
+// Filter - returns sessions matching filter at the moment now.
+func (f SessionFilter) Filter(sessions []Session, now time.Time) []Session {
+	rv := make([]Session, 0, len(sessions))
+
+	for i := range sessions {
+		if f.Match(sessions[i], now) {
+			rv = append(rv, sessions[i])
+		}
+	}
+
+	return rv
+}
+
+// ConnectionFilter - conditions to select connections, empty fields match anything.
+type ConnectionFilter struct {
+	Host        string
+	AppID       string
+	MinDuration time.Duration // minimal time since connect
+}
+
+// Match - checks connection against filter at the moment now.
+func (f ConnectionFilter) Match(c Connection, now time.Time) bool {
+	if f.Host != "" && !strings.EqualFold(f.Host, c.Host) {
+		return false
+	}
+
+	if f.AppID != "" && !strings.EqualFold(f.AppID, c.AppID) {
+		return false
+	}
+
+	if f.MinDuration > 0 && (c.Connected.IsZero() || now.Sub(c.Connected) < f.MinDuration) {
+		return false
+	}
+
+	return true
+}
+
+// Filter - returns connections matching filter at the moment now.
+func (f ConnectionFilter) Filter(connections []Connection, now time.Time) []Connection {
+	rv := make([]Connection, 0, len(connections))
+
+	for i := range connections {
+		if f.Match(connections[i], now) {
+			rv = append(rv, connections[i])
+		}
+	}
+
+	return rv
+}
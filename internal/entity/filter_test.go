@@ -0,0 +1,220 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionFilter(t *testing.T) {
+	now := time.Date(2023, time.August, 8, 12, 0, 0, 0, time.UTC)
+
+	sessions := []Session{
+		{
+			ID:         "1",
+			UserName:   "иванов",
+			Host:       "pc-1",
+			AppID:      "1cv8c",
+			Started:    now.Add(-3 * time.Hour),
+			LastActive: now.Add(-2 * time.Hour),
+		},
+		{
+			ID:         "2",
+			UserName:   "петров",
+			Host:       "pc-2",
+			AppID:      "1cv8c",
+			Started:    now.Add(-10 * time.Minute),
+			LastActive: now.Add(-1 * time.Minute),
+		},
+		{
+			ID:       "3",
+			UserName: "Иванов",
+			Host:     "pc-3",
+			AppID:    "designer",
+		},
+	}
+
+	cases := []struct {
+		name   string
+		filter SessionFilter
+		ids    []string
+	}{
+		{
+			name:   "Empty filter",
+			filter: SessionFilter{},
+			ids:    []string{"1", "2", "3"},
+		},
+		{
+			name:   "User case insensitive",
+			filter: SessionFilter{UserName: "ИВАНОВ"},
+			ids:    []string{"1", "3"},
+		},
+		{
+			name:   "Host",
+			filter: SessionFilter{Host: "pc-2"},
+			ids:    []string{"2"},
+		},
+		{
+			name:   "App id",
+			filter: SessionFilter{AppID: "designer"},
+			ids:    []string{"3"},
+		},
+		{
+			name:   "Idle",
+			filter: SessionFilter{IdleFor: time.Hour},
+			ids:    []string{"1"},
+		},
+		{
+			name:   "Min duration",
+			filter: SessionFilter{MinDuration: 5 * time.Minute},
+			ids:    []string{"1", "2"},
+		},
+		{
+			name:   "Combined",
+			filter: SessionFilter{UserName: "иванов", IdleFor: time.Minute},
+			ids:    []string{"1"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			res := tc.filter.Filter(sessions, now)
+
+			ids := make([]string, 0, len(res))
+			for i := range res {
+				ids = append(ids, res[i].ID)
+			}
+
+			require.Equal(t, tc.ids, ids)
+		})
+	}
+}
+
+// TestSessionFilterServerTime - rac prints times on wall clock of cluster servers, they are compared with now
+// in that time zone rather than in UTC.
+func TestSessionFilterServerTime(t *testing.T) {
+	prev := ServerLocation
+	ServerLocation = time.FixedZone("MSK", 3*60*60)
+
+	t.Cleanup(func() { ServerLocation = prev })
+
+	// 15:00 on wall clock of servers
+	now := time.Date(2023, time.August, 8, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name       string
+		started    string
+		lastActive string
+		filter     SessionFilter
+		match      bool
+	}{
+		{
+			name:       "Active a minute ago",
+			started:    "2023-08-08T09:00:00",
+			lastActive: "2023-08-08T14:59:00",
+			filter:     SessionFilter{IdleFor: 30 * time.Minute},
+			match:      false,
+		},
+		{
+			name:       "Idle for an hour",
+			started:    "2023-08-08T09:00:00",
+			lastActive: "2023-08-08T14:00:00",
+			filter:     SessionFilter{IdleFor: 30 * time.Minute},
+			match:      true,
+		},
+		{
+			name:       "Started ten minutes ago",
+			started:    "2023-08-08T14:50:00",
+			lastActive: "2023-08-08T14:59:00",
+			filter:     SessionFilter{MinDuration: time.Hour},
+			match:      false,
+		},
+		{
+			name:       "Started in the morning",
+			started:    "2023-08-08T09:00:00",
+			lastActive: "2023-08-08T14:59:00",
+			filter:     SessionFilter{MinDuration: time.Hour},
+			match:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		var s Session
+
+		require.NoError(t, s.UnmarshalRAC([]string{
+			"session        : 1",
+			"started-at     : " + tc.started,
+			"last-active-at : " + tc.lastActive,
+		}), tc.name)
+
+		require.Equal(t, tc.match, tc.filter.Match(s, now), tc.name)
+	}
+}
+
+func TestConnectionFilter(t *testing.T) {
+	now := time.Date(2023, time.August, 8, 12, 0, 0, 0, time.UTC)
+
+	connections := []Connection{
+		{
+			ID:        "1",
+			Host:      "pc-1",
+			AppID:     "1cv8c",
+			Connected: now.Add(-3 * time.Hour),
+		},
+		{
+			ID:        "2",
+			Host:      "PC-2",
+			AppID:     "comconsole",
+			Connected: now.Add(-time.Minute),
+		},
+	}
+
+	cases := []struct {
+		name   string
+		filter ConnectionFilter
+		ids    []string
+	}{
+		{
+			name:   "Empty filter",
+			filter: ConnectionFilter{},
+			ids:    []string{"1", "2"},
+		},
+		{
+			name:   "Host case insensitive",
+			filter: ConnectionFilter{Host: "pc-2"},
+			ids:    []string{"2"},
+		},
+		{
+			name:   "App id",
+			filter: ConnectionFilter{AppID: "1cv8c"},
+			ids:    []string{"1"},
+		},
+		{
+			name:   "Min duration",
+			filter: ConnectionFilter{MinDuration: time.Hour},
+			ids:    []string{"1"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			res := tc.filter.Filter(connections, now)
+
+			ids := make([]string, 0, len(res))
+			for i := range res {
+				ids = append(ids, res[i].ID)
+			}
+
+			require.Equal(t, tc.ids, ids)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const _formatLockTime = "02.01.2006 15:04"
+
+// SessionsLock - denial of new sessions in infobase -.
+type SessionsLock struct {
+	From              time.Time
+	To                time.Time
+	Message           string
+	Code              string
+	ScheduledJobsDeny bool
+}
+
+// InfobaseLock - denial settings of infobase as reported by infobase info,
+// saved before backup to be restored as is afterwards -.
+type InfobaseLock struct {
+	InfobaseID        string    `json:"ib"         rac:"infobase"             example:"UUID of infobase"`
+	SessionsDeny      bool      `json:"deny"       rac:"sessions-deny"        example:"on/off"`
+	From              time.Time `json:"from"       rac:"denied-from"          example:"Time lock starts"`
+	To                time.Time `json:"to"         rac:"denied-to"            example:"Time lock ends"`
+	Message           string    `json:"msg"        rac:"denied-message"       example:"Message shown to users"`
+	Parameter         string    `json:"param"      rac:"denied-parameter"     example:"Lock parameter"`
+	Code              string    `json:"code"       rac:"permission-code"      example:"Code to bypass lock"`
+	ScheduledJobsDeny bool      `json:"jobs"       rac:"scheduled-jobs-deny"  example:"on/off"`
+}
+
+// Render - replaces placeholders of message template:
+// {infobase} - infobase name, {from} and {to} - lock bounds, {minutes} - minutes left till lock starts.
+func (l SessionsLock) Render(tmpl, infobase string, now time.Time) string {
+	minutes := 0
+	if left := l.From.Sub(now); left > 0 {
+		minutes = int((left + time.Minute - 1) / time.Minute)
+	}
+
+	r := strings.NewReplacer(
+		"{infobase}", infobase,
+		"{from}", l.From.Format(_formatLockTime),
+		"{to}", l.To.Format(_formatLockTime),
+		"{minutes}", strconv.Itoa(minutes),
+	)
+
+	return r.Replace(tmpl)
+}
+
+// LockPolicy - how sessions of infobase are denied while making a backup -.
+type LockPolicy struct {
+	Code              string
+	Message           string        // template, see SessionsLock.Render
+	Window            time.Duration // how long sessions are denied, expected dump duration with margin
+	ScheduledJobsDeny bool
+}
+
+// Lock - lock of infobase starting at from.
+func (p LockPolicy) Lock(infobase string, from, now time.Time) SessionsLock {
+	l := SessionsLock{
+		From:              from,
+		To:                from.Add(p.Window),
+		Code:              p.Code,
+		ScheduledJobsDeny: p.ScheduledJobsDeny,
+	}
+
+	l.Message = l.Render(p.Message, infobase, now)
+
+	return l
+}
+
+// GracefulTermination - parameters of graceful sessions termination -.
+type GracefulTermination struct {
+	GracePeriod  time.Duration
+	PollInterval time.Duration
+	HardDeadline time.Duration
+
+	// Message - template shown to users while grace period lasts, see SessionsLock.Render
+	Message string
+	// ErrorMessage - shown to users of sessions terminated after grace period
+	ErrorMessage string
+}
+
+// DrainOptions - parameters of waiting until infobase has no sessions and connections -.
+type DrainOptions struct {
+	Timeout  time.Duration
+	Interval time.Duration
+
+	// Message - shown to users of terminated sessions
+	Message string
+}
@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionsLockRender(t *testing.T) {
+	now := time.Date(2023, time.August, 8, 22, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name string
+		lock SessionsLock
+		tmpl string
+		msg  string
+	}{
+		{
+			name: "Minutes",
+			lock: SessionsLock{From: now.Add(5 * time.Minute)},
+			tmpl: "closes in {minutes} min",
+			msg:  "closes in 5 min",
+		},
+		{
+			name: "Minutes rounded up",
+			lock: SessionsLock{From: now.Add(90 * time.Second)},
+			tmpl: "closes in {minutes} min",
+			msg:  "closes in 2 min",
+		},
+		{
+			name: "Minutes already started",
+			lock: SessionsLock{From: now.Add(-time.Minute)},
+			tmpl: "closes in {minutes} min",
+			msg:  "closes in 0 min",
+		},
+		{
+			name: "Bounds and infobase",
+			lock: SessionsLock{From: now, To: now.Add(90 * time.Minute)},
+			tmpl: "{infobase} is closed from {from} till {to}",
+			msg:  "buh is closed from 08.08.2023 22:00 till 08.08.2023 23:30",
+		},
+		{
+			name: "No placeholder",
+			lock: SessionsLock{From: now},
+			tmpl: "closed",
+			msg:  "closed",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.msg, tc.lock.Render(tc.tmpl, "buh", now))
+		})
+	}
+}
+
+func TestLockPolicyLock(t *testing.T) {
+	now := time.Date(2023, time.August, 8, 22, 0, 0, 0, time.UTC)
+
+	p := LockPolicy{
+		Code:              "123",
+		Message:           "closed till {to}",
+		Window:            time.Hour,
+		ScheduledJobsDeny: true,
+	}
+
+	require.Equal(t, SessionsLock{
+		From:              now,
+		To:                now.Add(time.Hour),
+		Message:           "closed till 08.08.2023 23:00",
+		Code:              "123",
+		ScheduledJobsDeny: true,
+	}, p.Lock("buh", now, now))
+}
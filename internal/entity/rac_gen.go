@@ -0,0 +1,608 @@
+// Code generated by racgen. DO NOT EDIT.
+
+package entity
+
+// UnmarshalRAC - decodes record of rac output into Cluster.
+func (v *Cluster) UnmarshalRAC(lines []string) error {
+	for _, line := range lines {
+		key, value, err := GetKeyValue(line, ':')
+		if err != nil {
+			continue
+		}
+
+		switch key {
+		case "cluster":
+			v.ID = value
+		case "host":
+			v.Host = value
+		case "port":
+			v.Port = value
+		case "name":
+			v.Name = value
+		case "expiration-timeout":
+			if x, ok := parseInt(value); ok {
+				v.Exp = x
+			}
+		case "lifetime-limit":
+			if x, ok := parseInt(value); ok {
+				v.LT = x
+			}
+		case "max-memory-size":
+			if x, ok := parseInt(value); ok {
+				v.MaxMemSize = x
+			}
+		case "max-memory-time-limit":
+			if x, ok := parseInt(value); ok {
+				v.MaxMemTimeLim = x
+			}
+		case "security-level":
+			if x, ok := parseInt(value); ok {
+				v.SecLevel = x
+			}
+		case "session-fault-tolerance-level":
+			if x, ok := parseInt(value); ok {
+				v.SesFTLevel = x
+			}
+		case "load-balancing-mode":
+			v.LBMode = value
+		case "errors-count-threshold":
+			if x, ok := parseInt(value); ok {
+				v.ErrCountTh = x
+			}
+		case "kill-problem-process":
+			if x, ok := parseInt(value); ok {
+				v.KillPP = x
+			}
+		}
+	}
+
+	if *v == (Cluster{}) {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// UnmarshalRAC - decodes record of rac output into Infobase.
+func (v *Infobase) UnmarshalRAC(lines []string) error {
+	for _, line := range lines {
+		key, value, err := GetKeyValue(line, ':')
+		if err != nil {
+			continue
+		}
+
+		switch key {
+		case "infobase":
+			v.ID = value
+		case "name":
+			v.Name = value
+		case "descr":
+			v.Desc = value
+		}
+	}
+
+	if *v == (Infobase{}) {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// UnmarshalRAC - decodes record of rac output into InfobaseInfo.
+func (v *InfobaseInfo) UnmarshalRAC(lines []string) error {
+	for _, line := range lines {
+		key, value, err := GetKeyValue(line, ':')
+		if err != nil {
+			continue
+		}
+
+		switch key {
+		case "infobase":
+			v.ID = value
+		case "name":
+			v.Name = value
+		case "descr":
+			v.Desc = value
+		case "dbms":
+			v.DBMS = value
+		case "db-server":
+			v.DBServer = value
+		case "db-name":
+			v.DBName = value
+		case "db-user":
+			v.DBUser = value
+		case "locale":
+			v.Locale = value
+		case "date-offset":
+			if x, ok := parseInt(value); ok {
+				v.DateOffset = x
+			}
+		case "security-level":
+			if x, ok := parseInt(value); ok {
+				v.SecLevel = x
+			}
+		case "license-distribution":
+			v.LicenseDistribution = value
+		case "sessions-deny":
+			v.SessionsDeny = parseBool(value)
+		case "denied-from":
+			if x, ok := parseTime(value); ok {
+				v.DeniedFrom = x
+			}
+		case "denied-to":
+			if x, ok := parseTime(value); ok {
+				v.DeniedTo = x
+			}
+		case "denied-message":
+			v.DeniedMessage = value
+		case "denied-parameter":
+			v.DeniedParameter = value
+		case "permission-code":
+			v.PermissionCode = value
+		case "scheduled-jobs-deny":
+			v.ScheduledJobsDeny = parseBool(value)
+		case "external-session-manager-connection-string":
+			v.ExtSessionMgrConn = value
+		case "external-session-manager-required":
+			v.ExtSessionMgrRequired = parseBool(value)
+		case "security-profile-name":
+			v.SecProfile = value
+		case "safe-mode-security-profile-name":
+			v.SafeModeSecProfile = value
+		case "reserve-working-processes":
+			v.ReserveProcesses = parseBool(value)
+		}
+	}
+
+	if *v == (InfobaseInfo{}) {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// UnmarshalRAC - decodes record of rac output into Session.
+func (v *Session) UnmarshalRAC(lines []string) error {
+	for _, line := range lines {
+		key, value, err := GetKeyValue(line, ':')
+		if err != nil {
+			continue
+		}
+
+		switch key {
+		case "session":
+			v.ID = value
+		case "session-id":
+			if x, ok := parseInt(value); ok {
+				v.SID = x
+			}
+		case "infobase":
+			v.InfobaseID = value
+		case "connection":
+			v.ConnectionID = value
+		case "process":
+			v.ProcessID = value
+		case "user-name":
+			v.UserName = value
+		case "host":
+			v.Host = value
+		case "app-id":
+			v.AppID = value
+		case "locale":
+			v.Loc = value
+		case "started-at":
+			if x, ok := parseTime(value); ok {
+				v.Started = x
+			}
+		case "last-active-at":
+			if x, ok := parseTime(value); ok {
+				v.LastActive = x
+			}
+		case "hibernate":
+			v.Hibernate = value
+		case "passive-session-hibernate-time":
+			if x, ok := parseInt(value); ok {
+				v.HiberTime = x
+			}
+		case "hibernate-session-terminate-time":
+			if x, ok := parseInt(value); ok {
+				v.HiberTermTime = x
+			}
+		case "blocked-by-dbms":
+			if x, ok := parseInt(value); ok {
+				v.BlockedDB = x
+			}
+		case "blocked-by-ls":
+			if x, ok := parseInt(value); ok {
+				v.BlockedLS = x
+			}
+		case "bytes-all":
+			if x, ok := parseInt(value); ok {
+				v.Bytes = x
+			}
+		case "bytes-last-5min":
+			if x, ok := parseInt(value); ok {
+				v.Bytes5m = x
+			}
+		case "calls-all":
+			if x, ok := parseInt(value); ok {
+				v.Calls = x
+			}
+		case "calls-last-5min":
+			if x, ok := parseInt(value); ok {
+				v.Calls5m = x
+			}
+		case "dbms-bytes-all":
+			if x, ok := parseInt(value); ok {
+				v.BytesDB = x
+			}
+		case "dbms-bytes-last-5min":
+			if x, ok := parseInt(value); ok {
+				v.BytesDB5m = x
+			}
+		case "db-proc-info":
+			v.DBProcInfo = value
+		case "db-proc-took":
+			if x, ok := parseInt(value); ok {
+				v.DBProc = x
+			}
+		case "db-proc-took-at":
+			v.DBProcAt = value
+		case "duration-all":
+			if x, ok := parseInt(value); ok {
+				v.Duration = x
+			}
+		case "duration-all-dbms":
+			if x, ok := parseInt(value); ok {
+				v.DurationDB = x
+			}
+		case "duration-current":
+			if x, ok := parseInt(value); ok {
+				v.DurationCur = x
+			}
+		case "duration-current-dbms":
+			if x, ok := parseInt(value); ok {
+				v.DurationCurDB = x
+			}
+		case "duration-last-5min":
+			if x, ok := parseInt(value); ok {
+				v.Duration5m = x
+			}
+		case "duration-last-5min-dbms":
+			if x, ok := parseInt(value); ok {
+				v.DurationDB5m = x
+			}
+		case "memory-current":
+			if x, ok := parseInt(value); ok {
+				v.MemoryCur = x
+			}
+		case "memory-last-5min":
+			if x, ok := parseInt(value); ok {
+				v.Memory5m = x
+			}
+		case "memory-total":
+			if x, ok := parseInt(value); ok {
+				v.Memory = x
+			}
+		case "read-current":
+			if x, ok := parseInt(value); ok {
+				v.ReadCur = x
+			}
+		case "read-last-5min":
+			if x, ok := parseInt(value); ok {
+				v.Read5m = x
+			}
+		case "read-total":
+			if x, ok := parseInt(value); ok {
+				v.Read = x
+			}
+		case "write-current":
+			if x, ok := parseInt(value); ok {
+				v.WriteCur = x
+			}
+		case "write-last-5min":
+			if x, ok := parseInt(value); ok {
+				v.Write5m = x
+			}
+		case "write-total":
+			if x, ok := parseInt(value); ok {
+				v.Write = x
+			}
+		case "duration-current-service":
+			if x, ok := parseInt(value); ok {
+				v.DurationSvcCur = x
+			}
+		case "duration-last-5min-service":
+			if x, ok := parseInt(value); ok {
+				v.DurationSvc5m = x
+			}
+		case "duration-all-service":
+			if x, ok := parseInt(value); ok {
+				v.DurationSvc = x
+			}
+		case "current-service-name":
+			v.Svc = value
+		case "cpu-time-current":
+			if x, ok := parseInt(value); ok {
+				v.CPUCur = x
+			}
+		case "cpu-time-last-5min":
+			if x, ok := parseInt(value); ok {
+				v.CPU5m = x
+			}
+		case "cpu-time-total":
+			if x, ok := parseInt(value); ok {
+				v.CPU = x
+			}
+		case "data-separation":
+			v.Sep = value
+		}
+	}
+
+	if *v == (Session{}) {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// UnmarshalRAC - decodes record of rac output into Connection.
+func (v *Connection) UnmarshalRAC(lines []string) error {
+	for _, line := range lines {
+		key, value, err := GetKeyValue(line, ':')
+		if err != nil {
+			continue
+		}
+
+		switch key {
+		case "connection":
+			v.ID = value
+		case "connection-id":
+			if x, ok := parseInt(value); ok {
+				v.CID = x
+			}
+		case "infobase":
+			v.InfobaseID = value
+		case "process":
+			v.ProcessID = value
+		case "host":
+			v.Host = value
+		case "application":
+			v.AppID = value
+		case "connected-at":
+			if x, ok := parseTime(value); ok {
+				v.Connected = x
+			}
+		case "session-number":
+			if x, ok := parseInt(value); ok {
+				v.SID = x
+			}
+		case "blocked-by-ls":
+			if x, ok := parseInt(value); ok {
+				v.Blocked = x
+			}
+		}
+	}
+
+	if *v == (Connection{}) {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// UnmarshalRAC - decodes record of rac output into Process.
+func (v *Process) UnmarshalRAC(lines []string) error {
+	for _, line := range lines {
+		key, value, err := GetKeyValue(line, ':')
+		if err != nil {
+			continue
+		}
+
+		switch key {
+		case "process":
+			v.ID = value
+		case "host":
+			v.Host = value
+		case "port":
+			if x, ok := parseInt(value); ok {
+				v.Port = x
+			}
+		case "pid":
+			if x, ok := parseInt(value); ok {
+				v.PID = x
+			}
+		case "turned-on":
+			v.Enabled = parseBool(value)
+		case "running":
+			v.Running = parseBool(value)
+		case "started-at":
+			if x, ok := parseTime(value); ok {
+				v.Started = x
+			}
+		case "use":
+			v.Use = value
+		case "available-perfomance":
+			if x, ok := parseInt(value); ok {
+				v.AvailPerf = x
+			}
+		case "capacity":
+			if x, ok := parseInt(value); ok {
+				v.Capacity = x
+			}
+		case "connections":
+			if x, ok := parseInt(value); ok {
+				v.Connections = x
+			}
+		case "memory-size":
+			if x, ok := parseInt(value); ok {
+				v.Memory = x
+			}
+		case "memory-excess-time":
+			if x, ok := parseInt(value); ok {
+				v.MemExcess = x
+			}
+		case "selection-size":
+			if x, ok := parseInt(value); ok {
+				v.Selection = x
+			}
+		case "reserve":
+			v.Reserve = parseBool(value)
+		}
+	}
+
+	if *v == (Process{}) {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// UnmarshalRAC - decodes record of rac output into Server.
+func (v *Server) UnmarshalRAC(lines []string) error {
+	for _, line := range lines {
+		key, value, err := GetKeyValue(line, ':')
+		if err != nil {
+			continue
+		}
+
+		switch key {
+		case "server":
+			v.ID = value
+		case "agent-host":
+			v.Host = value
+		case "agent-port":
+			if x, ok := parseInt(value); ok {
+				v.Port = x
+			}
+		case "port-range":
+			v.PortRange = value
+		case "name":
+			v.Name = value
+		case "using":
+			v.Using = value
+		case "dedicate-managers":
+			v.DedicateManagers = value
+		case "infobases-limit":
+			if x, ok := parseInt(value); ok {
+				v.InfobasesLimit = x
+			}
+		case "memory-limit":
+			if x, ok := parseInt(value); ok {
+				v.MemoryLimit = x
+			}
+		case "connections-limit":
+			if x, ok := parseInt(value); ok {
+				v.ConnectionsLimit = x
+			}
+		case "cluster-port":
+			if x, ok := parseInt(value); ok {
+				v.ClusterPort = x
+			}
+		case "critical-total-memory":
+			if x, ok := parseInt(value); ok {
+				v.CriticalMemory = x
+			}
+		case "safe-working-processes-memory-limit":
+			if x, ok := parseInt(value); ok {
+				v.SafeProcessMemory = x
+			}
+		case "safe-call-memory-limit":
+			if x, ok := parseInt(value); ok {
+				v.SafeCallMemory = x
+			}
+		}
+	}
+
+	if *v == (Server{}) {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// UnmarshalRAC - decodes record of rac output into Manager.
+func (v *Manager) UnmarshalRAC(lines []string) error {
+	for _, line := range lines {
+		key, value, err := GetKeyValue(line, ':')
+		if err != nil {
+			continue
+		}
+
+		switch key {
+		case "manager":
+			v.ID = value
+		case "pid":
+			if x, ok := parseInt(value); ok {
+				v.PID = x
+			}
+		case "using":
+			v.Using = value
+		case "host":
+			v.Host = value
+		case "main-port":
+			if x, ok := parseInt(value); ok {
+				v.Port = x
+			}
+		case "descr":
+			v.Desc = value
+		}
+	}
+
+	if *v == (Manager{}) {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// UnmarshalRAC - decodes record of rac output into InfobaseLock.
+func (v *InfobaseLock) UnmarshalRAC(lines []string) error {
+	for _, line := range lines {
+		key, value, err := GetKeyValue(line, ':')
+		if err != nil {
+			continue
+		}
+
+		switch key {
+		case "infobase":
+			v.InfobaseID = value
+		case "sessions-deny":
+			v.SessionsDeny = parseBool(value)
+		case "denied-from":
+			if x, ok := parseTime(value); ok {
+				v.From = x
+			}
+		case "denied-to":
+			if x, ok := parseTime(value); ok {
+				v.To = x
+			}
+		case "denied-message":
+			v.Message = value
+		case "denied-parameter":
+			v.Parameter = value
+		case "permission-code":
+			v.Code = value
+		case "scheduled-jobs-deny":
+			v.ScheduledJobsDeny = parseBool(value)
+		}
+	}
+
+	if *v == (InfobaseLock{}) {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+var (
+	_ RACUnmarshaler = (*Cluster)(nil)
+	_ RACUnmarshaler = (*Infobase)(nil)
+	_ RACUnmarshaler = (*InfobaseInfo)(nil)
+	_ RACUnmarshaler = (*Session)(nil)
+	_ RACUnmarshaler = (*Connection)(nil)
+	_ RACUnmarshaler = (*Process)(nil)
+	_ RACUnmarshaler = (*Server)(nil)
+	_ RACUnmarshaler = (*Manager)(nil)
+	_ RACUnmarshaler = (*InfobaseLock)(nil)
+)
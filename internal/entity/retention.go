@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"sort"
+	"time"
+)
+
+// RetentionPolicy - which backups of infobase are kept, zero policy keeps all of them -.
+type RetentionPolicy struct {
+	Keep   int           // newest backups to keep, unlimited if zero
+	MaxAge time.Duration // older backups are removed, unlimited if zero
+}
+
+// Backup - dump of infobase made earlier -.
+type Backup struct {
+	Path string
+	Time time.Time
+}
+
+// Expired - backups to remove by policy, the newest one is always kept.
+func (p RetentionPolicy) Expired(backups []Backup, now time.Time) []Backup {
+	sorted := append([]Backup(nil), backups...)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.After(sorted[j].Time)
+	})
+
+	var rv []Backup
+
+	for i, b := range sorted {
+		if i == 0 {
+			continue
+		}
+
+		if (p.Keep > 0 && i >= p.Keep) || (p.MaxAge > 0 && now.Sub(b.Time) > p.MaxAge) {
+			rv = append(rv, b)
+		}
+	}
+
+	return rv
+}
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetentionPolicyExpired(t *testing.T) {
+	now := time.Date(2023, 5, 10, 3, 0, 0, 0, time.UTC)
+
+	backups := []Backup{
+		{Path: "3", Time: now.Add(-72 * time.Hour)},
+		{Path: "0", Time: now},
+		{Path: "2", Time: now.Add(-48 * time.Hour)},
+		{Path: "1", Time: now.Add(-24 * time.Hour)},
+	}
+
+	cases := []struct {
+		name    string
+		policy  RetentionPolicy
+		backups []Backup
+		want    []string
+	}{
+		{
+			name:    "Keep all",
+			backups: backups,
+		},
+		{
+			name:    "Keep newest",
+			policy:  RetentionPolicy{Keep: 2},
+			backups: backups,
+			want:    []string{"2", "3"},
+		},
+		{
+			name:    "Max age",
+			policy:  RetentionPolicy{MaxAge: 36 * time.Hour},
+			backups: backups,
+			want:    []string{"2", "3"},
+		},
+		{
+			name:    "Both",
+			policy:  RetentionPolicy{Keep: 3, MaxAge: 36 * time.Hour},
+			backups: backups,
+			want:    []string{"2", "3"},
+		},
+		{
+			name:    "Newest is kept",
+			policy:  RetentionPolicy{Keep: 1, MaxAge: time.Hour},
+			backups: []Backup{{Path: "old", Time: now.Add(-100 * time.Hour)}},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+
+			for _, b := range tc.policy.Expired(tc.backups, now) {
+				got = append(got, b.Path)
+			}
+
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy - how calls failed by transient errors are repeated, zero policy makes single attempt -.
+type RetryPolicy struct {
+	Attempts int           // attempts in total, first one included
+	Initial  time.Duration // delay after first failure, doubled after each next one
+	Max      time.Duration // upper bound of delay
+	Deadline time.Duration // no attempt is started later than that since first one, unlimited if zero
+}
+
+// Backoff - delay before attempt following failed one, attempts are counted from 1.
+// Exponential delay is jittered by half to spread retries of parallel calls.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	d := p.Initial
+
+	for i := 1; i < attempt && (p.Max <= 0 || d < p.Max); i++ {
+		d *= 2
+	}
+
+	if p.Max > 0 && d > p.Max {
+		d = p.Max
+	}
+
+	if d <= 1 {
+		return d
+	}
+
+	half := d / 2
+
+	return half + time.Duration(rand.Int63n(int64(d-half))) //nolint:gosec // jitter only
+}
@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{
+		Attempts: 10,
+		Initial:  time.Second,
+		Max:      10 * time.Second,
+	}
+
+	cases := []struct {
+		name    string
+		attempt int
+		min     time.Duration
+		max     time.Duration
+	}{
+		{
+			name:    "First",
+			attempt: 1,
+			min:     500 * time.Millisecond,
+			max:     time.Second,
+		},
+		{
+			name:    "Doubled",
+			attempt: 3,
+			min:     2 * time.Second,
+			max:     4 * time.Second,
+		},
+		{
+			name:    "Capped",
+			attempt: 9,
+			min:     5 * time.Second,
+			max:     10 * time.Second,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			for i := 0; i < 100; i++ {
+				d := p.Backoff(tc.attempt)
+
+				require.GreaterOrEqual(t, d, tc.min)
+				require.LessOrEqual(t, d, tc.max)
+			}
+		})
+	}
+
+	require.Zero(t, RetryPolicy{}.Backoff(1))
+}
@@ -2,13 +2,22 @@ package backup
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 
 	"github.com/antonmisa/1cctl_cli/internal/entity"
 )
 
+var (
+	ErrDumpFailed    = errors.New("infobase dump failed")
+	ErrRestoreFailed = errors.New("infobase restore failed")
+	ErrNoResult      = errors.New("designer reported no success")
+)
+
 // CtrlBackup -.
 type CtrlBackup struct {
 	pathTo1C string
@@ -28,23 +37,95 @@ func New(path string) (*CtrlBackup, error) {
 	return ctrl, nil
 }
 
-// RunBackup -.
+// RunBackup - dumps infobase by designer, which reports reasons of failures to /Out file
+// and writes 0 to /DumpResult file on success only, exit code alone is not trusted.
+// Partial dump is removed on failure.
 func (r *CtrlBackup) RunBackup(ctx context.Context,
 	cl entity.Cluster, ib entity.Infobase,
 	ibCred entity.Credentials,
 	lockCode string,
 	outputPath string) error {
 
+	err := r.designer(ctx, cl, ib, ibCred, lockCode, "/DumpIB", outputPath)
+	if err == nil {
+		return nil
+	}
+
+	os.Remove(outputPath)
+
+	return fmt.Errorf("ctrlbackup - runbackup - %w: %w", ErrDumpFailed, err)
+}
+
+// RunRestore - loads infobase from dump by designer, it is reported the same way as dump.
+// Infobase is replaced entirely, sessions must be dropped before.
+func (r *CtrlBackup) RunRestore(ctx context.Context,
+	cl entity.Cluster, ib entity.Infobase,
+	ibCred entity.Credentials,
+	lockCode string,
+	dumpPath string) error {
+
+	if _, err := os.Stat(dumpPath); err != nil {
+		return fmt.Errorf("ctrlbackup - runrestore - os.Stat: %w", err)
+	}
+
+	if err := r.designer(ctx, cl, ib, ibCred, lockCode, "/RestoreIB", dumpPath); err != nil {
+		return fmt.Errorf("ctrlbackup - runrestore - %w: %w", ErrRestoreFailed, err)
+	}
+
+	return nil
+}
+
+// designer - runs designer action on dump file, success is told by /DumpResult file,
+// failure carries designer report.
+func (r *CtrlBackup) designer(ctx context.Context,
+	cl entity.Cluster, ib entity.Infobase,
+	ibCred entity.Credentials,
+	lockCode string,
+	action, path string) error {
+
+	dir, err := os.MkdirTemp("", "1cctl")
+	if err != nil {
+		return fmt.Errorf("os.MkdirTemp: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	outFile, resultFile := filepath.Join(dir, "out.txt"), filepath.Join(dir, "result.txt")
+
 	cmd := exec.CommandContext(ctx, r.pathTo1C, "CONFIG", "/S", fmt.Sprintf("%s:%s\\%s", cl.Host, cl.Port, ib.Name),
 		"/N", ibCred.Name, "/P", ibCred.Pwd,
 		"/UC", lockCode, "/DisableStartupMessages",
-		"/DumpIB", outputPath) //nolint:gosec // it is normal
+		"/Out", outFile, "/DumpResult", resultFile,
+		action, path) //nolint:gosec // it is normal
+
+	runErr := cmd.Run()
+
+	if result := readTrimmed(resultFile); runErr == nil && result == "0" {
+		return nil
+	}
 
-	err := cmd.Run()
+	// designer killed by canceled context tells nothing
+	if ctxErr := ctx.Err(); ctxErr != nil && runErr != nil {
+		runErr = fmt.Errorf("%w: %w", ctxErr, runErr)
+	}
+
+	if runErr == nil {
+		runErr = ErrNoResult
+	}
+
+	if out := readTrimmed(outFile); out != "" {
+		return fmt.Errorf("cmd.Run: %w: %s", runErr, out)
+	}
+
+	return fmt.Errorf("cmd.Run: %w", runErr)
+}
+
+// readTrimmed - content of designer report file, empty if there is none.
+func readTrimmed(path string) string {
+	data, err := os.ReadFile(path)
 	if err != nil {
-		return fmt.Errorf("ctrlbackup - runbackup - cmd.Run: %w", err)
+		return ""
 	}
-	defer cmd.Cancel()
 
-	return nil
+	// designer writes files with BOM
+	return strings.TrimSpace(strings.TrimPrefix(string(data), "\ufeff"))
 }
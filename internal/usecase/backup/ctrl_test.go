@@ -0,0 +1,184 @@
+package backup_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+	"github.com/antonmisa/1cctl_cli/internal/usecase/backup"
+	"github.com/antonmisa/1cctl_cli/internal/usecase/backup/designertest"
+)
+
+func TestMain(m *testing.M) {
+	designertest.Main()
+
+	os.Exit(m.Run())
+}
+
+func TestRunBackup(t *testing.T) {
+	cases := []struct {
+		name    string
+		fixture string
+		timeout time.Duration
+		err     error
+		out     string
+	}{
+		{
+			name:    "Success",
+			fixture: "testdata/ok.yaml",
+		},
+		{
+			name:    "Fail with message",
+			fixture: "testdata/fail.yaml",
+			err:     backup.ErrDumpFailed,
+			out:     "Ошибка получения монопольного доступа к информационной базе",
+		},
+		{
+			name:    "Wrong password",
+			fixture: "testdata/wrong_password.yaml",
+			err:     backup.ErrDumpFailed,
+			out:     designertest.ErrAuth.Error(),
+		},
+		{
+			name:    "Partial write",
+			fixture: "testdata/partial.yaml",
+			err:     backup.ErrDumpFailed,
+		},
+		{
+			name:    "Slow",
+			fixture: "testdata/slow.yaml",
+			timeout: 200 * time.Millisecond,
+			err:     context.DeadlineExceeded,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			d := designertest.New(t, tc.fixture)
+
+			ctrl, err := backup.New(d.Path)
+			require.NoError(t, err)
+
+			ctx := context.Background()
+
+			if tc.timeout > 0 {
+				var cancel context.CancelFunc
+
+				ctx, cancel = context.WithTimeout(ctx, tc.timeout)
+				defer cancel()
+			}
+
+			dump := filepath.Join(t.TempDir(), "buh.dt")
+
+			err = ctrl.RunBackup(ctx,
+				entity.Cluster{Host: "srv-1c", Port: "1541"}, entity.Infobase{Name: "Buh"},
+				entity.Credentials{Name: "backup", Pwd: "backup-pwd"}, "12345", dump)
+
+			calls := d.Calls(t)
+			require.Len(t, calls, 1)
+			require.Contains(t, calls[0], "/UC")
+			require.Contains(t, calls[0], "12345")
+
+			if tc.err == nil {
+				require.NoError(t, err)
+
+				data, err := os.ReadFile(dump)
+				require.NoError(t, err)
+				require.Equal(t, designertest.Dump(`srv-1c:1541\Buh`), data)
+
+				return
+			}
+
+			require.ErrorIs(t, err, tc.err)
+			require.ErrorContains(t, err, tc.out)
+
+			// No partial dump is left to be taken for backup
+			require.NoFileExists(t, dump)
+		})
+	}
+}
+
+func TestRunRestore(t *testing.T) {
+	dir := t.TempDir()
+
+	dump := filepath.Join(dir, "buh.dt")
+	require.NoError(t, os.WriteFile(dump, designertest.Dump(`srv-1c:1541\Buh`), 0o600))
+
+	partial := filepath.Join(dir, "partial.dt")
+	require.NoError(t, os.WriteFile(partial, designertest.Dump(`srv-1c:1541\Buh`)[:100], 0o600))
+
+	cases := []struct {
+		name    string
+		fixture string
+		dump    string
+		err     error
+		out     string
+	}{
+		{
+			name:    "Success",
+			fixture: "testdata/ok.yaml",
+			dump:    dump,
+		},
+		{
+			name:    "Partial dump",
+			fixture: "testdata/ok.yaml",
+			dump:    partial,
+			err:     backup.ErrRestoreFailed,
+			out:     designertest.ErrInvalidDump.Error(),
+		},
+		{
+			name:    "Wrong password",
+			fixture: "testdata/wrong_password.yaml",
+			dump:    dump,
+			err:     backup.ErrRestoreFailed,
+			out:     designertest.ErrAuth.Error(),
+		},
+		{
+			name:    "Missing dump",
+			fixture: "testdata/ok.yaml",
+			dump:    filepath.Join(dir, "missing.dt"),
+			err:     os.ErrNotExist,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			d := designertest.New(t, tc.fixture)
+
+			ctrl, err := backup.New(d.Path)
+			require.NoError(t, err)
+
+			err = ctrl.RunRestore(context.Background(),
+				entity.Cluster{Host: "srv-1c", Port: "1541"}, entity.Infobase{Name: "Buh"},
+				entity.Credentials{Name: "backup", Pwd: "backup-pwd"}, "12345", tc.dump)
+
+			if tc.err == nil {
+				require.NoError(t, err)
+
+				calls := d.Calls(t)
+				require.Len(t, calls, 1)
+				require.Contains(t, calls[0], "/RestoreIB")
+
+				return
+			}
+
+			require.ErrorIs(t, err, tc.err)
+			require.ErrorContains(t, err, tc.out)
+
+			// Dump is never touched by restore
+			if !errors.Is(tc.err, os.ErrNotExist) {
+				require.FileExists(t, tc.dump)
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+// Package designertest implements fake 1cv8 designer for tests: test binary started by CtrlBackup
+// dumps and restores infobases as told by behaviour fixture.
+package designertest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvFixture - path to behaviour fixture, test binary is fake designer when it is set.
+const EnvFixture = "DESIGNERTEST_FIXTURE"
+
+const (
+	// _envCalls - file designer appends arguments of each start to
+	_envCalls = "DESIGNERTEST_CALLS"
+
+	_exitError = 1
+
+	_dumpHeader = "1CV8DT fake dump\n"
+	_dumpFooter = "end of dump\n"
+	_dumpBlocks = 1024
+)
+
+var (
+	ErrUnknownMode      = errors.New("unknown mode")
+	ErrUnknownParameter = errors.New("unknown parameter")
+	ErrNoAction         = errors.New("nothing to do: /DumpIB or /RestoreIB is required")
+	ErrAuth             = errors.New("infobase user authentication failed")
+	ErrInvalidDump      = errors.New("invalid infobase dump file")
+)
+
+// Behaviour - how fake designer acts, read from fixture on each start -.
+type Behaviour struct {
+	// Users - infobase users with passwords, anyone is allowed if empty
+	Users map[string]string `yaml:"users"`
+	// Delay - time taken by dump or restore, e.g. 10s for slow designer
+	Delay time.Duration `yaml:"delay"`
+	// Fail - message designer fails with
+	Fail string `yaml:"fail"`
+	// Partial - dump is written halfway and designer exits without result, as if it crashed
+	Partial bool `yaml:"partial"`
+}
+
+// Designer - fake designer of a single test -.
+type Designer struct {
+	// Path - executable to pass to backup.New
+	Path string
+
+	calls string
+}
+
+// Main - turns test binary into fake designer when it is started in CONFIG or DESIGNER mode,
+// call it first in TestMain.
+func Main() {
+	fixture := os.Getenv(EnvFixture)
+	if fixture == "" || len(os.Args) < 2 || (os.Args[1] != "CONFIG" && os.Args[1] != "DESIGNER") {
+		return
+	}
+
+	os.Exit(Run(fixture, os.Args[1:]))
+}
+
+// New - fake designer acting as described by fixture.
+// Tests using it cannot be parallel since fixture is passed to designer by environment.
+func New(t testing.TB, fixture string) *Designer {
+	t.Helper()
+
+	path, err := os.Executable()
+	if err != nil {
+		t.Fatalf("designertest - New - os.Executable: %s", err)
+	}
+
+	fixture, err = filepath.Abs(fixture)
+	if err != nil {
+		t.Fatalf("designertest - New - filepath.Abs: %s", err)
+	}
+
+	t.Setenv(EnvFixture, fixture)
+
+	d := &Designer{
+		Path:  path,
+		calls: filepath.Join(t.TempDir(), "calls.jsonl"),
+	}
+
+	t.Setenv(_envCalls, d.calls)
+
+	return d
+}
+
+// Calls - arguments of every designer start, in order.
+func (d *Designer) Calls(t testing.TB) [][]string {
+	t.Helper()
+
+	data, err := os.ReadFile(d.calls)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		t.Fatalf("designertest - Calls - os.ReadFile: %s", err)
+	}
+
+	var rv [][]string
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var call []string
+
+		if err = json.Unmarshal(scanner.Bytes(), &call); err != nil {
+			t.Fatalf("designertest - Calls - json.Unmarshal: %s", err)
+		}
+
+		rv = append(rv, call)
+	}
+
+	return rv
+}
+
+// Dump - content of dump made by fake designer for infobase, /S value.
+func Dump(server string) []byte {
+	dump := append([]byte(_dumpHeader), bytes.Repeat([]byte(server+"\n"), _dumpBlocks)...)
+
+	return append(dump, _dumpFooter...)
+}
+
+// Run - executes designer command, returns exit code. As real designer it reports
+// to /Out file and writes 0 to /DumpResult file on success only.
+func Run(fixture string, args []string) int {
+	record(args)
+
+	p, err := parse(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+
+		return _exitError
+	}
+
+	err = run(fixture, p)
+
+	if p["out"] != "" {
+		msg := "Operation completed successfully"
+		if err != nil {
+			msg = err.Error()
+		}
+
+		os.WriteFile(p["out"], []byte(msg+"\n"), 0o600) //nolint:errcheck // best effort as designer does
+	}
+
+	if errors.Is(err, errCrash) {
+		return _exitError
+	}
+
+	result := "0"
+	if err != nil {
+		result = "1"
+	}
+
+	if p["dumpresult"] != "" {
+		os.WriteFile(p["dumpresult"], []byte(result), 0o600) //nolint:errcheck // best effort as designer does
+	}
+
+	if err != nil {
+		return _exitError
+	}
+
+	return 0
+}
+
+// errCrash - designer exits without result, as if it was killed.
+var errCrash = errors.New("designer crashed")
+
+func run(fixture string, p params) error {
+	b, err := load(fixture)
+	if err != nil {
+		return err
+	}
+
+	if len(b.Users) > 0 {
+		if pwd, ok := b.Users[p["n"]]; !ok || pwd != p["p"] {
+			return ErrAuth
+		}
+	}
+
+	time.Sleep(b.Delay)
+
+	if b.Fail != "" {
+		return errors.New(b.Fail) //nolint:goerr113 // message of fixture
+	}
+
+	switch {
+	case p["dumpib"] != "":
+		dump := Dump(p["s"])
+
+		if b.Partial {
+			os.WriteFile(p["dumpib"], dump[:len(dump)/2], 0o600) //nolint:errcheck // crashing anyway
+
+			return errCrash
+		}
+
+		return os.WriteFile(p["dumpib"], dump, 0o600)
+	case p["restoreib"] != "":
+		data, err := os.ReadFile(p["restoreib"])
+		if err != nil {
+			return err
+		}
+
+		// dump of any infobase can be restored, but not a partial one
+		if !bytes.HasPrefix(data, []byte(_dumpHeader)) || !bytes.HasSuffix(data, []byte(_dumpFooter)) {
+			return ErrInvalidDump
+		}
+
+		return nil
+	default:
+		return ErrNoAction
+	}
+}
+
+// params - values of designer parameters by lowercased name without slash.
+type params map[string]string
+
+// _parameters - parameters known to fake designer, true if parameter has value.
+var _parameters = map[string]bool{
+	"s":                      true,
+	"n":                      true,
+	"p":                      true,
+	"uc":                     true,
+	"dumpib":                 true,
+	"restoreib":              true,
+	"out":                    true,
+	"dumpresult":             true,
+	"disablestartupmessages": false,
+}
+
+func parse(args []string) (params, error) {
+	if len(args) == 0 || (args[0] != "CONFIG" && args[0] != "DESIGNER") {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownMode, args)
+	}
+
+	p := params{}
+
+	for i := 1; i < len(args); i++ {
+		name := strings.ToLower(strings.TrimPrefix(args[i], "/"))
+
+		hasValue, ok := _parameters[name]
+		if !ok || !strings.HasPrefix(args[i], "/") {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownParameter, args[i])
+		}
+
+		if hasValue && i+1 < len(args) {
+			i++
+			p[name] = args[i]
+		} else {
+			p[name] = ""
+		}
+	}
+
+	return p, nil
+}
+
+func load(fixture string) (Behaviour, error) {
+	var b Behaviour
+
+	data, err := os.ReadFile(fixture)
+	if err != nil {
+		return b, err
+	}
+
+	if err = yaml.Unmarshal(data, &b); err != nil {
+		return b, fmt.Errorf("yaml.Unmarshal: %w", err)
+	}
+
+	return b, nil
+}
+
+// record - appends arguments to calls file of test.
+func record(args []string) {
+	path := os.Getenv(_envCalls)
+	if path == "" {
+		return
+	}
+
+	data, err := json.Marshal(args)
+	if err != nil {
+		return
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	f.Write(append(data, '\n')) //nolint:errcheck // best effort
+}
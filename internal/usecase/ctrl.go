@@ -3,13 +3,24 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"os"
 	"path"
+	"path/filepath"
+	"strings"
 	"time"
 
-	e "github.com/antonmisa/1cctl_cli/internal/common/clierror"
 	"github.com/antonmisa/1cctl_cli/internal/entity"
 )
 
+const (
+	defaultBlockTime    = 60 * time.Minute
+	defaultBlockMessage = "База закрыта на создание резервной копии до {to}"
+	defaultPollInterval = 15 * time.Second
+
+	// formatBackupTime - time of backup in its file name
+	formatBackupTime = "02_01_2006_15_04_05"
+)
+
 // CtrlUseCase -.
 type CtrlUseCase struct {
 	pipe   CtrlPipe
@@ -26,21 +37,41 @@ func New(p CtrlPipe, b CtrlBackup) *CtrlUseCase {
 	}
 }
 
-// ClusterByName - getting cluster by name -.
-func (uc *CtrlUseCase) ClusterByName(ctx context.Context, clusterName string) (entity.Cluster, error) {
-	clusters, err := uc.pipe.GetClusters(ctx)
+// Clusters - clusters of central server.
+func (uc *CtrlUseCase) Clusters(ctx context.Context, agentCred entity.Credentials) ([]entity.Cluster, error) {
+	clusters, err := uc.pipe.GetClusters(ctx, agentCred)
+	if err != nil {
+		return nil, fmt.Errorf("CtrlUseCase - Clusters - uc.pipe.GetClusters: %w", err)
+	}
+
+	return clusters, nil
+}
+
+// ClusterByName - getting cluster by name, agent credentials are needed if central server has administrators -.
+func (uc *CtrlUseCase) ClusterByName(ctx context.Context, clusterName string, agentCred entity.Credentials) (entity.Cluster, error) {
+	clusters, err := uc.pipe.GetClusters(ctx, agentCred)
 	if err != nil {
 		return entity.Cluster{}, fmt.Errorf("CtrlUseCase - Clusters - uc.pipe.GetClusters: %w", err)
 	}
 
 	for i := range clusters {
-		if fmt.Sprintf("%s:%s", clusters[i].Host, clusters[i].Port) == clusterName {
+		// host names are case-insensitive
+		if strings.EqualFold(fmt.Sprintf("%s:%s", clusters[i].Host, clusters[i].Port), clusterName) {
 			return clusters[i], nil
 		}
 	}
 
-	return entity.Cluster{}, e.WithText{
-		Txt: fmt.Sprintf("cluster with name %s not found", clusterName)}
+	return entity.Cluster{}, fmt.Errorf("CtrlUseCase - ClusterByName: %w: %s", ErrClusterNotFound, clusterName)
+}
+
+// Infobases - infobases of cluster, as brief as rac lists them.
+func (uc *CtrlUseCase) Infobases(ctx context.Context, cluster entity.Cluster, clusterCred entity.Credentials) ([]entity.Infobase, error) {
+	infobases, err := uc.pipe.GetInfobases(ctx, cluster, clusterCred)
+	if err != nil {
+		return nil, fmt.Errorf("CtrlUseCase - Infobases - uc.pipe.GetInfobases: %w", err)
+	}
+
+	return infobases, nil
 }
 
 // Infobases - getting infobases list for cluster.
@@ -51,13 +82,23 @@ func (uc *CtrlUseCase) InfobaseByName(ctx context.Context, cluster entity.Cluste
 	}
 
 	for i := range infobases {
-		if infobases[i].Name == infobaseName {
+		// 1C platform treats infobase names case-insensitively, so does rac
+		if strings.EqualFold(infobases[i].Name, infobaseName) {
 			return infobases[i], nil
 		}
 	}
 
-	return entity.Infobase{}, e.WithText{
-		Txt: fmt.Sprintf("infobase with name %s not found", infobaseName)}
+	return entity.Infobase{}, fmt.Errorf("CtrlUseCase - InfobaseByName: %w: %s", ErrInfobaseNotFound, infobaseName)
+}
+
+// InfobaseInfo - getting full properties of infobase.
+func (uc *CtrlUseCase) InfobaseInfo(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred, infobaseCred entity.Credentials) (entity.InfobaseInfo, error) {
+	info, err := uc.pipe.GetInfobaseInfo(ctx, cluster, infobase, clusterCred, infobaseCred)
+	if err != nil {
+		return entity.InfobaseInfo{}, fmt.Errorf("CtrlUseCase - InfobaseInfo - uc.pipe.GetInfobaseInfo: %w", err)
+	}
+
+	return info, nil
 }
 
 // Sessions - getting sessions list for cluster.
@@ -71,28 +112,47 @@ func (uc *CtrlUseCase) Sessions(ctx context.Context, cluster entity.Cluster, inf
 }
 
 // Disable new sessions for current infobase -.
-func (uc *CtrlUseCase) DisableSessions(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred, infobaseCred entity.Credentials, code string) error {
-	err := uc.pipe.DisableSessions(ctx, cluster, infobase, clusterCred, infobaseCred, code)
+func (uc *CtrlUseCase) DisableSessions(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred, infobaseCred entity.Credentials, policy entity.LockPolicy) error {
+	now := time.Now()
+
+	lock := withDefaults(policy).Lock(infobase.Name, now, now)
+
+	err := uc.pipe.DisableSessions(ctx, cluster, infobase, clusterCred, infobaseCred, lock)
 	if err != nil {
 		return fmt.Errorf("CtrlUseCase - DisableSessions - uc.pipe.DisableSessions: %w", err)
 	}
 
+	err = uc.verifyLock(ctx, cluster, infobase, clusterCred, infobaseCred, lock)
+	if err != nil {
+		return fmt.Errorf("CtrlUseCase - DisableSessions - uc.verifyLock: %w", err)
+	}
+
 	return nil
 }
 
-// Enable new sessions for current infobase -.
-func (uc *CtrlUseCase) EnableSessions(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred, infobaseCred entity.Credentials, code string) error {
-	err := uc.pipe.EnableSessions(ctx, cluster, infobase, clusterCred, infobaseCred, code)
+// LockState - current denial settings of infobase -.
+func (uc *CtrlUseCase) LockState(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred, infobaseCred entity.Credentials) (entity.InfobaseLock, error) {
+	state, err := uc.pipe.GetInfobaseLock(ctx, cluster, infobase, clusterCred, infobaseCred)
+	if err != nil {
+		return entity.InfobaseLock{}, fmt.Errorf("CtrlUseCase - LockState - uc.pipe.GetInfobaseLock: %w", err)
+	}
+
+	return state, nil
+}
+
+// RestoreLock - returns denial settings of infobase to state saved by LockState -.
+func (uc *CtrlUseCase) RestoreLock(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred, infobaseCred entity.Credentials, state entity.InfobaseLock) error {
+	err := uc.pipe.RestoreLock(ctx, cluster, infobase, clusterCred, infobaseCred, state)
 	if err != nil {
-		return fmt.Errorf("CtrlUseCase - EnableSessions - uc.pipe.EnableSessions: %w", err)
+		return fmt.Errorf("CtrlUseCase - RestoreLock - uc.pipe.RestoreLock: %w", err)
 	}
 
 	return nil
 }
 
-// Delete sessions -.
-func (uc *CtrlUseCase) DeleteSessions(ctx context.Context, cluster entity.Cluster, sessions []entity.Session, clusterCred entity.Credentials) error {
-	err := uc.pipe.DeleteSessions(ctx, cluster, sessions, clusterCred)
+// Delete sessions, message is shown to users of terminated sessions -.
+func (uc *CtrlUseCase) DeleteSessions(ctx context.Context, cluster entity.Cluster, sessions []entity.Session, clusterCred entity.Credentials, message string) error {
+	err := uc.pipe.DeleteSessions(ctx, cluster, sessions, clusterCred, message)
 	if err != nil {
 		return fmt.Errorf("CtrlUseCase - DeleteSessions - uc.pipe.DeleteSessions: %w", err)
 	}
@@ -100,6 +160,123 @@ func (uc *CtrlUseCase) DeleteSessions(ctx context.Context, cluster entity.Cluste
 	return nil
 }
 
+// TerminateSessions - denies new sessions warning users that infobase closes after grace period,
+// waits for users to leave and terminates remaining sessions. Sessions are terminated by hard deadline
+// even if grace period is longer.
+func (uc *CtrlUseCase) TerminateSessions(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred, infobaseCred entity.Credentials, policy entity.LockPolicy, opts entity.GracefulTermination) error {
+	pollInterval := opts.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+
+	now := time.Now()
+	closeAt := now.Add(opts.GracePeriod)
+
+	if opts.HardDeadline > 0 && opts.HardDeadline < opts.GracePeriod {
+		closeAt = now.Add(opts.HardDeadline)
+	}
+
+	lock := withDefaults(policy).Lock(infobase.Name, closeAt, now)
+	lock.Message = lock.Render(opts.Message, infobase.Name, now)
+
+	err := uc.pipe.DisableSessions(ctx, cluster, infobase, clusterCred, infobaseCred, lock)
+	if err != nil {
+		return fmt.Errorf("CtrlUseCase - TerminateSessions - uc.pipe.DisableSessions: %w", err)
+	}
+
+	err = uc.verifyLock(ctx, cluster, infobase, clusterCred, infobaseCred, lock)
+	if err != nil {
+		return fmt.Errorf("CtrlUseCase - TerminateSessions - uc.verifyLock: %w", err)
+	}
+
+	for {
+		sessions, err := uc.pipe.GetSessions(ctx, cluster, infobase, clusterCred)
+		if err != nil {
+			return fmt.Errorf("CtrlUseCase - TerminateSessions - uc.pipe.GetSessions: %w", err)
+		}
+
+		if len(sessions) == 0 {
+			return nil
+		}
+
+		left := time.Until(closeAt)
+		if left <= 0 {
+			err = uc.pipe.DeleteSessions(ctx, cluster, sessions, clusterCred, opts.ErrorMessage)
+			if err != nil {
+				return fmt.Errorf("CtrlUseCase - TerminateSessions - uc.pipe.DeleteSessions: %w", err)
+			}
+
+			return nil
+		}
+
+		if left > pollInterval {
+			left = pollInterval
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("CtrlUseCase - TerminateSessions: %w", ctx.Err())
+		case <-time.After(left):
+		}
+	}
+}
+
+// Drain - terminates sessions and connections of infobase until none remain or timeout passes,
+// returns *DrainError with stragglers left after timeout.
+func (uc *CtrlUseCase) Drain(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, opts entity.DrainOptions) error {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	deadline := time.Now().Add(opts.Timeout)
+
+	var lastErr error
+
+	for pass := 0; ; pass++ {
+		sessions, err := uc.pipe.GetSessions(ctx, cluster, infobase, clusterCred)
+		if err != nil {
+			return fmt.Errorf("CtrlUseCase - Drain - uc.pipe.GetSessions: %w", err)
+		}
+
+		connections, err := uc.pipe.GetConnections(ctx, cluster, infobase, clusterCred)
+		if err != nil {
+			return fmt.Errorf("CtrlUseCase - Drain - uc.pipe.GetConnections: %w", err)
+		}
+
+		if len(sessions) == 0 && len(connections) == 0 {
+			return nil
+		}
+
+		// Stragglers are terminated at least once, even if timeout is not positive
+		if pass > 0 && !time.Now().Before(deadline) {
+			return &DrainError{
+				Sessions:    sessions,
+				Connections: connections,
+				Err:         lastErr,
+			}
+		}
+
+		if len(sessions) > 0 {
+			if err = uc.pipe.DeleteSessions(ctx, cluster, sessions, clusterCred, opts.Message); err != nil {
+				lastErr = err
+			}
+		}
+
+		if len(connections) > 0 {
+			if err = uc.pipe.DeleteConnections(ctx, cluster, connections, clusterCred); err != nil {
+				lastErr = err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("CtrlUseCase - Drain: %w", ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
+
 // Connections - getting connections list for cluster.
 func (uc *CtrlUseCase) Connections(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials) ([]entity.Connection, error) {
 	connections, err := uc.pipe.GetConnections(ctx, cluster, infobase, clusterCred)
@@ -120,9 +297,39 @@ func (uc *CtrlUseCase) DeleteConnections(ctx context.Context, cluster entity.Clu
 	return nil
 }
 
+// Processes - getting working processes of cluster.
+func (uc *CtrlUseCase) Processes(ctx context.Context, cluster entity.Cluster, clusterCred entity.Credentials) ([]entity.Process, error) {
+	processes, err := uc.pipe.GetProcesses(ctx, cluster, clusterCred)
+	if err != nil {
+		return nil, fmt.Errorf("CtrlUseCase - Processes - uc.pipe.GetProcesses: %w", err)
+	}
+
+	return processes, nil
+}
+
+// Servers - getting working servers of cluster.
+func (uc *CtrlUseCase) Servers(ctx context.Context, cluster entity.Cluster, clusterCred entity.Credentials) ([]entity.Server, error) {
+	servers, err := uc.pipe.GetServers(ctx, cluster, clusterCred)
+	if err != nil {
+		return nil, fmt.Errorf("CtrlUseCase - Servers - uc.pipe.GetServers: %w", err)
+	}
+
+	return servers, nil
+}
+
+// Managers - getting cluster managers.
+func (uc *CtrlUseCase) Managers(ctx context.Context, cluster entity.Cluster, clusterCred entity.Credentials) ([]entity.Manager, error) {
+	managers, err := uc.pipe.GetManagers(ctx, cluster, clusterCred)
+	if err != nil {
+		return nil, fmt.Errorf("CtrlUseCase - Managers - uc.pipe.GetManagers: %w", err)
+	}
+
+	return managers, nil
+}
+
 // Backup -.
 func (uc *CtrlUseCase) RunBackup(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, lockCode, outputPath string) (string, error) {
-	fullPath := path.Join(outputPath, fmt.Sprintf("%s_%s.dt", time.Now().Format("02_01_2006_15_04_05"), infobase.Name))
+	fullPath := path.Join(outputPath, fmt.Sprintf("%s_%s.dt", time.Now().Format(formatBackupTime), infobase.Name))
 
 	err := uc.backup.RunBackup(ctx, cluster, infobase, clusterCred, lockCode, fullPath)
 	if err != nil {
@@ -131,3 +338,86 @@ func (uc *CtrlUseCase) RunBackup(ctx context.Context, cluster entity.Cluster, in
 
 	return fullPath, nil
 }
+
+// RunRestore - loads infobase from dump file.
+func (uc *CtrlUseCase) RunRestore(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, infobaseCred entity.Credentials, lockCode, dumpPath string) error {
+	err := uc.backup.RunRestore(ctx, cluster, infobase, infobaseCred, lockCode, dumpPath)
+	if err != nil {
+		return fmt.Errorf("CtrlUseCase - RunRestore - uc.backup.RunRestore: %w", err)
+	}
+
+	return nil
+}
+
+// PruneBackups - removes backups of infobase in outputPath expired by policy, returns removed ones.
+// Backups are told by file names given by RunBackup, infobase names are compared case-insensitively.
+func (uc *CtrlUseCase) PruneBackups(outputPath string, infobase string, policy entity.RetentionPolicy) ([]entity.Backup, error) {
+	if policy == (entity.RetentionPolicy{}) {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("CtrlUseCase - PruneBackups - os.ReadDir: %w", err)
+	}
+
+	var backups []entity.Backup
+
+	for _, entry := range entries {
+		name := entry.Name()
+
+		if entry.IsDir() || len(name) <= len(formatBackupTime)+1 || name[len(formatBackupTime)] != '_' {
+			continue
+		}
+
+		ib, ok := strings.CutSuffix(name[len(formatBackupTime)+1:], ".dt")
+		if !ok || !strings.EqualFold(ib, infobase) {
+			continue
+		}
+
+		t, err := time.ParseInLocation(formatBackupTime, name[:len(formatBackupTime)], time.Local)
+		if err != nil {
+			continue
+		}
+
+		backups = append(backups, entity.Backup{Path: filepath.Join(outputPath, name), Time: t})
+	}
+
+	expired := policy.Expired(backups, time.Now())
+
+	for i, b := range expired {
+		if err = os.Remove(b.Path); err != nil {
+			return expired[:i], fmt.Errorf("CtrlUseCase - PruneBackups - os.Remove: %w", err)
+		}
+	}
+
+	return expired, nil
+}
+
+// verifyLock - reads denial settings back, rac may silently ignore update, e.g. lacking infobase rights.
+func (uc *CtrlUseCase) verifyLock(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred, infobaseCred entity.Credentials, lock entity.SessionsLock) error {
+	state, err := uc.pipe.GetInfobaseLock(ctx, cluster, infobase, clusterCred, infobaseCred)
+	if err != nil {
+		return fmt.Errorf("uc.pipe.GetInfobaseLock: %w", err)
+	}
+
+	if !state.SessionsDeny || !strings.EqualFold(state.Code, lock.Code) {
+		return ErrLockNotApplied
+	}
+
+	return nil
+}
+
+// withDefaults - lock policy with empty window and message replaced by default ones,
+// so users are never shown lock without a word.
+func withDefaults(policy entity.LockPolicy) entity.LockPolicy {
+	if policy.Window <= 0 {
+		policy.Window = defaultBlockTime
+	}
+
+	if policy.Message == "" {
+		policy.Message = defaultBlockMessage
+	}
+
+	return policy
+}
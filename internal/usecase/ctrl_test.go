@@ -5,7 +5,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -21,8 +25,10 @@ func TestClusterByName(t *testing.T) {
 		ctx           context.Context
 		clConn        string
 		clName        string
+		agentCred     entity.Credentials
 		cls           []entity.Cluster
 		respError     string
+		respErrorIs   error
 		pipeMockError error
 	}{
 		{
@@ -39,6 +45,24 @@ func TestClusterByName(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "Success w agent cred",
+			ctx:    context.Background(),
+			clConn: "localhost:1545",
+			clName: "localhost:1234",
+			agentCred: entity.Credentials{
+				Name: "admin",
+				Pwd:  "pwd",
+			},
+			cls: []entity.Cluster{
+				{
+					ID:   "1",
+					Host: "localhost",
+					Port: "1234",
+					Name: "test",
+				},
+			},
+		},
 		{
 			name:   "Not found",
 			ctx:    context.Background(),
@@ -52,7 +76,8 @@ func TestClusterByName(t *testing.T) {
 					Name: "bad",
 				},
 			},
-			respError: "cluster with name test not found",
+			respError:   "cluster not found: test",
+			respErrorIs: usecase.ErrClusterNotFound,
 		},
 		{
 			name:          "Pipe error",
@@ -73,13 +98,13 @@ func TestClusterByName(t *testing.T) {
 			ctrlPipeMock := mocks.NewCtrlPipe(t)
 			ctrlBackupMock := mocks.NewCtrlBackup(t)
 
-			ctrlPipeMock.On("GetClusters", mock.MatchedBy(func(ctx context.Context) bool { return true })).
+			ctrlPipeMock.On("GetClusters", mock.MatchedBy(func(ctx context.Context) bool { return true }), tc.agentCred).
 				Return(tc.cls, tc.pipeMockError).
 				Once()
 
 			ctrl := usecase.New(ctrlPipeMock, ctrlBackupMock)
 
-			cl, err := ctrl.ClusterByName(tc.ctx, tc.clName)
+			cl, err := ctrl.ClusterByName(tc.ctx, tc.clName, tc.agentCred)
 
 			if tc.respError == "" {
 				require.NoError(t, err)
@@ -89,6 +114,10 @@ func TestClusterByName(t *testing.T) {
 				require.Error(t, err)
 				require.ErrorContains(t, err, tc.respError)
 
+				if tc.respErrorIs != nil {
+					require.ErrorIs(t, err, tc.respErrorIs)
+				}
+
 				require.Equal(t, cl, entity.Cluster{})
 			}
 		})
@@ -104,6 +133,7 @@ func TestInfobaseByName(t *testing.T) {
 		clCred        entity.Credentials
 		ibs           []entity.Infobase
 		respError     string
+		respErrorIs   error
 		pipeMockError error
 	}{
 		{
@@ -119,6 +149,19 @@ func TestInfobaseByName(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "Success case-insensitive",
+			ctx:    context.Background(),
+			cl:     entity.Cluster{ID: "123"},
+			ibName: "buh_main",
+			clCred: entity.Credentials{},
+			ibs: []entity.Infobase{
+				{
+					ID:   "1",
+					Name: "Buh_Main",
+				},
+			},
+		},
 		{
 			name:   "Not found",
 			ctx:    context.Background(),
@@ -131,7 +174,8 @@ func TestInfobaseByName(t *testing.T) {
 					Name: "bad",
 				},
 			},
-			respError: "infobase with name test not found",
+			respError:   "infobase not found: test",
+			respErrorIs: usecase.ErrInfobaseNotFound,
 		},
 		{
 			name:          "Pipe error",
@@ -167,11 +211,15 @@ func TestInfobaseByName(t *testing.T) {
 			if tc.respError == "" {
 				require.NoError(t, err)
 
-				require.Equal(t, ib.Name, tc.ibName)
+				require.True(t, strings.EqualFold(ib.Name, tc.ibName))
 			} else {
 				require.Error(t, err)
 				require.ErrorContains(t, err, tc.respError)
 
+				if tc.respErrorIs != nil {
+					require.ErrorIs(t, err, tc.respErrorIs)
+				}
+
 				require.Equal(t, ib, entity.Infobase{})
 			}
 		})
@@ -260,3 +308,468 @@ func TestSessions(t *testing.T) {
 		})
 	}
 }
+
+func TestTerminateSessions(t *testing.T) {
+	cases := []struct {
+		name             string
+		opts             entity.GracefulTermination
+		ss               []entity.Session
+		respError        string
+		disableMockError error
+		deleteMockError  error
+		deleteCalls      int
+	}{
+		{
+			name: "Success users left",
+			opts: entity.GracefulTermination{
+				GracePeriod:  time.Hour,
+				PollInterval: time.Millisecond,
+				Message:      "closes in {minutes} min",
+			},
+			ss: make([]entity.Session, 0),
+		},
+		{
+			name: "Success terminated after grace period",
+			opts: entity.GracefulTermination{
+				GracePeriod:  0,
+				PollInterval: time.Millisecond,
+				ErrorMessage: "terminated for backup",
+			},
+			ss: []entity.Session{
+				{
+					ID: "1",
+				},
+			},
+			deleteCalls: 1,
+		},
+		{
+			name: "Hard deadline terminates before grace period ends",
+			opts: entity.GracefulTermination{
+				GracePeriod:  time.Hour,
+				PollInterval: time.Millisecond,
+				HardDeadline: 20 * time.Millisecond,
+				ErrorMessage: "terminated for backup",
+			},
+			ss: []entity.Session{
+				{
+					ID: "1",
+				},
+			},
+			deleteCalls: 1,
+		},
+		{
+			name: "Disable error",
+			opts: entity.GracefulTermination{
+				GracePeriod: time.Hour,
+			},
+			respError:        ": unexpected error",
+			disableMockError: errors.New("unexpected error"),
+		},
+		{
+			name: "Delete error",
+			opts: entity.GracefulTermination{},
+			ss: []entity.Session{
+				{
+					ID: "1",
+				},
+			},
+			respError:       ": delete error",
+			deleteMockError: errors.New("delete error"),
+			deleteCalls:     1,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrlPipeMock := mocks.NewCtrlPipe(t)
+			ctrlBackupMock := mocks.NewCtrlBackup(t)
+
+			ctrlPipeMock.On("DisableSessions",
+				mock.MatchedBy(func(ctx context.Context) bool { return true }),
+				mock.AnythingOfType("entity.Cluster"),
+				mock.AnythingOfType("entity.Infobase"),
+				mock.AnythingOfType("entity.Credentials"),
+				mock.AnythingOfType("entity.Credentials"),
+				mock.MatchedBy(func(lock entity.SessionsLock) bool {
+					return lock.Code == "123" && lock.ScheduledJobsDeny &&
+						lock.To.Sub(lock.From) == time.Hour && !strings.Contains(lock.Message, "{minutes}")
+				})).
+				Return(tc.disableMockError).
+				Once()
+
+			ctrlPipeMock.On("GetInfobaseLock",
+				mock.MatchedBy(func(ctx context.Context) bool { return true }),
+				mock.AnythingOfType("entity.Cluster"),
+				mock.AnythingOfType("entity.Infobase"),
+				mock.AnythingOfType("entity.Credentials"),
+				mock.AnythingOfType("entity.Credentials")).
+				Return(entity.InfobaseLock{SessionsDeny: true, Code: "123"}, nil).
+				Maybe()
+
+			ctrlPipeMock.On("GetSessions",
+				mock.MatchedBy(func(ctx context.Context) bool { return true }),
+				mock.AnythingOfType("entity.Cluster"),
+				mock.AnythingOfType("entity.Infobase"),
+				mock.AnythingOfType("entity.Credentials")).
+				Return(tc.ss, nil).
+				Maybe()
+
+			if tc.deleteCalls > 0 {
+				ctrlPipeMock.On("DeleteSessions",
+					mock.MatchedBy(func(ctx context.Context) bool { return true }),
+					mock.AnythingOfType("entity.Cluster"),
+					tc.ss,
+					mock.AnythingOfType("entity.Credentials"),
+					tc.opts.ErrorMessage).
+					Return(tc.deleteMockError).
+					Times(tc.deleteCalls)
+			}
+
+			ctrl := usecase.New(ctrlPipeMock, ctrlBackupMock)
+
+			err := ctrl.TerminateSessions(context.Background(), entity.Cluster{ID: "1"}, entity.Infobase{ID: "2"},
+				entity.Credentials{}, entity.Credentials{}, entity.LockPolicy{Code: "123", Window: time.Hour, ScheduledJobsDeny: true}, tc.opts)
+
+			if tc.respError == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.ErrorContains(t, err, tc.respError)
+			}
+		})
+	}
+}
+
+func TestDisableSessions(t *testing.T) {
+	cases := []struct {
+		name          string
+		policy        entity.LockPolicy
+		window        time.Duration
+		message       string // template of lock message expected, one of policy if empty
+		state         entity.InfobaseLock
+		respError     string
+		pipeMockError error
+		infoMockError error
+	}{
+		{
+			name: "Success",
+			policy: entity.LockPolicy{
+				Code:              "123",
+				Message:           "{infobase} closed",
+				Window:            2 * time.Hour,
+				ScheduledJobsDeny: true,
+			},
+			window: 2 * time.Hour,
+			state:  entity.InfobaseLock{SessionsDeny: true, Code: "123"},
+		},
+		{
+			name: "Success default window",
+			policy: entity.LockPolicy{
+				Code:    "123",
+				Message: "{infobase} closed",
+			},
+			window: time.Hour,
+			state:  entity.InfobaseLock{SessionsDeny: true, Code: "123"},
+		},
+		{
+			name: "Success default message",
+			policy: entity.LockPolicy{
+				Code: "123",
+			},
+			window:  time.Hour,
+			message: "База закрыта на создание резервной копии до {to}",
+			state:   entity.InfobaseLock{SessionsDeny: true, Code: "123"},
+		},
+		{
+			name: "Lock not applied",
+			policy: entity.LockPolicy{
+				Code:    "123",
+				Message: "{infobase} closed",
+			},
+			window:    time.Hour,
+			respError: usecase.ErrLockNotApplied.Error(),
+		},
+		{
+			name: "Lock of someone else",
+			policy: entity.LockPolicy{
+				Code:    "123",
+				Message: "{infobase} closed",
+			},
+			window:    time.Hour,
+			state:     entity.InfobaseLock{SessionsDeny: true, Code: "777"},
+			respError: usecase.ErrLockNotApplied.Error(),
+		},
+		{
+			name: "Info error",
+			policy: entity.LockPolicy{
+				Code:    "123",
+				Message: "{infobase} closed",
+			},
+			window:        time.Hour,
+			respError:     ": info error",
+			infoMockError: errors.New("info error"),
+		},
+		{
+			name: "Pipe error",
+			policy: entity.LockPolicy{
+				Code:    "123",
+				Message: "{infobase} closed",
+			},
+			window:        time.Hour,
+			respError:     ": unexpected error",
+			pipeMockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrlPipeMock := mocks.NewCtrlPipe(t)
+			ctrlBackupMock := mocks.NewCtrlBackup(t)
+
+			message := tc.policy.Message
+			if tc.message != "" {
+				message = tc.message
+			}
+
+			ctrlPipeMock.On("DisableSessions",
+				mock.MatchedBy(func(ctx context.Context) bool { return true }),
+				mock.AnythingOfType("entity.Cluster"),
+				mock.AnythingOfType("entity.Infobase"),
+				mock.AnythingOfType("entity.Credentials"),
+				mock.AnythingOfType("entity.Credentials"),
+				mock.MatchedBy(func(lock entity.SessionsLock) bool {
+					return lock.Code == tc.policy.Code && lock.Message == lock.Render(message, "buh", lock.From) &&
+						lock.ScheduledJobsDeny == tc.policy.ScheduledJobsDeny && lock.To.Sub(lock.From) == tc.window
+				})).
+				Return(tc.pipeMockError).
+				Once()
+
+			ctrlPipeMock.On("GetInfobaseLock",
+				mock.MatchedBy(func(ctx context.Context) bool { return true }),
+				mock.AnythingOfType("entity.Cluster"),
+				mock.AnythingOfType("entity.Infobase"),
+				mock.AnythingOfType("entity.Credentials"),
+				mock.AnythingOfType("entity.Credentials")).
+				Return(tc.state, tc.infoMockError).
+				Maybe()
+
+			ctrl := usecase.New(ctrlPipeMock, ctrlBackupMock)
+
+			err := ctrl.DisableSessions(context.Background(), entity.Cluster{ID: "1"}, entity.Infobase{ID: "2", Name: "buh"},
+				entity.Credentials{}, entity.Credentials{}, tc.policy)
+
+			if tc.respError == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.ErrorContains(t, err, tc.respError)
+			}
+		})
+	}
+}
+
+func TestDrain(t *testing.T) {
+	stubborn := []entity.Session{
+		{
+			ID:       "1",
+			UserName: "ivanov",
+			Host:     "pc-1",
+			AppID:    "1CV8C",
+		},
+	}
+
+	conns := []entity.Connection{
+		{
+			ID:    "2",
+			Host:  "pc-2",
+			AppID: "COMConnection",
+		},
+	}
+
+	errDenied := errors.New("access denied")
+
+	cases := []struct {
+		name           string
+		opts           entity.DrainOptions
+		ss             [][]entity.Session
+		cs             [][]entity.Connection
+		respError      string
+		deleteSessions int
+		deleteConns    int
+		deleteError    error
+		pipeMockError  error
+	}{
+		{
+			name: "Success empty",
+			opts: entity.DrainOptions{Timeout: time.Second, Interval: time.Millisecond},
+			ss:   [][]entity.Session{{}},
+			cs:   [][]entity.Connection{{}},
+		},
+		{
+			name:           "Success after termination",
+			opts:           entity.DrainOptions{Timeout: time.Second, Interval: time.Millisecond, Message: "bye"},
+			ss:             [][]entity.Session{stubborn, {}},
+			cs:             [][]entity.Connection{conns, {}},
+			deleteSessions: 1,
+			deleteConns:    1,
+		},
+		{
+			name:           "Stragglers",
+			opts:           entity.DrainOptions{Timeout: 0, Interval: time.Millisecond},
+			ss:             [][]entity.Session{stubborn, stubborn},
+			cs:             [][]entity.Connection{conns, conns},
+			respError:      `session 1 user "ivanov" host "pc-1" app "1CV8C"; connection 2 host "pc-2" app "COMConnection"`,
+			deleteSessions: 1,
+			deleteConns:    1,
+		},
+		{
+			name:           "Negative timeout terminates once",
+			opts:           entity.DrainOptions{Timeout: -time.Minute, Interval: time.Millisecond},
+			ss:             [][]entity.Session{stubborn, {}},
+			cs:             [][]entity.Connection{conns, {}},
+			deleteSessions: 1,
+			deleteConns:    1,
+		},
+		{
+			name:           "Termination error kept",
+			opts:           entity.DrainOptions{Timeout: 0, Interval: time.Millisecond},
+			ss:             [][]entity.Session{stubborn, stubborn},
+			cs:             [][]entity.Connection{conns, conns},
+			respError:      "last error: access denied",
+			deleteSessions: 1,
+			deleteConns:    1,
+			deleteError:    errDenied,
+		},
+		{
+			name:          "Pipe error",
+			opts:          entity.DrainOptions{Timeout: time.Second, Interval: time.Millisecond},
+			ss:            [][]entity.Session{{}},
+			respError:     ": unexpected error",
+			pipeMockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrlPipeMock := mocks.NewCtrlPipe(t)
+			ctrlBackupMock := mocks.NewCtrlBackup(t)
+
+			for i := range tc.ss {
+				ctrlPipeMock.On("GetSessions",
+					mock.MatchedBy(func(ctx context.Context) bool { return true }),
+					mock.AnythingOfType("entity.Cluster"),
+					mock.AnythingOfType("entity.Infobase"),
+					mock.AnythingOfType("entity.Credentials")).
+					Return(tc.ss[i], tc.pipeMockError).
+					Once()
+			}
+
+			for i := range tc.cs {
+				ctrlPipeMock.On("GetConnections",
+					mock.MatchedBy(func(ctx context.Context) bool { return true }),
+					mock.AnythingOfType("entity.Cluster"),
+					mock.AnythingOfType("entity.Infobase"),
+					mock.AnythingOfType("entity.Credentials")).
+					Return(tc.cs[i], nil).
+					Once()
+			}
+
+			if tc.deleteSessions > 0 {
+				ctrlPipeMock.On("DeleteSessions",
+					mock.MatchedBy(func(ctx context.Context) bool { return true }),
+					mock.AnythingOfType("entity.Cluster"),
+					stubborn,
+					mock.AnythingOfType("entity.Credentials"),
+					tc.opts.Message).
+					Return(tc.deleteError).
+					Times(tc.deleteSessions)
+			}
+
+			if tc.deleteConns > 0 {
+				ctrlPipeMock.On("DeleteConnections",
+					mock.MatchedBy(func(ctx context.Context) bool { return true }),
+					mock.AnythingOfType("entity.Cluster"),
+					conns,
+					mock.AnythingOfType("entity.Credentials")).
+					Return(tc.deleteError).
+					Times(tc.deleteConns)
+			}
+
+			ctrl := usecase.New(ctrlPipeMock, ctrlBackupMock)
+
+			err := ctrl.Drain(context.Background(), entity.Cluster{ID: "1"}, entity.Infobase{ID: "2"}, entity.Credentials{}, tc.opts)
+
+			if tc.respError == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.ErrorContains(t, err, tc.respError)
+			}
+
+			if tc.pipeMockError == nil && tc.respError != "" {
+				var de *usecase.DrainError
+
+				require.ErrorIs(t, err, usecase.ErrDrainIncomplete)
+				require.ErrorAs(t, err, &de)
+				require.Equal(t, stubborn, de.Sessions)
+				require.Equal(t, conns, de.Connections)
+			}
+
+			if tc.deleteError != nil {
+				require.ErrorIs(t, err, tc.deleteError)
+			}
+		})
+	}
+}
+
+func TestPruneBackups(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	names := []string{
+		now.Format("02_01_2006_15_04_05") + "_Buh.dt",
+		now.Add(-24*time.Hour).Format("02_01_2006_15_04_05") + "_Buh.dt",
+		now.Add(-48*time.Hour).Format("02_01_2006_15_04_05") + "_buh.dt",
+		now.Add(-72*time.Hour).Format("02_01_2006_15_04_05") + "_Zup.dt",
+		"notes_Buh.dt",
+	}
+
+	for _, name := range names {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o600))
+	}
+
+	uc := usecase.New(mocks.NewCtrlPipe(t), mocks.NewCtrlBackup(t))
+
+	removed, err := uc.PruneBackups(dir, "buh", entity.RetentionPolicy{})
+	require.NoError(t, err)
+	require.Empty(t, removed)
+
+	removed, err = uc.PruneBackups(dir, "buh", entity.RetentionPolicy{Keep: 2})
+	require.NoError(t, err)
+	require.Len(t, removed, 1)
+	require.Equal(t, filepath.Join(dir, names[2]), removed[0].Path)
+
+	removed, err = uc.PruneBackups(dir, "BUH", entity.RetentionPolicy{MaxAge: time.Hour})
+	require.NoError(t, err)
+	require.Len(t, removed, 1)
+	require.Equal(t, filepath.Join(dir, names[1]), removed[0].Path)
+
+	left, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, left, 3)
+
+	_, err = uc.PruneBackups(filepath.Join(dir, "absent"), "buh", entity.RetentionPolicy{Keep: 1})
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
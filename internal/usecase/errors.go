@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+)
+
+var (
+	ErrDrainIncomplete = errors.New("sessions remain in infobase")
+	ErrLockNotApplied  = errors.New("sessions are not denied in infobase after lock")
+	ErrClusterNotFound = errors.New("cluster not found")
+)
+
+// Failures of cluster administration, reported by rac or RAS. CtrlPipe implementations return
+// *ClusterError matching one of them by errors.Is when reason of failure is known.
+var (
+	ErrAuthFailed         = errors.New("authentication failed")
+	ErrInfobaseNotFound   = errors.New("infobase not found")
+	ErrClusterUnreachable = errors.New("cluster is unreachable")
+	ErrInsufficientRights = errors.New("insufficient rights")
+
+	// ErrSessionNotFound, ErrConnectionNotFound - object to terminate is gone, e.g. terminated already
+	ErrSessionNotFound    = errors.New("session not found")
+	ErrConnectionNotFound = errors.New("connection not found")
+
+	// ErrAgentAuth, ErrClusterAuth - authentication failures of central server and cluster administrators
+	ErrAgentAuth   error = &kindError{"central server administrator is not authenticated", ErrAuthFailed}
+	ErrClusterAuth error = &kindError{"cluster administrator is not authenticated", ErrAuthFailed}
+)
+
+// kindError - sentinel error being special case of another one -.
+type kindError struct {
+	msg    string
+	parent error
+}
+
+func (e *kindError) Error() string {
+	return e.msg
+}
+
+func (e *kindError) Unwrap() error {
+	return e.parent
+}
+
+// ClusterError - failure reported by rac or RAS with its reason, errors.Is matches Kind and Err -.
+type ClusterError struct {
+	Kind    error  // one of sentinel errors above, nil if reason is unknown
+	Message string // reason as reported, e.g. error output of rac
+	Code    int    // exit code of rac, 0 if not applicable
+	Err     error
+}
+
+func (e *ClusterError) Error() string {
+	if e.Kind == nil {
+		return e.Err.Error()
+	}
+
+	return fmt.Sprintf("%s: %s", e.Kind, e.Err)
+}
+
+func (e *ClusterError) Unwrap() []error {
+	if e.Kind == nil {
+		return []error{e.Err}
+	}
+
+	return []error{e.Kind, e.Err}
+}
+
+// _messages - known rac and RAS messages by failure, lowercased, first match wins.
+var _messages = []struct {
+	kind  error
+	parts []string
+}{
+	{ErrAgentAuth, []string{"администратор центрального сервера не аутентифицирован", "central server administrator is not authenticated"}},
+	{ErrClusterAuth, []string{"администратор кластера не аутентифицирован", "cluster administrator is not authenticated"}},
+	{ErrAuthFailed, []string{"не аутентифицирован", "not authenticated", "идентификация пользователя не выполнена",
+		"user authentication failed", "неправильное имя или пароль", "invalid user name or password"}},
+	{ErrInsufficientRights, []string{"недостаточно прав", "insufficient rights", "insufficient user rights"}},
+	{ErrInfobaseNotFound, []string{"информационная база не найдена", "информационная база с указанным идентификатором не найдена",
+		"infobase not found", "infobase is not found"}},
+	{ErrSessionNotFound, []string{"сеанс с указанным идентификатором не найден", "сеанс не найден",
+		"session not found", "session is not found"}},
+	{ErrConnectionNotFound, []string{"соединение с указанным идентификатором не найдено", "соединение не найдено",
+		"connection not found", "connection is not found"}},
+	{ErrClusterUnreachable, []string{"connection refused", "connection reset", "timed out", "timeout", "broken pipe",
+		"отказано в подключении", "подключение не установлено", "разорвал существующее подключение",
+		"превышено время ожидания", "сервер не обнаружен", "server not found"}},
+}
+
+// ClassifyMessage - sentinel error of known rac or RAS failure message, nil if it is unknown.
+func ClassifyMessage(msg string) error {
+	msg = strings.ToLower(msg)
+
+	for _, m := range _messages {
+		for _, part := range m.parts {
+			if strings.Contains(msg, part) {
+				return m.kind
+			}
+		}
+	}
+
+	return nil
+}
+
+// DrainError - sessions and connections which refused to die -.
+type DrainError struct {
+	Sessions    []entity.Session
+	Connections []entity.Connection
+
+	// Err - last error of termination, if any
+	Err error
+}
+
+func (e *DrainError) Error() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s: %d session(s), %d connection(s)", ErrDrainIncomplete, len(e.Sessions), len(e.Connections))
+
+	for i := range e.Sessions {
+		s := &e.Sessions[i]
+		fmt.Fprintf(&b, "; session %s user %q host %q app %q", s.ID, s.UserName, s.Host, s.AppID)
+	}
+
+	for i := range e.Connections {
+		c := &e.Connections[i]
+		fmt.Fprintf(&b, "; connection %s host %q app %q", c.ID, c.Host, c.AppID)
+	}
+
+	if e.Err != nil {
+		fmt.Fprintf(&b, "; last error: %s", e.Err)
+	}
+
+	return b.String()
+}
+
+// Unwrap - drain is incomplete and last error of termination tells why, e.g. lost rights or RAS.
+func (e *DrainError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{ErrDrainIncomplete}
+	}
+
+	return []error{ErrDrainIncomplete, e.Err}
+}
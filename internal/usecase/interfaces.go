@@ -12,32 +12,51 @@ import (
 type (
 	// Ctrl -.
 	Ctrl interface {
-		ClusterByName(ctx context.Context, clusterName string) (entity.Cluster, error)
+		Clusters(ctx context.Context, agentCred entity.Credentials) ([]entity.Cluster, error)
+		ClusterByName(ctx context.Context, clusterName string, agentCred entity.Credentials) (entity.Cluster, error)
+		Infobases(ctx context.Context, cluster entity.Cluster, clusterCred entity.Credentials) ([]entity.Infobase, error)
 		InfobaseByName(ctx context.Context, cluster entity.Cluster, infobaseName string, clusterCred entity.Credentials) (entity.Infobase, error)
+		InfobaseInfo(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials) (entity.InfobaseInfo, error)
+
+		LockState(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials) (entity.InfobaseLock, error)
+		RestoreLock(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials, state entity.InfobaseLock) error
 
 		Sessions(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials) ([]entity.Session, error)
-		DisableSessions(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials, code string) error
-		EnableSessions(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials, code string) error
-		DeleteSessions(ctx context.Context, cluster entity.Cluster, sessions []entity.Session, clusterCred entity.Credentials) error
+		DisableSessions(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials, policy entity.LockPolicy) error
+		DeleteSessions(ctx context.Context, cluster entity.Cluster, sessions []entity.Session, clusterCred entity.Credentials, message string) error
+		TerminateSessions(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials, policy entity.LockPolicy, opts entity.GracefulTermination) error
 
 		Connections(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials) ([]entity.Connection, error)
 		DeleteConnections(ctx context.Context, cluster entity.Cluster, connections []entity.Connection, clusterCred entity.Credentials) error
 
+		Processes(ctx context.Context, cluster entity.Cluster, clusterCred entity.Credentials) ([]entity.Process, error)
+		Servers(ctx context.Context, cluster entity.Cluster, clusterCred entity.Credentials) ([]entity.Server, error)
+		Managers(ctx context.Context, cluster entity.Cluster, clusterCred entity.Credentials) ([]entity.Manager, error)
+
+		Drain(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, opts entity.DrainOptions) error
+
 		RunBackup(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, infobaseCred entity.Credentials, lockCode string, outputPath string) (string, error)
+		RunRestore(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, infobaseCred entity.Credentials, lockCode string, dumpPath string) error
+		PruneBackups(outputPath string, infobase string, policy entity.RetentionPolicy) ([]entity.Backup, error)
 	}
 
 	// CtrlPipe -.
 	CtrlPipe interface {
-		GetClusters(ctx context.Context) ([]entity.Cluster, error)
+		GetClusters(ctx context.Context, agentCred entity.Credentials) ([]entity.Cluster, error)
 		GetInfobases(ctx context.Context, cluster entity.Cluster, clusterCred entity.Credentials) ([]entity.Infobase, error)
 		GetSessions(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials) ([]entity.Session, error)
 		GetConnections(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials) ([]entity.Connection, error)
+		GetProcesses(ctx context.Context, cluster entity.Cluster, clusterCred entity.Credentials) ([]entity.Process, error)
+		GetServers(ctx context.Context, cluster entity.Cluster, clusterCred entity.Credentials) ([]entity.Server, error)
+		GetManagers(ctx context.Context, cluster entity.Cluster, clusterCred entity.Credentials) ([]entity.Manager, error)
+		GetInfobaseInfo(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials) (entity.InfobaseInfo, error)
+		GetInfobaseLock(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials) (entity.InfobaseLock, error)
 
-		DisableSessions(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials, code string) error
-		EnableSessions(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials, code string) error
+		DisableSessions(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials, lock entity.SessionsLock) error
+		RestoreLock(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials, state entity.InfobaseLock) error
 
-		DeleteSession(ctx context.Context, cluster entity.Cluster, session entity.Session, clusterCred entity.Credentials) error
-		DeleteSessions(ctx context.Context, cluster entity.Cluster, sessions []entity.Session, clusterCred entity.Credentials) error
+		DeleteSession(ctx context.Context, cluster entity.Cluster, session entity.Session, clusterCred entity.Credentials, message string) error
+		DeleteSessions(ctx context.Context, cluster entity.Cluster, sessions []entity.Session, clusterCred entity.Credentials, message string) error
 
 		DeleteConnection(ctx context.Context, cluster entity.Cluster, connection entity.Connection, clusterCred entity.Credentials) error
 		DeleteConnections(ctx context.Context, cluster entity.Cluster, connections []entity.Connection, clusterCred entity.Credentials) error
@@ -46,5 +65,6 @@ type (
 	// CtrlBackup -.
 	CtrlBackup interface {
 		RunBackup(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, infobaseCred entity.Credentials, lockCode string, outputPath string) error
+		RunRestore(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, infobaseCred entity.Credentials, lockCode string, dumpPath string) error
 	}
 )
@@ -14,23 +14,49 @@ type Ctrl struct {
 	mock.Mock
 }
 
-// ClusterByName provides a mock function with given fields: ctx, clusterName
-func (_m *Ctrl) ClusterByName(ctx context.Context, clusterName string) (entity.Cluster, error) {
-	ret := _m.Called(ctx, clusterName)
+// ClusterByName provides a mock function with given fields: ctx, clusterName, agentCred
+func (_m *Ctrl) ClusterByName(ctx context.Context, clusterName string, agentCred entity.Credentials) (entity.Cluster, error) {
+	ret := _m.Called(ctx, clusterName, agentCred)
 
 	var r0 entity.Cluster
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (entity.Cluster, error)); ok {
-		return rf(ctx, clusterName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.Credentials) (entity.Cluster, error)); ok {
+		return rf(ctx, clusterName, agentCred)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) entity.Cluster); ok {
-		r0 = rf(ctx, clusterName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.Credentials) entity.Cluster); ok {
+		r0 = rf(ctx, clusterName, agentCred)
 	} else {
 		r0 = ret.Get(0).(entity.Cluster)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, clusterName)
+	if rf, ok := ret.Get(1).(func(context.Context, string, entity.Credentials) error); ok {
+		r1 = rf(ctx, clusterName, agentCred)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Clusters provides a mock function with given fields: ctx, agentCred
+func (_m *Ctrl) Clusters(ctx context.Context, agentCred entity.Credentials) ([]entity.Cluster, error) {
+	ret := _m.Called(ctx, agentCred)
+
+	var r0 []entity.Cluster
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Credentials) ([]entity.Cluster, error)); ok {
+		return rf(ctx, agentCred)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Credentials) []entity.Cluster); ok {
+		r0 = rf(ctx, agentCred)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Cluster)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Credentials) error); ok {
+		r1 = rf(ctx, agentCred)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -78,13 +104,13 @@ func (_m *Ctrl) DeleteConnections(ctx context.Context, cluster entity.Cluster, c
 	return r0
 }
 
-// DeleteSessions provides a mock function with given fields: ctx, cluster, sessions, clusterCred
-func (_m *Ctrl) DeleteSessions(ctx context.Context, cluster entity.Cluster, sessions []entity.Session, clusterCred entity.Credentials) error {
-	ret := _m.Called(ctx, cluster, sessions, clusterCred)
+// DeleteSessions provides a mock function with given fields: ctx, cluster, sessions, clusterCred, message
+func (_m *Ctrl) DeleteSessions(ctx context.Context, cluster entity.Cluster, sessions []entity.Session, clusterCred entity.Credentials, message string) error {
+	ret := _m.Called(ctx, cluster, sessions, clusterCred, message)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, []entity.Session, entity.Credentials) error); ok {
-		r0 = rf(ctx, cluster, sessions, clusterCred)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, []entity.Session, entity.Credentials, string) error); ok {
+		r0 = rf(ctx, cluster, sessions, clusterCred, message)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -92,13 +118,13 @@ func (_m *Ctrl) DeleteSessions(ctx context.Context, cluster entity.Cluster, sess
 	return r0
 }
 
-// DisableSessions provides a mock function with given fields: ctx, cluster, infobase, clusterCred, infobaseCred, code
-func (_m *Ctrl) DisableSessions(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials, code string) error {
-	ret := _m.Called(ctx, cluster, infobase, clusterCred, infobaseCred, code)
+// DisableSessions provides a mock function with given fields: ctx, cluster, infobase, clusterCred, infobaseCred, policy
+func (_m *Ctrl) DisableSessions(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials, policy entity.LockPolicy) error {
+	ret := _m.Called(ctx, cluster, infobase, clusterCred, infobaseCred, policy)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Infobase, entity.Credentials, entity.Credentials, string) error); ok {
-		r0 = rf(ctx, cluster, infobase, clusterCred, infobaseCred, code)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Infobase, entity.Credentials, entity.Credentials, entity.LockPolicy) error); ok {
+		r0 = rf(ctx, cluster, infobase, clusterCred, infobaseCred, policy)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -106,13 +132,13 @@ func (_m *Ctrl) DisableSessions(ctx context.Context, cluster entity.Cluster, inf
 	return r0
 }
 
-// EnableSessions provides a mock function with given fields: ctx, cluster, infobase, clusterCred, infobaseCred, code
-func (_m *Ctrl) EnableSessions(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials, code string) error {
-	ret := _m.Called(ctx, cluster, infobase, clusterCred, infobaseCred, code)
+// Drain provides a mock function with given fields: ctx, cluster, infobase, clusterCred, opts
+func (_m *Ctrl) Drain(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, opts entity.DrainOptions) error {
+	ret := _m.Called(ctx, cluster, infobase, clusterCred, opts)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Infobase, entity.Credentials, entity.Credentials, string) error); ok {
-		r0 = rf(ctx, cluster, infobase, clusterCred, infobaseCred, code)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Infobase, entity.Credentials, entity.DrainOptions) error); ok {
+		r0 = rf(ctx, cluster, infobase, clusterCred, opts)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -144,6 +170,172 @@ func (_m *Ctrl) InfobaseByName(ctx context.Context, cluster entity.Cluster, info
 	return r0, r1
 }
 
+// InfobaseInfo provides a mock function with given fields: ctx, cluster, infobase, clusterCred, infobaseCred
+func (_m *Ctrl) InfobaseInfo(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials) (entity.InfobaseInfo, error) {
+	ret := _m.Called(ctx, cluster, infobase, clusterCred, infobaseCred)
+
+	var r0 entity.InfobaseInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Infobase, entity.Credentials, entity.Credentials) (entity.InfobaseInfo, error)); ok {
+		return rf(ctx, cluster, infobase, clusterCred, infobaseCred)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Infobase, entity.Credentials, entity.Credentials) entity.InfobaseInfo); ok {
+		r0 = rf(ctx, cluster, infobase, clusterCred, infobaseCred)
+	} else {
+		r0 = ret.Get(0).(entity.InfobaseInfo)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Cluster, entity.Infobase, entity.Credentials, entity.Credentials) error); ok {
+		r1 = rf(ctx, cluster, infobase, clusterCred, infobaseCred)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Infobases provides a mock function with given fields: ctx, cluster, clusterCred
+func (_m *Ctrl) Infobases(ctx context.Context, cluster entity.Cluster, clusterCred entity.Credentials) ([]entity.Infobase, error) {
+	ret := _m.Called(ctx, cluster, clusterCred)
+
+	var r0 []entity.Infobase
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Credentials) ([]entity.Infobase, error)); ok {
+		return rf(ctx, cluster, clusterCred)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Credentials) []entity.Infobase); ok {
+		r0 = rf(ctx, cluster, clusterCred)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Infobase)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Cluster, entity.Credentials) error); ok {
+		r1 = rf(ctx, cluster, clusterCred)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LockState provides a mock function with given fields: ctx, cluster, infobase, clusterCred, infobaseCred
+func (_m *Ctrl) LockState(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials) (entity.InfobaseLock, error) {
+	ret := _m.Called(ctx, cluster, infobase, clusterCred, infobaseCred)
+
+	var r0 entity.InfobaseLock
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Infobase, entity.Credentials, entity.Credentials) (entity.InfobaseLock, error)); ok {
+		return rf(ctx, cluster, infobase, clusterCred, infobaseCred)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Infobase, entity.Credentials, entity.Credentials) entity.InfobaseLock); ok {
+		r0 = rf(ctx, cluster, infobase, clusterCred, infobaseCred)
+	} else {
+		r0 = ret.Get(0).(entity.InfobaseLock)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Cluster, entity.Infobase, entity.Credentials, entity.Credentials) error); ok {
+		r1 = rf(ctx, cluster, infobase, clusterCred, infobaseCred)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Managers provides a mock function with given fields: ctx, cluster, clusterCred
+func (_m *Ctrl) Managers(ctx context.Context, cluster entity.Cluster, clusterCred entity.Credentials) ([]entity.Manager, error) {
+	ret := _m.Called(ctx, cluster, clusterCred)
+
+	var r0 []entity.Manager
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Credentials) ([]entity.Manager, error)); ok {
+		return rf(ctx, cluster, clusterCred)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Credentials) []entity.Manager); ok {
+		r0 = rf(ctx, cluster, clusterCred)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Manager)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Cluster, entity.Credentials) error); ok {
+		r1 = rf(ctx, cluster, clusterCred)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Processes provides a mock function with given fields: ctx, cluster, clusterCred
+func (_m *Ctrl) Processes(ctx context.Context, cluster entity.Cluster, clusterCred entity.Credentials) ([]entity.Process, error) {
+	ret := _m.Called(ctx, cluster, clusterCred)
+
+	var r0 []entity.Process
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Credentials) ([]entity.Process, error)); ok {
+		return rf(ctx, cluster, clusterCred)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Credentials) []entity.Process); ok {
+		r0 = rf(ctx, cluster, clusterCred)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Process)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Cluster, entity.Credentials) error); ok {
+		r1 = rf(ctx, cluster, clusterCred)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PruneBackups provides a mock function with given fields: outputPath, infobase, policy
+func (_m *Ctrl) PruneBackups(outputPath string, infobase string, policy entity.RetentionPolicy) ([]entity.Backup, error) {
+	ret := _m.Called(outputPath, infobase, policy)
+
+	var r0 []entity.Backup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, entity.RetentionPolicy) ([]entity.Backup, error)); ok {
+		return rf(outputPath, infobase, policy)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, entity.RetentionPolicy) []entity.Backup); ok {
+		r0 = rf(outputPath, infobase, policy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Backup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, entity.RetentionPolicy) error); ok {
+		r1 = rf(outputPath, infobase, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreLock provides a mock function with given fields: ctx, cluster, infobase, clusterCred, infobaseCred, state
+func (_m *Ctrl) RestoreLock(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials, state entity.InfobaseLock) error {
+	ret := _m.Called(ctx, cluster, infobase, clusterCred, infobaseCred, state)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Infobase, entity.Credentials, entity.Credentials, entity.InfobaseLock) error); ok {
+		r0 = rf(ctx, cluster, infobase, clusterCred, infobaseCred, state)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RunBackup provides a mock function with given fields: ctx, cluster, infobase, infobaseCred, lockCode, outputPath
 func (_m *Ctrl) RunBackup(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, infobaseCred entity.Credentials, lockCode string, outputPath string) (string, error) {
 	ret := _m.Called(ctx, cluster, infobase, infobaseCred, lockCode, outputPath)
@@ -168,6 +360,46 @@ func (_m *Ctrl) RunBackup(ctx context.Context, cluster entity.Cluster, infobase
 	return r0, r1
 }
 
+// RunRestore provides a mock function with given fields: ctx, cluster, infobase, infobaseCred, lockCode, dumpPath
+func (_m *Ctrl) RunRestore(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, infobaseCred entity.Credentials, lockCode string, dumpPath string) error {
+	ret := _m.Called(ctx, cluster, infobase, infobaseCred, lockCode, dumpPath)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Infobase, entity.Credentials, string, string) error); ok {
+		r0 = rf(ctx, cluster, infobase, infobaseCred, lockCode, dumpPath)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Servers provides a mock function with given fields: ctx, cluster, clusterCred
+func (_m *Ctrl) Servers(ctx context.Context, cluster entity.Cluster, clusterCred entity.Credentials) ([]entity.Server, error) {
+	ret := _m.Called(ctx, cluster, clusterCred)
+
+	var r0 []entity.Server
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Credentials) ([]entity.Server, error)); ok {
+		return rf(ctx, cluster, clusterCred)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Credentials) []entity.Server); ok {
+		r0 = rf(ctx, cluster, clusterCred)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Server)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Cluster, entity.Credentials) error); ok {
+		r1 = rf(ctx, cluster, clusterCred)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Sessions provides a mock function with given fields: ctx, cluster, infobase, clusterCred
 func (_m *Ctrl) Sessions(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials) ([]entity.Session, error) {
 	ret := _m.Called(ctx, cluster, infobase, clusterCred)
@@ -194,6 +426,20 @@ func (_m *Ctrl) Sessions(ctx context.Context, cluster entity.Cluster, infobase e
 	return r0, r1
 }
 
+// TerminateSessions provides a mock function with given fields: ctx, cluster, infobase, clusterCred, infobaseCred, policy, opts
+func (_m *Ctrl) TerminateSessions(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials, policy entity.LockPolicy, opts entity.GracefulTermination) error {
+	ret := _m.Called(ctx, cluster, infobase, clusterCred, infobaseCred, policy, opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Infobase, entity.Credentials, entity.Credentials, entity.LockPolicy, entity.GracefulTermination) error); ok {
+		r0 = rf(ctx, cluster, infobase, clusterCred, infobaseCred, policy, opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewCtrl creates a new instance of Ctrl. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCtrl(t interface {
@@ -28,6 +28,20 @@ func (_m *CtrlBackup) RunBackup(ctx context.Context, cluster entity.Cluster, inf
 	return r0
 }
 
+// RunRestore provides a mock function with given fields: ctx, cluster, infobase, infobaseCred, lockCode, dumpPath
+func (_m *CtrlBackup) RunRestore(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, infobaseCred entity.Credentials, lockCode string, dumpPath string) error {
+	ret := _m.Called(ctx, cluster, infobase, infobaseCred, lockCode, dumpPath)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Infobase, entity.Credentials, string, string) error); ok {
+		r0 = rf(ctx, cluster, infobase, infobaseCred, lockCode, dumpPath)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewCtrlBackup creates a new instance of CtrlBackup. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCtrlBackup(t interface {
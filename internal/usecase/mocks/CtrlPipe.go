@@ -42,13 +42,13 @@ func (_m *CtrlPipe) DeleteConnections(ctx context.Context, cluster entity.Cluste
 	return r0
 }
 
-// DeleteSession provides a mock function with given fields: ctx, cluster, session, clusterCred
-func (_m *CtrlPipe) DeleteSession(ctx context.Context, cluster entity.Cluster, session entity.Session, clusterCred entity.Credentials) error {
-	ret := _m.Called(ctx, cluster, session, clusterCred)
+// DeleteSession provides a mock function with given fields: ctx, cluster, session, clusterCred, message
+func (_m *CtrlPipe) DeleteSession(ctx context.Context, cluster entity.Cluster, session entity.Session, clusterCred entity.Credentials, message string) error {
+	ret := _m.Called(ctx, cluster, session, clusterCred, message)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Session, entity.Credentials) error); ok {
-		r0 = rf(ctx, cluster, session, clusterCred)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Session, entity.Credentials, string) error); ok {
+		r0 = rf(ctx, cluster, session, clusterCred, message)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -56,13 +56,13 @@ func (_m *CtrlPipe) DeleteSession(ctx context.Context, cluster entity.Cluster, s
 	return r0
 }
 
-// DeleteSessions provides a mock function with given fields: ctx, cluster, sessions, clusterCred
-func (_m *CtrlPipe) DeleteSessions(ctx context.Context, cluster entity.Cluster, sessions []entity.Session, clusterCred entity.Credentials) error {
-	ret := _m.Called(ctx, cluster, sessions, clusterCred)
+// DeleteSessions provides a mock function with given fields: ctx, cluster, sessions, clusterCred, message
+func (_m *CtrlPipe) DeleteSessions(ctx context.Context, cluster entity.Cluster, sessions []entity.Session, clusterCred entity.Credentials, message string) error {
+	ret := _m.Called(ctx, cluster, sessions, clusterCred, message)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, []entity.Session, entity.Credentials) error); ok {
-		r0 = rf(ctx, cluster, sessions, clusterCred)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, []entity.Session, entity.Credentials, string) error); ok {
+		r0 = rf(ctx, cluster, sessions, clusterCred, message)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -70,13 +70,13 @@ func (_m *CtrlPipe) DeleteSessions(ctx context.Context, cluster entity.Cluster,
 	return r0
 }
 
-// DisableSessions provides a mock function with given fields: ctx, cluster, infobase, clusterCred, infobaseCred, code
-func (_m *CtrlPipe) DisableSessions(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials, code string) error {
-	ret := _m.Called(ctx, cluster, infobase, clusterCred, infobaseCred, code)
+// DisableSessions provides a mock function with given fields: ctx, cluster, infobase, clusterCred, infobaseCred, lock
+func (_m *CtrlPipe) DisableSessions(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials, lock entity.SessionsLock) error {
+	ret := _m.Called(ctx, cluster, infobase, clusterCred, infobaseCred, lock)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Infobase, entity.Credentials, entity.Credentials, string) error); ok {
-		r0 = rf(ctx, cluster, infobase, clusterCred, infobaseCred, code)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Infobase, entity.Credentials, entity.Credentials, entity.SessionsLock) error); ok {
+		r0 = rf(ctx, cluster, infobase, clusterCred, infobaseCred, lock)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -84,39 +84,25 @@ func (_m *CtrlPipe) DisableSessions(ctx context.Context, cluster entity.Cluster,
 	return r0
 }
 
-// EnableSessions provides a mock function with given fields: ctx, cluster, infobase, clusterCred, infobaseCred, code
-func (_m *CtrlPipe) EnableSessions(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials, code string) error {
-	ret := _m.Called(ctx, cluster, infobase, clusterCred, infobaseCred, code)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Infobase, entity.Credentials, entity.Credentials, string) error); ok {
-		r0 = rf(ctx, cluster, infobase, clusterCred, infobaseCred, code)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// GetClusters provides a mock function with given fields: ctx
-func (_m *CtrlPipe) GetClusters(ctx context.Context) ([]entity.Cluster, error) {
-	ret := _m.Called(ctx)
+// GetClusters provides a mock function with given fields: ctx, agentCred
+func (_m *CtrlPipe) GetClusters(ctx context.Context, agentCred entity.Credentials) ([]entity.Cluster, error) {
+	ret := _m.Called(ctx, agentCred)
 
 	var r0 []entity.Cluster
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]entity.Cluster, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Credentials) ([]entity.Cluster, error)); ok {
+		return rf(ctx, agentCred)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []entity.Cluster); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Credentials) []entity.Cluster); ok {
+		r0 = rf(ctx, agentCred)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.Cluster)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Credentials) error); ok {
+		r1 = rf(ctx, agentCred)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -150,6 +136,54 @@ func (_m *CtrlPipe) GetConnections(ctx context.Context, cluster entity.Cluster,
 	return r0, r1
 }
 
+// GetInfobaseInfo provides a mock function with given fields: ctx, cluster, infobase, clusterCred, infobaseCred
+func (_m *CtrlPipe) GetInfobaseInfo(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials) (entity.InfobaseInfo, error) {
+	ret := _m.Called(ctx, cluster, infobase, clusterCred, infobaseCred)
+
+	var r0 entity.InfobaseInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Infobase, entity.Credentials, entity.Credentials) (entity.InfobaseInfo, error)); ok {
+		return rf(ctx, cluster, infobase, clusterCred, infobaseCred)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Infobase, entity.Credentials, entity.Credentials) entity.InfobaseInfo); ok {
+		r0 = rf(ctx, cluster, infobase, clusterCred, infobaseCred)
+	} else {
+		r0 = ret.Get(0).(entity.InfobaseInfo)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Cluster, entity.Infobase, entity.Credentials, entity.Credentials) error); ok {
+		r1 = rf(ctx, cluster, infobase, clusterCred, infobaseCred)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInfobaseLock provides a mock function with given fields: ctx, cluster, infobase, clusterCred, infobaseCred
+func (_m *CtrlPipe) GetInfobaseLock(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials) (entity.InfobaseLock, error) {
+	ret := _m.Called(ctx, cluster, infobase, clusterCred, infobaseCred)
+
+	var r0 entity.InfobaseLock
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Infobase, entity.Credentials, entity.Credentials) (entity.InfobaseLock, error)); ok {
+		return rf(ctx, cluster, infobase, clusterCred, infobaseCred)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Infobase, entity.Credentials, entity.Credentials) entity.InfobaseLock); ok {
+		r0 = rf(ctx, cluster, infobase, clusterCred, infobaseCred)
+	} else {
+		r0 = ret.Get(0).(entity.InfobaseLock)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Cluster, entity.Infobase, entity.Credentials, entity.Credentials) error); ok {
+		r1 = rf(ctx, cluster, infobase, clusterCred, infobaseCred)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetInfobases provides a mock function with given fields: ctx, cluster, clusterCred
 func (_m *CtrlPipe) GetInfobases(ctx context.Context, cluster entity.Cluster, clusterCred entity.Credentials) ([]entity.Infobase, error) {
 	ret := _m.Called(ctx, cluster, clusterCred)
@@ -176,6 +210,84 @@ func (_m *CtrlPipe) GetInfobases(ctx context.Context, cluster entity.Cluster, cl
 	return r0, r1
 }
 
+// GetManagers provides a mock function with given fields: ctx, cluster, clusterCred
+func (_m *CtrlPipe) GetManagers(ctx context.Context, cluster entity.Cluster, clusterCred entity.Credentials) ([]entity.Manager, error) {
+	ret := _m.Called(ctx, cluster, clusterCred)
+
+	var r0 []entity.Manager
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Credentials) ([]entity.Manager, error)); ok {
+		return rf(ctx, cluster, clusterCred)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Credentials) []entity.Manager); ok {
+		r0 = rf(ctx, cluster, clusterCred)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Manager)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Cluster, entity.Credentials) error); ok {
+		r1 = rf(ctx, cluster, clusterCred)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProcesses provides a mock function with given fields: ctx, cluster, clusterCred
+func (_m *CtrlPipe) GetProcesses(ctx context.Context, cluster entity.Cluster, clusterCred entity.Credentials) ([]entity.Process, error) {
+	ret := _m.Called(ctx, cluster, clusterCred)
+
+	var r0 []entity.Process
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Credentials) ([]entity.Process, error)); ok {
+		return rf(ctx, cluster, clusterCred)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Credentials) []entity.Process); ok {
+		r0 = rf(ctx, cluster, clusterCred)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Process)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Cluster, entity.Credentials) error); ok {
+		r1 = rf(ctx, cluster, clusterCred)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetServers provides a mock function with given fields: ctx, cluster, clusterCred
+func (_m *CtrlPipe) GetServers(ctx context.Context, cluster entity.Cluster, clusterCred entity.Credentials) ([]entity.Server, error) {
+	ret := _m.Called(ctx, cluster, clusterCred)
+
+	var r0 []entity.Server
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Credentials) ([]entity.Server, error)); ok {
+		return rf(ctx, cluster, clusterCred)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Credentials) []entity.Server); ok {
+		r0 = rf(ctx, cluster, clusterCred)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Server)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Cluster, entity.Credentials) error); ok {
+		r1 = rf(ctx, cluster, clusterCred)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSessions provides a mock function with given fields: ctx, cluster, infobase, clusterCred
 func (_m *CtrlPipe) GetSessions(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials) ([]entity.Session, error) {
 	ret := _m.Called(ctx, cluster, infobase, clusterCred)
@@ -202,6 +314,20 @@ func (_m *CtrlPipe) GetSessions(ctx context.Context, cluster entity.Cluster, inf
 	return r0, r1
 }
 
+// RestoreLock provides a mock function with given fields: ctx, cluster, infobase, clusterCred, infobaseCred, state
+func (_m *CtrlPipe) RestoreLock(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials, state entity.InfobaseLock) error {
+	ret := _m.Called(ctx, cluster, infobase, clusterCred, infobaseCred, state)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Cluster, entity.Infobase, entity.Credentials, entity.Credentials, entity.InfobaseLock) error); ok {
+		r0 = rf(ctx, cluster, infobase, clusterCred, infobaseCred, state)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewCtrlPipe creates a new instance of CtrlPipe. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCtrlPipe(t interface {
@@ -5,11 +5,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"sync"
-	"time"
 
 	"github.com/antonmisa/1cctl_cli/internal/entity"
 	uc "github.com/antonmisa/1cctl_cli/internal/usecase"
+	"github.com/antonmisa/1cctl_cli/pkg/logger"
 	"github.com/antonmisa/1cctl_cli/pkg/pipe"
 	"golang.org/x/sync/errgroup"
 )
@@ -17,26 +16,30 @@ import (
 const (
 	initialDataSize int = 10
 
-	initialPropertiesSizeSmall int = 15
-	initialPropertiesSizeBig   int = 60
-
-	defaultBlockTime time.Duration = 60
+	initialPropertiesSizeBig int = 60
 
 	initialBlockLimitSize int = 50
 
-	formatDate string = "01-02-2006 15:04:05"
+	// formatDate - same as rac prints, so saved lock bounds are restored as is
+	formatDate string = "2006-01-02T15:04:05"
 )
 
 var (
 	ErrInfobaseIsEmpty   = errors.New("infobase is empty")
 	ErrSessionIsEmpty    = errors.New("session is empty")
 	ErrConnectionIsEmpty = errors.New("connection is empty")
+	ErrNotFound          = errors.New("key not found")
+	ErrAgentAuth         = uc.ErrAgentAuth
+	ErrClusterAuth       = uc.ErrClusterAuth
 )
 
 // CtrlPipe -.
 type CtrlPipe struct {
 	pipe              pipe.Piper
 	clusterConnection string
+
+	retry map[string]entity.RetryPolicy
+	l     logger.Interface
 }
 
 var _ uc.CtrlPipe = (*CtrlPipe)(nil)
@@ -51,116 +54,20 @@ func New(p pipe.Piper, cc string) *CtrlPipe {
 	return ctrl
 }
 
-// GetClusters -.
-func (r *CtrlPipe) GetClusters(ctx context.Context) ([]entity.Cluster, error) {
+// GetClusters - agent credentials are required by central servers with administrators.
+func (r *CtrlPipe) GetClusters(ctx context.Context, agentCred entity.Credentials) ([]entity.Cluster, error) {
 	args := []string{r.clusterConnection, "cluster", "list"}
 
-	cmd, stdout, err := r.pipe.Run(ctx, args...)
-	if err != nil {
-		return nil, fmt.Errorf("ctrlpipe - getclusters - r.pipe.Run: %w", err)
+	if agentCred != (entity.Credentials{}) {
+		args = append(args, []string{"--agent-user", agentCred.Name, "--agent-pwd", agentCred.Pwd}...)
 	}
 
-	defer cmd.Cancel()
-	defer stdout.Close()
-
-	var wg sync.WaitGroup
-
-	datas := make(chan entity.Cluster)
-	errs := make(chan error)
-	quit := make(chan struct{})
-
-	defer close(quit)
-	defer close(errs)
-	defer close(datas)
-
-	if err = cmd.Start(); err != nil {
-		//break all
-		return nil, fmt.Errorf("ctrlpipe - getclusters - cmd.Start: %w", err)
+	rv, err := readAll[entity.Cluster](ctx, r.call(OpRead), args)
+	if err != nil {
+		return nil, fmt.Errorf("ctrlpipe - getclusters - readAll: %w", err)
 	}
 
-	wg.Add(1)
-
-	go func() {
-		defer wg.Done()
-
-		rawStrings := make([]string, 0, initialPropertiesSizeSmall)
-
-		scanner := bufio.NewScanner(stdout)
-		for scanner.Scan() {
-			line := scanner.Text()
-
-			if line == "" {
-				var data entity.Cluster
-
-				err = entity.Unmarshal(rawStrings, &data)
-
-				if err != nil {
-					errs <- fmt.Errorf("ctrlpipe - getclusters - decoder.Unmarshal: %w", err)
-
-					return
-				}
-
-				datas <- data
-
-				rawStrings = rawStrings[:0]
-				continue
-			}
-
-			rawStrings = append(rawStrings, line)
-		}
-
-		if len(rawStrings) > 0 {
-			var data entity.Cluster
-
-			err = entity.Unmarshal(rawStrings, &data)
-			if err != nil {
-				errs <- fmt.Errorf("ctrlpipe - getclusters - decoder.Unmarshal: %w", err)
-
-				return
-			}
-
-			datas <- data
-		}
-
-		if err := scanner.Err(); err != nil {
-			errs <- fmt.Errorf("ctrlpipe - getclusters - scanner.Err: %w", err)
-		}
-	}()
-
-	wg.Add(1)
-
-	go func() {
-		defer wg.Done()
-
-		if err = cmd.Wait(); err != nil {
-			errs <- fmt.Errorf("ctrlpipe - getclusters - cmd.Wait: %w", err)
-		}
-		quit <- struct{}{}
-	}()
-
-	var errg error
-
-	rv := make([]entity.Cluster, 0, initialDataSize)
-
-	num := 0
-
-	for {
-		select {
-		case data := <-datas:
-			rv = append(rv, data)
-			num++
-		case errg = <-errs:
-			cmd.Cancel()
-		case <-quit:
-			wg.Wait()
-
-			if errg != nil {
-				return nil, errg
-			}
-
-			return rv[:num:num], errg
-		}
-	}
+	return rv, nil
 }
 
 // GetInfobases -.
@@ -171,112 +78,12 @@ func (r *CtrlPipe) GetInfobases(ctx context.Context, cluster entity.Cluster, clu
 		args = append(args, []string{"--cluster-user", clusterCred.Name, "--cluster-pwd", clusterCred.Pwd}...)
 	}
 
-	cmd, stdout, err := r.pipe.Run(ctx, args...)
+	rv, err := readAll[entity.Infobase](ctx, r.call(OpRead), args)
 	if err != nil {
-		return nil, fmt.Errorf("ctrlpipe - getinfobases - error opening pipe: %w", err)
+		return nil, fmt.Errorf("ctrlpipe - getinfobases - readAll: %w", err)
 	}
 
-	defer cmd.Cancel()
-	defer stdout.Close()
-
-	var wg sync.WaitGroup
-
-	datas := make(chan entity.Infobase)
-	errs := make(chan error)
-	quit := make(chan struct{})
-
-	defer close(quit)
-	defer close(errs)
-	defer close(datas)
-
-	if err = cmd.Start(); err != nil {
-		//break all
-		return nil, fmt.Errorf("ctrlpipe - getinfobases - cmd.Start: %w", err)
-	}
-
-	wg.Add(1)
-
-	go func() {
-		defer wg.Done()
-
-		if err := cmd.Wait(); err != nil {
-			errs <- err
-		}
-		quit <- struct{}{}
-	}()
-
-	wg.Add(1)
-
-	go func() {
-		defer wg.Done()
-
-		rawStrings := make([]string, 0, initialPropertiesSizeSmall)
-
-		scanner := bufio.NewScanner(stdout)
-		for scanner.Scan() {
-			line := scanner.Text()
-
-			if line == "" {
-				var data entity.Infobase
-
-				err = entity.Unmarshal(rawStrings, &data)
-
-				if err != nil {
-					errs <- fmt.Errorf("ctrlpipe - getinfobases - decoder.Unmarshal: %w", err)
-
-					return
-				}
-
-				datas <- data
-
-				rawStrings = rawStrings[:0]
-				continue
-			}
-
-			rawStrings = append(rawStrings, line)
-		}
-
-		if len(rawStrings) > 0 {
-			var data entity.Infobase
-
-			err = entity.Unmarshal(rawStrings, &data)
-			if err != nil {
-				errs <- fmt.Errorf("ctrlpipe - getinfobases - decoder.Unmarshal: %w", err)
-
-				return
-			}
-
-			datas <- data
-		}
-
-		if err := scanner.Err(); err != nil {
-			errs <- fmt.Errorf("ctrlpipe - getinfobases - scanner.Err: %w", err)
-		}
-	}()
-
-	var errg error
-
-	rv := make([]entity.Infobase, 0, initialDataSize)
-
-	num := 0
-
-	for {
-		select {
-		case data := <-datas:
-			rv = append(rv, data)
-			num++
-		case errg = <-errs:
-			cmd.Cancel()
-		case <-quit:
-			wg.Wait()
-
-			if errg != nil {
-				return nil, errg
-			}
-
-			return rv[:num:num], errg
-		}
-	}
+	return rv, nil
 }
 
 // GetSessions -.
@@ -291,115 +98,15 @@ func (r *CtrlPipe) GetSessions(ctx context.Context, cluster entity.Cluster, info
 		args = append(args, []string{"--infobase", infobase.ID}...)
 	}
 
-	cmd, stdout, err := r.pipe.Run(ctx, args...)
+	rv, err := readAll[entity.Session](ctx, r.call(OpRead), args)
 	if err != nil {
-		return nil, fmt.Errorf("ctrlpipe - getsessions - error opening pipe: %w", err)
+		return nil, fmt.Errorf("ctrlpipe - getsessions - readAll: %w", err)
 	}
 
-	defer cmd.Cancel()
-	defer stdout.Close()
-
-	var wg sync.WaitGroup
-
-	datas := make(chan entity.Session)
-	errs := make(chan error)
-	quit := make(chan struct{})
-
-	defer close(quit)
-	defer close(errs)
-	defer close(datas)
-
-	if err = cmd.Start(); err != nil {
-		//break all
-		return nil, fmt.Errorf("ctrlpipe - getsessions - cmd.Start: %w", err)
-	}
-
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-
-		if err := cmd.Wait(); err != nil {
-			errs <- err
-		}
-		quit <- struct{}{}
-	}()
-
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-
-		rawStrings := make([]string, 0, initialPropertiesSizeBig)
-
-		scanner := bufio.NewScanner(stdout)
-		for scanner.Scan() {
-			line := scanner.Text()
-
-			if line == "" {
-				var data entity.Session
-
-				err = entity.Unmarshal(rawStrings, &data)
-
-				if err != nil {
-					errs <- fmt.Errorf("ctrlpipe - getsessions - decoder.Unmarshal: %w", err)
-
-					return
-				}
-
-				datas <- data
-
-				rawStrings = rawStrings[:0]
-				continue
-			}
-
-			rawStrings = append(rawStrings, line)
-		}
-
-		if len(rawStrings) > 0 {
-			var data entity.Session
-
-			err = entity.Unmarshal(rawStrings, &data)
-			if err != nil {
-				errs <- fmt.Errorf("ctrlpipe - getsessions - decoder.Unmarshal: %w", err)
-
-				return
-			}
-
-			datas <- data
-		}
-
-		if err := scanner.Err(); err != nil {
-			errs <- fmt.Errorf("ctrlpipe - getsessions - scanner.Err: %w", err)
-		}
-	}()
-
-	var errg error
-
-	rv := make([]entity.Session, 0, initialDataSize)
-
-	num := 0
-
-	for {
-		select {
-		case data := <-datas:
-			rv = append(rv, data)
-			num++
-		case errg = <-errs:
-			cmd.Cancel()
-		case <-quit:
-			wg.Wait()
-
-			if errg != nil {
-				return nil, errg
-			}
-
-			return rv[:num:num], errg
-		}
-	}
+	return rv, nil
 }
 
-func (r *CtrlPipe) DisableSessions(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials, code string) error {
-	now := time.Now()
-
+func (r *CtrlPipe) DisableSessions(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials, lock entity.SessionsLock) error {
 	if infobase == (entity.Infobase{}) {
 		return fmt.Errorf("ctrlpipe - disablesessions: %w", ErrInfobaseIsEmpty)
 	}
@@ -407,11 +114,11 @@ func (r *CtrlPipe) DisableSessions(ctx context.Context, cluster entity.Cluster,
 	args := []string{r.clusterConnection, "infobase", "update",
 		"--cluster", cluster.ID,
 		"--infobase", infobase.ID,
-		"--denied-from", now.Format(formatDate),
-		"--denied-message", "БАЗА ЗАКРЫТА НА СОЗДАНИЕ РЕЗЕРВНОЙ КОПИИ",
-		"--denied-to", now.Add(defaultBlockTime * time.Minute).Format(formatDate),
-		"--permission-code", code,
-		"--scheduled-jobs-deny", "on",
+		"--denied-from", lockTime(lock.From),
+		"--denied-message", lock.Message,
+		"--denied-to", lockTime(lock.To),
+		"--permission-code", lock.Code,
+		"--scheduled-jobs-deny", onOff(lock.ScheduledJobsDeny),
 		"--sessions-deny", "on"}
 
 	if clusterCred != (entity.Credentials{}) {
@@ -422,62 +129,103 @@ func (r *CtrlPipe) DisableSessions(ctx context.Context, cluster entity.Cluster,
 		args = append(args, []string{"--infobase-user", infobaseCred.Name, "--infobase-pwd", infobaseCred.Pwd}...)
 	}
 
-	cmd, _, err := r.pipe.Run(ctx, args...)
-
-	if err != nil {
-		return fmt.Errorf("ctrlpipe - disablesessions - error opening pipe: %w", err)
+	if err := exec(ctx, r.call(OpUpdate), args); err != nil {
+		return fmt.Errorf("ctrlpipe - disablesessions - exec: %w", err)
 	}
 
-	defer cmd.Cancel()
+	return nil
+}
 
-	var wg sync.WaitGroup
+// GetInfobaseInfo - reads full properties of infobase, infobase credentials are required by rac.
+func (r *CtrlPipe) GetInfobaseInfo(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials) (entity.InfobaseInfo, error) {
+	if infobase == (entity.Infobase{}) {
+		return entity.InfobaseInfo{}, fmt.Errorf("ctrlpipe - getinfobaseinfo: %w", ErrInfobaseIsEmpty)
+	}
 
-	errs := make(chan error)
-	quit := make(chan struct{})
+	args := []string{r.clusterConnection, "infobase", "info",
+		"--cluster", cluster.ID,
+		"--infobase", infobase.ID}
 
-	defer close(quit)
-	defer close(errs)
+	if clusterCred != (entity.Credentials{}) {
+		args = append(args, []string{"--cluster-user", clusterCred.Name, "--cluster-pwd", clusterCred.Pwd}...)
+	}
 
-	if err = cmd.Start(); err != nil {
-		//break all
-		return fmt.Errorf("ctrlpipe - disablesessions - cmd.Start: %w", err)
+	if infobaseCred != (entity.Credentials{}) {
+		args = append(args, []string{"--infobase-user", infobaseCred.Name, "--infobase-pwd", infobaseCred.Pwd}...)
 	}
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
+	// Single record, no need to stream it
+	rawStrings := make([]string, 0, initialPropertiesSizeBig)
+
+	err := r.call(OpRead).do(ctx, args, func(ctx context.Context) error {
+		cmd, stdout, err := r.pipe.Run(ctx, args...)
+		if err != nil {
+			return fmt.Errorf("error opening pipe: %w", err)
+		}
+
+		defer cmd.Cancel()
+		defer stdout.Close()
 
-		if err := cmd.Wait(); err != nil {
-			errs <- err
+		if err = cmd.Start(); err != nil {
+			return fmt.Errorf("cmd.Start: %w", err)
 		}
-		quit <- struct{}{}
-	}()
 
-	var errg error
+		rawStrings = rawStrings[:0]
 
-	for {
-		select {
-		case errg = <-errs:
-			cmd.Cancel()
-		case <-quit:
-			wg.Wait()
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			rawStrings = append(rawStrings, scanner.Text())
+		}
 
-			return errg
+		if err = scanner.Err(); err != nil {
+			return fmt.Errorf("scanner.Err: %w", err)
 		}
+
+		if err = cmd.Wait(); err != nil {
+			return fmt.Errorf("cmd.Wait: %w", racError(err))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entity.InfobaseInfo{}, fmt.Errorf("ctrlpipe - getinfobaseinfo - %w", err)
 	}
+
+	var data entity.InfobaseInfo
+
+	if err = data.UnmarshalRAC(rawStrings); err != nil {
+		return entity.InfobaseInfo{}, fmt.Errorf("ctrlpipe - getinfobaseinfo - data.UnmarshalRAC: %w", err)
+	}
+
+	return data, nil
+}
+
+// GetInfobaseLock - reads current denial settings of infobase.
+func (r *CtrlPipe) GetInfobaseLock(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials) (entity.InfobaseLock, error) {
+	info, err := r.GetInfobaseInfo(ctx, cluster, infobase, clusterCred, infobaseCred)
+	if err != nil {
+		return entity.InfobaseLock{}, fmt.Errorf("ctrlpipe - getinfobaselock - r.GetInfobaseInfo: %w", err)
+	}
+
+	return info.Lock(), nil
 }
 
-func (r *CtrlPipe) EnableSessions(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials, code string) error {
+// RestoreLock - sets denial settings of infobase to state saved by GetInfobaseLock.
+func (r *CtrlPipe) RestoreLock(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials, state entity.InfobaseLock) error {
 	if infobase == (entity.Infobase{}) {
-		return fmt.Errorf("ctrlpipe - enablesessions: %w", ErrInfobaseIsEmpty)
+		return fmt.Errorf("ctrlpipe - restorelock: %w", ErrInfobaseIsEmpty)
 	}
 
 	args := []string{r.clusterConnection, "infobase", "update",
 		"--cluster", cluster.ID,
 		"--infobase", infobase.ID,
-		"--permission-code", code,
-		"--scheduled-jobs-deny", "off",
-		"--sessions-deny", "off"}
+		"--denied-from", lockTime(state.From),
+		"--denied-message", state.Message,
+		"--denied-parameter", state.Parameter,
+		"--denied-to", lockTime(state.To),
+		"--permission-code", state.Code,
+		"--scheduled-jobs-deny", onOff(state.ScheduledJobsDeny),
+		"--sessions-deny", onOff(state.SessionsDeny)}
 
 	if clusterCred != (entity.Credentials{}) {
 		args = append(args, []string{"--cluster-user", clusterCred.Name, "--cluster-pwd", clusterCred.Pwd}...)
@@ -487,52 +235,14 @@ func (r *CtrlPipe) EnableSessions(ctx context.Context, cluster entity.Cluster, i
 		args = append(args, []string{"--infobase-user", infobaseCred.Name, "--infobase-pwd", infobaseCred.Pwd}...)
 	}
 
-	cmd, _, err := r.pipe.Run(ctx, args...)
-
-	if err != nil {
-		return fmt.Errorf("ctrlpipe - enablesessions - error opening pipe: %w", err)
-	}
-
-	defer cmd.Cancel()
-
-	var wg sync.WaitGroup
-
-	errs := make(chan error)
-	quit := make(chan struct{})
-
-	defer close(quit)
-	defer close(errs)
-
-	if err = cmd.Start(); err != nil {
-		//break all
-		return fmt.Errorf("ctrlpipe - enablesessions - cmd.Start: %w", err)
+	if err := exec(ctx, r.call(OpUpdate), args); err != nil {
+		return fmt.Errorf("ctrlpipe - restorelock - exec: %w", err)
 	}
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-
-		if err := cmd.Wait(); err != nil {
-			errs <- err
-		}
-		quit <- struct{}{}
-	}()
-
-	var errg error
-
-	for {
-		select {
-		case errg = <-errs:
-			cmd.Cancel()
-		case <-quit:
-			wg.Wait()
-
-			return errg
-		}
-	}
+	return nil
 }
 
-func (r *CtrlPipe) DeleteSession(ctx context.Context, cluster entity.Cluster, session entity.Session, clusterCred entity.Credentials) error {
+func (r *CtrlPipe) DeleteSession(ctx context.Context, cluster entity.Cluster, session entity.Session, clusterCred entity.Credentials, message string) error {
 	if session == (entity.Session{}) {
 		return fmt.Errorf("ctrlpipe - deletesession: %w", ErrSessionIsEmpty)
 	}
@@ -541,56 +251,22 @@ func (r *CtrlPipe) DeleteSession(ctx context.Context, cluster entity.Cluster, se
 		"--cluster", cluster.ID,
 		"--session", session.ID}
 
-	if clusterCred != (entity.Credentials{}) {
-		args = append(args, []string{"--cluster-user", clusterCred.Name, "--cluster-pwd", clusterCred.Pwd}...)
+	if message != "" {
+		args = append(args, []string{"--error-message", message}...)
 	}
 
-	cmd, _, err := r.pipe.Run(ctx, args...)
-
-	if err != nil {
-		return fmt.Errorf("ctrlpipe - deletesession - error opening pipe: %w", err)
+	if clusterCred != (entity.Credentials{}) {
+		args = append(args, []string{"--cluster-user", clusterCred.Name, "--cluster-pwd", clusterCred.Pwd}...)
 	}
 
-	defer cmd.Cancel()
-
-	var wg sync.WaitGroup
-
-	errs := make(chan error)
-	quit := make(chan struct{})
-
-	defer close(quit)
-	defer close(errs)
-
-	if err = cmd.Start(); err != nil {
-		//break all
-		return fmt.Errorf("ctrlpipe - deletesession - cmd.Start: %w", err)
+	if err := exec(ctx, r.call(OpTerminate), args); err != nil {
+		return fmt.Errorf("ctrlpipe - deletesession - exec: %w", err)
 	}
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-
-		if err := cmd.Wait(); err != nil {
-			errs <- err
-		}
-		quit <- struct{}{}
-	}()
-
-	var errg error
-
-	for {
-		select {
-		case errg = <-errs:
-			cmd.Cancel()
-		case <-quit:
-			wg.Wait()
-
-			return errg
-		}
-	}
+	return nil
 }
 
-func (r *CtrlPipe) DeleteSessions(ctx context.Context, cluster entity.Cluster, sessions []entity.Session, clusterCred entity.Credentials) error {
+func (r *CtrlPipe) DeleteSessions(ctx context.Context, cluster entity.Cluster, sessions []entity.Session, clusterCred entity.Credentials, message string) error {
 	g, ctx := errgroup.WithContext(ctx)
 
 	g.SetLimit(initialBlockLimitSize)
@@ -599,7 +275,7 @@ func (r *CtrlPipe) DeleteSessions(ctx context.Context, cluster entity.Cluster, s
 		i := i
 
 		g.Go(func() error {
-			return r.DeleteSession(ctx, cluster, sessions[i], clusterCred)
+			return r.DeleteSession(ctx, cluster, sessions[i], clusterCred, message)
 		})
 	}
 
@@ -618,110 +294,12 @@ func (r *CtrlPipe) GetConnections(ctx context.Context, cluster entity.Cluster, i
 		args = append(args, []string{"--infobase", infobase.ID}...)
 	}
 
-	cmd, stdout, err := r.pipe.Run(ctx, args...)
+	rv, err := readAll[entity.Connection](ctx, r.call(OpRead), args)
 	if err != nil {
-		return nil, fmt.Errorf("ctrlpipe - getconnections - error opening pipe: %w", err)
+		return nil, fmt.Errorf("ctrlpipe - getconnections - readAll: %w", err)
 	}
 
-	defer cmd.Cancel()
-	defer stdout.Close()
-
-	var wg sync.WaitGroup
-
-	datas := make(chan entity.Connection)
-	errs := make(chan error)
-	quit := make(chan struct{})
-
-	defer close(quit)
-	defer close(errs)
-	defer close(datas)
-
-	if err = cmd.Start(); err != nil {
-		//break all
-		return nil, fmt.Errorf("ctrlpipe - getconnections - cmd.Start: %w", err)
-	}
-
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-
-		if err := cmd.Wait(); err != nil {
-			errs <- err
-		}
-		quit <- struct{}{}
-	}()
-
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-
-		rawStrings := make([]string, 0, initialPropertiesSizeSmall)
-
-		scanner := bufio.NewScanner(stdout)
-		for scanner.Scan() {
-			line := scanner.Text()
-
-			if line == "" {
-				var data entity.Connection
-
-				err = entity.Unmarshal(rawStrings, &data)
-
-				if err != nil {
-					errs <- fmt.Errorf("ctrlpipe - getconnections - decoder.Unmarshal: %w", err)
-
-					return
-				}
-
-				datas <- data
-
-				rawStrings = rawStrings[:0]
-				continue
-			}
-
-			rawStrings = append(rawStrings, line)
-		}
-
-		if len(rawStrings) > 0 {
-			var data entity.Connection
-
-			err = entity.Unmarshal(rawStrings, &data)
-			if err != nil {
-				errs <- fmt.Errorf("ctrlpipe - getconnections - decoder.Unmarshal: %w", err)
-
-				return
-			}
-
-			datas <- data
-		}
-
-		if err := scanner.Err(); err != nil {
-			errs <- fmt.Errorf("ctrlpipe - getconnections - scanner.Err: %w", err)
-		}
-	}()
-
-	var errg error
-
-	rv := make([]entity.Connection, 0, initialDataSize)
-
-	num := 0
-
-	for {
-		select {
-		case data := <-datas:
-			rv = append(rv, data)
-			num++
-		case errg = <-errs:
-			cmd.Cancel()
-		case <-quit:
-			wg.Wait()
-
-			if errg != nil {
-				return nil, errg
-			}
-
-			return rv[:num:num], errg
-		}
-	}
+	return rv, nil
 }
 
 func (r *CtrlPipe) DeleteConnection(ctx context.Context, cluster entity.Cluster, connection entity.Connection, clusterCred entity.Credentials) error {
@@ -731,55 +309,17 @@ func (r *CtrlPipe) DeleteConnection(ctx context.Context, cluster entity.Cluster,
 
 	args := []string{r.clusterConnection, "connection", "disconnect",
 		"--cluster", cluster.ID,
-		"--conection", connection.ID}
+		"--connection", connection.ID}
 
 	if clusterCred != (entity.Credentials{}) {
 		args = append(args, []string{"--cluster-user", clusterCred.Name, "--cluster-pwd", clusterCred.Pwd}...)
 	}
 
-	cmd, _, err := r.pipe.Run(ctx, args...)
-
-	if err != nil {
-		return fmt.Errorf("ctrlpipe - deleteconnection - error opening pipe: %w", err)
-	}
-
-	defer cmd.Cancel()
-
-	var wg sync.WaitGroup
-
-	errs := make(chan error)
-	quit := make(chan struct{})
-
-	defer close(quit)
-	defer close(errs)
-
-	if err = cmd.Start(); err != nil {
-		//break all
-		return fmt.Errorf("ctrlpipe - deleteconnection - cmd.Start: %w", err)
+	if err := exec(ctx, r.call(OpTerminate), args); err != nil {
+		return fmt.Errorf("ctrlpipe - deleteconnection - exec: %w", err)
 	}
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-
-		if err := cmd.Wait(); err != nil {
-			errs <- err
-		}
-		quit <- struct{}{}
-	}()
-
-	var errg error
-
-	for {
-		select {
-		case errg = <-errs:
-			cmd.Cancel()
-		case <-quit:
-			wg.Wait()
-
-			return errg
-		}
-	}
+	return nil
 }
 
 func (r *CtrlPipe) DeleteConnections(ctx context.Context, cluster entity.Cluster, connections []entity.Connection, clusterCred entity.Credentials) error {
@@ -798,3 +338,51 @@ func (r *CtrlPipe) DeleteConnections(ctx context.Context, cluster entity.Cluster
 	err := g.Wait()
 	return err
 }
+
+// GetProcesses -.
+func (r *CtrlPipe) GetProcesses(ctx context.Context, cluster entity.Cluster, clusterCred entity.Credentials) ([]entity.Process, error) {
+	args := []string{r.clusterConnection, "process", "list", "--cluster", cluster.ID}
+
+	if clusterCred != (entity.Credentials{}) {
+		args = append(args, []string{"--cluster-user", clusterCred.Name, "--cluster-pwd", clusterCred.Pwd}...)
+	}
+
+	rv, err := readAll[entity.Process](ctx, r.call(OpRead), args)
+	if err != nil {
+		return nil, fmt.Errorf("ctrlpipe - getprocesses - readAll: %w", err)
+	}
+
+	return rv, nil
+}
+
+// GetServers -.
+func (r *CtrlPipe) GetServers(ctx context.Context, cluster entity.Cluster, clusterCred entity.Credentials) ([]entity.Server, error) {
+	args := []string{r.clusterConnection, "server", "list", "--cluster", cluster.ID}
+
+	if clusterCred != (entity.Credentials{}) {
+		args = append(args, []string{"--cluster-user", clusterCred.Name, "--cluster-pwd", clusterCred.Pwd}...)
+	}
+
+	rv, err := readAll[entity.Server](ctx, r.call(OpRead), args)
+	if err != nil {
+		return nil, fmt.Errorf("ctrlpipe - getservers - readAll: %w", err)
+	}
+
+	return rv, nil
+}
+
+// GetManagers -.
+func (r *CtrlPipe) GetManagers(ctx context.Context, cluster entity.Cluster, clusterCred entity.Credentials) ([]entity.Manager, error) {
+	args := []string{r.clusterConnection, "manager", "list", "--cluster", cluster.ID}
+
+	if clusterCred != (entity.Credentials{}) {
+		args = append(args, []string{"--cluster-user", clusterCred.Name, "--cluster-pwd", clusterCred.Pwd}...)
+	}
+
+	rv, err := readAll[entity.Manager](ctx, r.call(OpRead), args)
+	if err != nil {
+		return nil, fmt.Errorf("ctrlpipe - getmanagers - readAll: %w", err)
+	}
+
+	return rv, nil
+}
@@ -2,12 +2,15 @@
 package pipe
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/antonmisa/1cctl_cli/internal/entity"
+	"github.com/antonmisa/1cctl_cli/pkg/pipe"
 	"github.com/antonmisa/1cctl_cli/pkg/pipe/mocks"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -20,136 +23,124 @@ type FakeReadCloser struct {
 	enable bool
 }
 
-func NewFakeConnection3() *FakeReadCloser {
-	text := `connection : 1111-3434-5656 
-				infobase: 3333-4444
-				process: 1-1-1-1
-				host: test-ic
-				application: 1cv8
-
-				connection : 2222-3434-5656 
-				infobase: 3333-4444
-				process: 1-1-1-2
-				host: test-ic-1
-				application: 1cv8
-				
-				connection : 3333-3434-5656 
-				infobase: 1111-4444
-				process: 1-2-1-2
-				host: test-ic-2
-				application: 1cv8`
+// newFakeOutput - rac output of list, records are printed by entity.MarshalList as rac prints them.
+func newFakeOutput[T any](list []T) *FakeReadCloser {
+	var buf bytes.Buffer
+
+	if err := entity.MarshalList(&buf, list); err != nil {
+		panic(err)
+	}
 
 	return &FakeReadCloser{
-		body: []byte(text),
+		body: buf.Bytes(),
 	}
 }
 
-func NewFakeConnection2() *FakeReadCloser {
-	text := `connection : 1111-3434-5656 
-				infobase: 3333-4444
-				process: 1-1-1-1
-				host: test-ic
-				application: 1cv8
-
-				connection : 2222-3434-5656 
-				infobase: 3333-4444
-				process: 1-1-1-2
-				host: test-ic-1
-				application: 1cv8`
+func NewFakeConnection3() *FakeReadCloser {
+	return newFakeOutput([]entity.Connection{
+		{ID: "1111-3434-5656", InfobaseID: "3333-4444", ProcessID: "1-1-1-1", Host: "test-ic", AppID: "1cv8"},
+		{ID: "2222-3434-5656", InfobaseID: "3333-4444", ProcessID: "1-1-1-2", Host: "test-ic-1", AppID: "1cv8"},
+		{ID: "3333-3434-5656", InfobaseID: "1111-4444", ProcessID: "1-2-1-2", Host: "test-ic-2", AppID: "1cv8"},
+	})
+}
 
-	return &FakeReadCloser{
-		body: []byte(text),
-	}
+func NewFakeConnection2() *FakeReadCloser {
+	return newFakeOutput([]entity.Connection{
+		{ID: "1111-3434-5656", InfobaseID: "3333-4444", ProcessID: "1-1-1-1", Host: "test-ic", AppID: "1cv8"},
+		{ID: "2222-3434-5656", InfobaseID: "3333-4444", ProcessID: "1-1-1-2", Host: "test-ic-1", AppID: "1cv8"},
+	})
 }
 
 func NewFakeSession3() *FakeReadCloser {
-	text := `session : 1111-3434-5656 
-				infobase: 3333-4444
-				connection: 3-4-5-6
-				process: 1-1-1-1
-				user-name: тестовый пользователь
-				host: test-ic
-				app-id: 1cv8
-
-				session : 2222-3434-5656 
-				infobase: 3333-4444
-				connection: 3-4-5-7
-				process: 1-1-1-2
-				user-name: тестовый пользователь 1
-				host: test-ic-1
-				app-id: 1cv8
-				
-				session : 3333-3434-5656 
-				infobase: 1111-4444
-				connection: 1-4-5-7
-				process: 1-2-1-2
-				user-name: неизвестный пользователь
-				host: test-ic-2
-				app-id: 1cv8`
-
-	return &FakeReadCloser{
-		body: []byte(text),
-	}
+	return newFakeOutput([]entity.Session{
+		{
+			ID: "1111-3434-5656", InfobaseID: "3333-4444", ConnectionID: "3-4-5-6", ProcessID: "1-1-1-1",
+			UserName: "тестовый пользователь", Host: "test-ic", AppID: "1cv8",
+		},
+		{
+			ID: "2222-3434-5656", InfobaseID: "3333-4444", ConnectionID: "3-4-5-7", ProcessID: "1-1-1-2",
+			UserName: "тестовый пользователь 1", Host: "test-ic-1", AppID: "1cv8",
+		},
+		{
+			ID: "3333-3434-5656", InfobaseID: "1111-4444", ConnectionID: "1-4-5-7", ProcessID: "1-2-1-2",
+			UserName: "неизвестный пользователь", Host: "test-ic-2", AppID: "1cv8",
+		},
+	})
 }
 
 func NewFakeSession2() *FakeReadCloser {
-	text := `session : 1111-3434-5656 
-				infobase: 3333-4444
-				connection: 3-4-5-6
-				process: 1-1-1-1
-				user-name: тестовый пользователь
-				host: test-ic
-				app-id: 1cv8
-
-				session : 2222-3434-5656 
-				infobase: 3333-4444
-				connection: 3-4-5-7
-				process: 1-1-1-2
-				user-name: тестовый пользователь 1
-				host: test-ic-1
-				app-id: 1cv8`
-
-	return &FakeReadCloser{
-		body: []byte(text),
-	}
+	return newFakeOutput([]entity.Session{
+		{
+			ID: "1111-3434-5656", InfobaseID: "3333-4444", ConnectionID: "3-4-5-6", ProcessID: "1-1-1-1",
+			UserName: "тестовый пользователь", Host: "test-ic", AppID: "1cv8",
+		},
+		{
+			ID: "2222-3434-5656", InfobaseID: "3333-4444", ConnectionID: "3-4-5-7", ProcessID: "1-1-1-2",
+			UserName: "тестовый пользователь 1", Host: "test-ic-1", AppID: "1cv8",
+		},
+	})
 }
 
 func NewFakeSession0() *FakeReadCloser {
-	text := ``
-
-	return &FakeReadCloser{
-		body: []byte(text),
-	}
+	return newFakeOutput([]entity.Session{})
 }
 
 func NewFakeInfobase() *FakeReadCloser {
-	text := `infobase : 1212-3434-5656 
-			 name: test
-			 descr:
+	return newFakeOutput([]entity.Infobase{
+		{ID: "1212-3434-5656", Name: "test"},
+		{ID: "1111-2222-3333", Name: "test_ib", Desc: "test desc"},
+	})
+}
 
-			 infobase : 1111-2222-3333 
-			  name: test_ib
-			 descr: "test desc" `
+func NewFakeInfobaseInfo() *FakeReadCloser {
+	return newFakeOutput([]entity.InfobaseInfo{{
+		ID:                  "3333-4444",
+		Name:                "test",
+		DBMS:                "MSSQLServer",
+		DBServer:            "sql-01",
+		DBName:              "test_db",
+		DBUser:              "sa",
+		Locale:              "ru_RU",
+		DateOffset:          2000,
+		LicenseDistribution: "allow",
+		SessionsDeny:        true,
+		DeniedFrom:          time.Date(2023, time.August, 8, 22, 0, 0, 0, entity.ServerLocation),
+		DeniedTo:            time.Date(2023, time.August, 9, 6, 0, 0, 0, entity.ServerLocation),
+		DeniedMessage:       "planned maintenance",
+		PermissionCode:      "777",
+		ScheduledJobsDeny:   true,
+		ReserveProcesses:    true,
+	}})
+}
 
-	return &FakeReadCloser{
-		body: []byte(text),
-	}
+func NewFakeProcess() *FakeReadCloser {
+	return newFakeOutput([]entity.Process{
+		{
+			ID: "1111-2222", Host: "srv-1c", Port: 1560, PID: 4040, Enabled: true, Running: true,
+			Started: time.Date(2023, time.August, 8, 10, 48, 43, 0, entity.ServerLocation),
+			Use:     "used", AvailPerf: 120, Connections: 12, Memory: 524288,
+		},
+		{ID: "3333-4444", Host: "srv-1c", Port: 1561, PID: 5050, Running: true, AvailPerf: 80, Memory: 1024},
+	})
 }
 
-func NewFakeCluster() *FakeReadCloser {
-	text := `cluster : 1212-3434-5656 
-			 host: localhost 
-			 port: 1234 
-			 name: "test"
+func NewFakeServer() *FakeReadCloser {
+	return newFakeOutput([]entity.Server{
+		{ID: "1111-2222", Host: "srv-1c", Port: 1540, PortRange: "1560:1591", Name: "Central server", Using: "main"},
+	})
+}
 
-			 cluster : 1111-2222-3333 
-			  host: localhost.tnx.ru    
-			 port: 1545 
-			  name: "test cluster" `
+func NewFakeManager() *FakeReadCloser {
+	return newFakeOutput([]entity.Manager{
+		{ID: "1111-2222", PID: 3030, Using: "main", Host: "srv-1c", Port: 1541, Desc: "Main cluster manager"},
+	})
+}
 
-	return &FakeReadCloser{
-		body: []byte(text),
-	}
+func NewFakeCluster() *FakeReadCloser {
+	return newFakeOutput([]entity.Cluster{
+		{ID: "1212-3434-5656", Host: "localhost", Port: "1234", Name: "test"},
+		{ID: "1111-2222-3333", Host: "localhost.tnx.ru", Port: "1545", Name: "test cluster"},
+	})
 }
 
 func (t *FakeReadCloser) SetEnable(v bool) {
@@ -165,13 +156,14 @@ func (t *FakeReadCloser) Read(p []byte) (n int, err error) {
 		return 0, io.EOF
 	}
 
-	t.pos = copy(p, t.body[t.pos:])
+	n = copy(p, t.body[t.pos:])
+	t.pos += n
 
 	if t.pos >= len(t.body) {
-		return t.pos, io.EOF
-	} else {
-		return t.pos, nil
+		return n, io.EOF
 	}
+
+	return n, nil
 }
 
 func (t *FakeReadCloser) Close() error {
@@ -182,11 +174,34 @@ func (t *FakeReadCloser) Close() error {
 
 var _ io.ReadCloser = (*FakeReadCloser)(nil)
 
+// newFakeCommander - command which output stdout between Start and Wait.
+func newFakeCommander(t *testing.T, stdout *FakeReadCloser) *mocks.Commander {
+	comMock := mocks.NewCommander(t)
+
+	comMock.On("Start").
+		Return(nil).
+		Run(func(args mock.Arguments) { stdout.SetEnable(true) }).
+		Maybe()
+
+	comMock.On("Wait").
+		Return(nil).
+		Run(func(args mock.Arguments) { stdout.SetEnable(false) }).
+		Maybe()
+
+	comMock.On("Cancel").
+		Return(nil).
+		Run(func(args mock.Arguments) { stdout.SetEnable(false) }).
+		Maybe()
+
+	return comMock
+}
+
 func TestGetClusters(t *testing.T) {
 	cases := []struct {
 		name              string
 		ctx               context.Context
 		cs                string
+		cred              entity.Credentials
 		stdout            *FakeReadCloser
 		cls               []entity.Cluster
 		respError         string
@@ -222,6 +237,22 @@ func TestGetClusters(t *testing.T) {
 			respError:        ": wait error",
 			comMockWaitError: errors.New("wait error"),
 		},
+		{
+			name: "Error agent auth",
+			ctx:  context.Background(),
+			cs:   "localhost:1545",
+			cred: entity.Credentials{
+				Name: "admin",
+				Pwd:  "bad",
+			},
+			stdout:    NewFakeCluster(),
+			cls:       make([]entity.Cluster, 0),
+			respError: ErrAgentAuth.Error(),
+			comMockWaitError: &pipe.ExitError{
+				Err:    errors.New("exit status 255"),
+				Stderr: "Администратор центрального сервера не аутентифицирован",
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -253,12 +284,16 @@ func TestGetClusters(t *testing.T) {
 				mock.MatchedBy(func(ctx context.Context) bool { return true }),
 				mock.AnythingOfType("string"),
 				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
 				mock.AnythingOfType("string")).
 				Return(comMock, tc.stdout, tc.pipeMockError)
 
 			ctrl := New(pipeMock, tc.cs)
 
-			cls, err := ctrl.GetClusters(tc.ctx)
+			cls, err := ctrl.GetClusters(tc.ctx, tc.cred)
 
 			if err == nil {
 				require.NoError(t, err)
@@ -307,7 +342,7 @@ func TestGetInfobases(t *testing.T) {
 				{
 					ID:   "1111-2222-3333",
 					Name: "test_ib",
-					Desc: "\"test desc\"",
+					Desc: "test desc",
 				},
 			},
 		},
@@ -332,7 +367,7 @@ func TestGetInfobases(t *testing.T) {
 				{
 					ID:   "1111-2222-3333",
 					Name: "test_ib",
-					Desc: "\"test desc\"",
+					Desc: "test desc",
 				},
 			},
 		},
@@ -406,6 +441,160 @@ func TestGetInfobases(t *testing.T) {
 	}
 }
 
+func TestGetProcesses(t *testing.T) {
+	cases := []struct {
+		name          string
+		cred          entity.Credentials
+		stdout        *FakeReadCloser
+		res           []entity.Process
+		respError     string
+		pipeMockError error
+	}{
+		{
+			name: "Success w cred",
+			cred: entity.Credentials{
+				Name: "test",
+				Pwd:  "pwd",
+			},
+			stdout: NewFakeProcess(),
+			res: []entity.Process{
+				{
+					ID:          "1111-2222",
+					Host:        "srv-1c",
+					Port:        1560,
+					PID:         4040,
+					Enabled:     true,
+					Running:     true,
+					Started:     time.Date(2023, time.August, 8, 10, 48, 43, 0, entity.ServerLocation),
+					Use:         "used",
+					AvailPerf:   120,
+					Connections: 12,
+					Memory:      524288,
+				},
+				{
+					ID:        "3333-4444",
+					Host:      "srv-1c",
+					Port:      1561,
+					PID:       5050,
+					Running:   true,
+					AvailPerf: 80,
+					Memory:    1024,
+				},
+			},
+		},
+		{
+			name:          "Error no command",
+			stdout:        NewFakeProcess(),
+			respError:     ": no command",
+			pipeMockError: errors.New("no command"),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			comMock := newFakeCommander(t, tc.stdout)
+
+			pipeMock := mocks.NewPiper(t)
+
+			pipeMock.On("Run",
+				mock.MatchedBy(func(ctx context.Context) bool { return true }),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string")).
+				Return(comMock, tc.stdout, tc.pipeMockError).
+				Maybe()
+
+			ctrl := New(pipeMock, "localhost:1545")
+
+			res, err := ctrl.GetProcesses(context.Background(), entity.Cluster{ID: "1212-3434-5656"}, tc.cred)
+
+			if tc.respError == "" {
+				require.NoError(t, err)
+				require.ElementsMatch(t, tc.res, res)
+			} else {
+				require.Error(t, err)
+				require.ErrorContains(t, err, tc.respError)
+				require.Empty(t, res)
+			}
+		})
+	}
+}
+
+func TestGetServers(t *testing.T) {
+	stdout := NewFakeServer()
+
+	pipeMock := mocks.NewPiper(t)
+
+	pipeMock.On("Run",
+		mock.MatchedBy(func(ctx context.Context) bool { return true }),
+		mock.AnythingOfType("string"),
+		mock.AnythingOfType("string"),
+		mock.AnythingOfType("string"),
+		mock.AnythingOfType("string"),
+		mock.AnythingOfType("string")).
+		Return(newFakeCommander(t, stdout), stdout, nil).
+		Once()
+
+	ctrl := New(pipeMock, "localhost:1545")
+
+	res, err := ctrl.GetServers(context.Background(), entity.Cluster{ID: "1212-3434-5656"}, entity.Credentials{})
+
+	require.NoError(t, err)
+	require.Equal(t, []entity.Server{
+		{
+			ID:        "1111-2222",
+			Host:      "srv-1c",
+			Port:      1540,
+			PortRange: "1560:1591",
+			Name:      "Central server",
+			Using:     "main",
+		},
+	}, res)
+}
+
+func TestGetManagers(t *testing.T) {
+	stdout := NewFakeManager()
+
+	pipeMock := mocks.NewPiper(t)
+
+	pipeMock.On("Run",
+		mock.MatchedBy(func(ctx context.Context) bool { return true }),
+		mock.AnythingOfType("string"),
+		mock.AnythingOfType("string"),
+		mock.AnythingOfType("string"),
+		mock.AnythingOfType("string"),
+		mock.AnythingOfType("string")).
+		Return(newFakeCommander(t, stdout), stdout, nil).
+		Once()
+
+	ctrl := New(pipeMock, "localhost:1545")
+
+	res, err := ctrl.GetManagers(context.Background(), entity.Cluster{ID: "1212-3434-5656"}, entity.Credentials{})
+
+	require.NoError(t, err)
+	require.Equal(t, []entity.Manager{
+		{
+			ID:    "1111-2222",
+			PID:   3030,
+			Using: "main",
+			Host:  "srv-1c",
+			Port:  1541,
+			Desc:  "Main cluster manager",
+		},
+	}, res)
+}
+
 func TestGetSessions(t *testing.T) {
 	cases := []struct {
 		name              string
@@ -811,7 +1000,7 @@ func TestDisableSessions(t *testing.T) {
 
 			ctrl := New(pipeMock, tc.cs)
 
-			err := ctrl.DisableSessions(tc.ctx, tc.cl, tc.ib, tc.clCred, tc.ibCred, tc.code)
+			err := ctrl.DisableSessions(tc.ctx, tc.cl, tc.ib, tc.clCred, tc.ibCred, entity.SessionsLock{Code: tc.code})
 
 			if err == nil {
 				require.NoError(t, err)
@@ -823,7 +1012,110 @@ func TestDisableSessions(t *testing.T) {
 	}
 }
 
-func TestEnableSessions(t *testing.T) {
+func TestGetInfobaseInfo(t *testing.T) {
+	cases := []struct {
+		name          string
+		ib            entity.Infobase
+		stdout        *FakeReadCloser
+		respError     string
+		res           entity.InfobaseInfo
+		pipeMockError error
+	}{
+		{
+			name:      "Error infobase empty",
+			ib:        entity.Infobase{},
+			stdout:    NewFakeInfobaseInfo(),
+			respError: ": infobase is empty",
+		},
+		{
+			name: "Success",
+			ib: entity.Infobase{
+				ID: "3333-4444",
+			},
+			stdout: NewFakeInfobaseInfo(),
+			res: entity.InfobaseInfo{
+				ID:                  "3333-4444",
+				Name:                "test",
+				DBMS:                "MSSQLServer",
+				DBServer:            "sql-01",
+				DBName:              "test_db",
+				DBUser:              "sa",
+				Locale:              "ru_RU",
+				DateOffset:          2000,
+				LicenseDistribution: "allow",
+				SessionsDeny:        true,
+				DeniedFrom:          time.Date(2023, time.August, 8, 22, 0, 0, 0, entity.ServerLocation),
+				DeniedTo:            time.Date(2023, time.August, 9, 6, 0, 0, 0, entity.ServerLocation),
+				DeniedMessage:       "planned maintenance",
+				PermissionCode:      "777",
+				ScheduledJobsDeny:   true,
+				ReserveProcesses:    true,
+			},
+		},
+		{
+			name: "Error no command",
+			ib: entity.Infobase{
+				ID: "12",
+			},
+			stdout:        NewFakeSession0(),
+			respError:     ": no command",
+			pipeMockError: errors.New("no command"),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			comMock := mocks.NewCommander(t)
+
+			comMock.On("Start").
+				Return(nil).
+				Run(func(args mock.Arguments) { tc.stdout.SetEnable(true) }).
+				Maybe()
+
+			comMock.On("Wait").
+				Return(nil).
+				Run(func(args mock.Arguments) { tc.stdout.SetEnable(false) }).
+				Maybe()
+
+			comMock.On("Cancel").
+				Return(nil).
+				Run(func(args mock.Arguments) { tc.stdout.SetEnable(false) }).
+				Maybe()
+
+			pipeMock := mocks.NewPiper(t)
+
+			pipeMock.On("Run",
+				mock.MatchedBy(func(ctx context.Context) bool { return true }),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string")).
+				Return(comMock, tc.stdout, tc.pipeMockError).
+				Maybe()
+
+			ctrl := New(pipeMock, "localhost:1545")
+
+			res, err := ctrl.GetInfobaseInfo(context.Background(), entity.Cluster{ID: "1212-3434-5656"}, tc.ib, entity.Credentials{}, entity.Credentials{})
+
+			if tc.respError == "" {
+				require.NoError(t, err)
+				require.Equal(t, tc.res, res)
+			} else {
+				require.Error(t, err)
+				require.ErrorContains(t, err, tc.respError)
+			}
+		})
+	}
+}
+
+func TestRestoreLock(t *testing.T) {
 	cases := []struct {
 		name              string
 		ctx               context.Context
@@ -832,7 +1124,7 @@ func TestEnableSessions(t *testing.T) {
 		ib                entity.Infobase
 		clCred            entity.Credentials
 		ibCred            entity.Credentials
-		code              string
+		state             entity.InfobaseLock
 		stdout            *FakeReadCloser
 		respError         string
 		pipeMockError     error
@@ -849,7 +1141,7 @@ func TestEnableSessions(t *testing.T) {
 			ib:            entity.Infobase{},
 			clCred:        entity.Credentials{},
 			ibCred:        entity.Credentials{},
-			code:          "12345",
+			state:         entity.InfobaseLock{Code: "12345"},
 			stdout:        NewFakeSession3(),
 			respError:     ": infobase is empty",
 			pipeMockError: ErrInfobaseIsEmpty,
@@ -866,7 +1158,7 @@ func TestEnableSessions(t *testing.T) {
 			},
 			clCred: entity.Credentials{},
 			ibCred: entity.Credentials{},
-			code:   "12345",
+			state:  entity.InfobaseLock{Code: "12345", SessionsDeny: true},
 			stdout: NewFakeSession3(),
 		},
 		{
@@ -885,7 +1177,7 @@ func TestEnableSessions(t *testing.T) {
 				Name: "test",
 				Pwd:  "pwd",
 			},
-			code:          "12345",
+			state:         entity.InfobaseLock{Code: "12345"},
 			stdout:        NewFakeSession3(),
 			respError:     ": infobase is empty",
 			pipeMockError: ErrInfobaseIsEmpty,
@@ -908,7 +1200,7 @@ func TestEnableSessions(t *testing.T) {
 				Name: "test",
 				Pwd:  "pwd",
 			},
-			code:   "12345",
+			state:  entity.InfobaseLock{Code: "12345", SessionsDeny: true},
 			stdout: NewFakeSession2(),
 		},
 		{
@@ -921,7 +1213,7 @@ func TestEnableSessions(t *testing.T) {
 			},
 			clCred:        entity.Credentials{},
 			ibCred:        entity.Credentials{},
-			code:          "12345",
+			state:         entity.InfobaseLock{Code: "12345"},
 			stdout:        NewFakeSession0(),
 			respError:     ": no command",
 			pipeMockError: errors.New("no command"),
@@ -975,13 +1267,21 @@ func TestEnableSessions(t *testing.T) {
 				mock.AnythingOfType("string"),
 				mock.AnythingOfType("string"),
 				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
 				mock.AnythingOfType("string")).
 				Return(comMock, tc.stdout, tc.pipeMockError).
 				Maybe()
 
 			ctrl := New(pipeMock, tc.cs)
 
-			err := ctrl.EnableSessions(tc.ctx, tc.cl, tc.ib, tc.clCred, tc.ibCred, tc.code)
+			err := ctrl.RestoreLock(tc.ctx, tc.cl, tc.ib, tc.clCred, tc.ibCred, tc.state)
 
 			if err == nil {
 				require.NoError(t, err)
@@ -993,6 +1293,163 @@ func TestEnableSessions(t *testing.T) {
 	}
 }
 
+func TestGetInfobaseLock(t *testing.T) {
+	cases := []struct {
+		name              string
+		ctx               context.Context
+		cs                string
+		cl                entity.Cluster
+		ib                entity.Infobase
+		clCred            entity.Credentials
+		ibCred            entity.Credentials
+		stdout            *FakeReadCloser
+		respError         string
+		res               entity.InfobaseLock
+		pipeMockError     error
+		comMockStartError error
+		comMockWaitError  error
+	}{
+		{
+			name: "Error infobase empty",
+			ctx:  context.Background(),
+			cs:   "localhost:1545",
+			cl: entity.Cluster{
+				ID: "1212-3434-5656",
+			},
+			ib:        entity.Infobase{},
+			stdout:    NewFakeInfobaseInfo(),
+			respError: ": infobase is empty",
+		},
+		{
+			name: "Success w cred",
+			ctx:  context.Background(),
+			cs:   "localhost:1545",
+			cl: entity.Cluster{
+				ID: "1212-3434-5656",
+			},
+			ib: entity.Infobase{
+				ID: "3333-4444",
+			},
+			clCred: entity.Credentials{
+				Name: "test",
+				Pwd:  "pwd",
+			},
+			ibCred: entity.Credentials{
+				Name: "test",
+				Pwd:  "pwd",
+			},
+			stdout: NewFakeInfobaseInfo(),
+			res: entity.InfobaseLock{
+				InfobaseID:        "3333-4444",
+				SessionsDeny:      true,
+				From:              time.Date(2023, time.August, 8, 22, 0, 0, 0, entity.ServerLocation),
+				To:                time.Date(2023, time.August, 9, 6, 0, 0, 0, entity.ServerLocation),
+				Message:           "planned maintenance",
+				Code:              "777",
+				ScheduledJobsDeny: true,
+			},
+		},
+		{
+			name: "Error empty output",
+			ctx:  context.Background(),
+			cs:   "localhost:1545",
+			cl: entity.Cluster{
+				ID: "1212-3434-5656",
+			},
+			ib: entity.Infobase{
+				ID: "3333-4444",
+			},
+			stdout:    NewFakeSession0(),
+			respError: entity.ErrNotFound.Error(),
+		},
+		{
+			name: "Error wait",
+			ctx:  context.Background(),
+			cs:   "localhost:1545",
+			cl: entity.Cluster{
+				ID: "1212-3434-5656",
+			},
+			ib: entity.Infobase{
+				ID: "3333-4444",
+			},
+			stdout:           NewFakeInfobaseInfo(),
+			respError:        "cmd.Wait: exit status 1",
+			comMockWaitError: errors.New("exit status 1"),
+		},
+		{
+			name: "Error no command",
+			ctx:  context.Background(),
+			cs:   "localhost:1545",
+			cl:   entity.Cluster{},
+			ib: entity.Infobase{
+				ID: "12",
+			},
+			stdout:        NewFakeSession0(),
+			respError:     ": no command",
+			pipeMockError: errors.New("no command"),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			comMock := mocks.NewCommander(t)
+
+			comMock.On("Start").
+				Return(tc.comMockStartError).
+				Run(func(args mock.Arguments) { tc.stdout.SetEnable(true) }).
+				Maybe()
+
+			comMock.On("Wait").
+				Return(tc.comMockWaitError).
+				Run(func(args mock.Arguments) { tc.stdout.SetEnable(false) }).
+				Maybe()
+
+			comMock.On("Cancel").
+				Return(nil).
+				Run(func(args mock.Arguments) { tc.stdout.SetEnable(false) }).
+				Maybe()
+
+			pipeMock := mocks.NewPiper(t)
+
+			pipeMock.On("Run",
+				mock.MatchedBy(func(ctx context.Context) bool { return true }),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string")).
+				Return(comMock, tc.stdout, tc.pipeMockError).
+				Maybe()
+
+			ctrl := New(pipeMock, tc.cs)
+
+			res, err := ctrl.GetInfobaseLock(tc.ctx, tc.cl, tc.ib, tc.clCred, tc.ibCred)
+
+			if tc.respError == "" {
+				require.NoError(t, err)
+				require.Equal(t, tc.res, res)
+			} else {
+				require.Error(t, err)
+				require.ErrorContains(t, err, tc.respError)
+			}
+		})
+	}
+}
+
 func TestDeleteSession(t *testing.T) {
 	cases := []struct {
 		name              string
@@ -1001,6 +1458,7 @@ func TestDeleteSession(t *testing.T) {
 		cl                entity.Cluster
 		s                 entity.Session
 		clCred            entity.Credentials
+		msg               string
 		stdout            *FakeReadCloser
 		respError         string
 		pipeMockError     error
@@ -1065,6 +1523,23 @@ func TestDeleteSession(t *testing.T) {
 			},
 			stdout: NewFakeSession3(),
 		},
+		{
+			name: "Success w message w cred",
+			ctx:  context.Background(),
+			cs:   "localhost:1545",
+			cl: entity.Cluster{
+				ID: "1212-3434-5656",
+			},
+			s: entity.Session{
+				ID: "3333-4444",
+			},
+			clCred: entity.Credentials{
+				Name: "test",
+				Pwd:  "pwd",
+			},
+			msg:    "session is terminated for backup",
+			stdout: NewFakeSession3(),
+		},
 		{
 			name: "Error no command",
 			ctx:  context.Background(),
@@ -1117,13 +1592,15 @@ func TestDeleteSession(t *testing.T) {
 				mock.AnythingOfType("string"),
 				mock.AnythingOfType("string"),
 				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("string"),
 				mock.AnythingOfType("string")).
 				Return(comMock, tc.stdout, tc.pipeMockError).
 				Maybe()
 
 			ctrl := New(pipeMock, tc.cs)
 
-			err := ctrl.DeleteSession(tc.ctx, tc.cl, tc.s, tc.clCred)
+			err := ctrl.DeleteSession(tc.ctx, tc.cl, tc.s, tc.clCred, tc.msg)
 
 			if err == nil {
 				require.NoError(t, err)
@@ -1273,7 +1750,7 @@ func TestDeleteSessions(t *testing.T) {
 
 			ctrl := New(pipeMock, tc.cs)
 
-			err := ctrl.DeleteSessions(tc.ctx, tc.cl, tc.ss, tc.clCred)
+			err := ctrl.DeleteSessions(tc.ctx, tc.cl, tc.ss, tc.clCred, "")
 
 			if err == nil {
 				require.NoError(t, err)
@@ -1,25 +1,57 @@
 package pipe
 
 import (
-	"strings"
-	"unicode"
+	"errors"
+	"time"
+
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+	uc "github.com/antonmisa/1cctl_cli/internal/usecase"
+	"github.com/antonmisa/1cctl_cli/pkg/pipe"
 )
 
 type Helper struct {
 }
 
+// GetKeyValue - see entity.GetKeyValue.
 func (h Helper) GetKeyValue(line string, delimeter rune) (k, v string, err error) {
-	cleanLine := strings.Map(func(r rune) rune {
-		if unicode.IsPrint(r) {
-			return unicode.ToLower(r)
-		}
+	k, v, err = entity.GetKeyValue(line, delimeter)
+	if err != nil {
+		return "", "", ErrNotFound
+	}
+
+	return k, v, nil
+}
 
-		return -1
-	}, line)
+// onOff - rac representation of boolean flag.
+func onOff(v bool) string {
+	if v {
+		return "on"
+	}
+
+	return "off"
+}
 
-	if pos := strings.IndexRune(cleanLine, delimeter); pos != -1 {
-		return strings.Trim(cleanLine[:pos], " "), strings.Trim(cleanLine[pos+1:], " "), nil
+// lockTime - rac representation of lock bound on wall clock of cluster servers, empty one resets it.
+func lockTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
 	}
 
-	return "", "", ErrNotFound
+	return t.In(entity.ServerLocation).Format(formatDate)
+}
+
+// racError - failure of rac with its error output and exit code, reason is told by known messages.
+func racError(err error) error {
+	var ee *pipe.ExitError
+
+	if !errors.As(err, &ee) {
+		return err
+	}
+
+	return &uc.ClusterError{
+		Kind:    uc.ClassifyMessage(ee.Stderr),
+		Message: ee.Stderr,
+		Code:    ee.Code,
+		Err:     err,
+	}
 }
@@ -2,8 +2,12 @@
 package pipe
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
+	uc "github.com/antonmisa/1cctl_cli/internal/usecase"
+	"github.com/antonmisa/1cctl_cli/pkg/pipe"
 	"github.com/stretchr/testify/require"
 )
 
@@ -79,3 +83,102 @@ func TestGetKeyValue(t *testing.T) {
 		})
 	}
 }
+
+func TestRacError(t *testing.T) {
+	exit := errors.New("exit status 255")
+
+	cases := []struct {
+		name string
+		err  error
+		is   error
+	}{
+		{
+			name: "Agent",
+			err:  &pipe.ExitError{Err: exit, Stderr: "Ошибка операции администрирования\nАдминистратор центрального сервера не аутентифицирован", Code: 255},
+			is:   ErrAgentAuth,
+		},
+		{
+			name: "Agent english",
+			err:  &pipe.ExitError{Err: exit, Stderr: "Central server administrator is not authenticated", Code: 255},
+			is:   ErrAgentAuth,
+		},
+		{
+			name: "Cluster",
+			err:  fmt.Errorf("wrapped: %w", &pipe.ExitError{Err: exit, Stderr: "Администратор кластера не аутентифицирован", Code: 255}),
+			is:   ErrClusterAuth,
+		},
+		{
+			name: "Infobase user",
+			err:  &pipe.ExitError{Err: exit, Stderr: "Идентификация пользователя не выполнена", Code: 255},
+			is:   uc.ErrAuthFailed,
+		},
+		{
+			name: "Rights",
+			err:  &pipe.ExitError{Err: exit, Stderr: "Недостаточно прав пользователя на информационную базу Buh", Code: 255},
+			is:   uc.ErrInsufficientRights,
+		},
+		{
+			name: "Infobase not found",
+			err:  &pipe.ExitError{Err: exit, Stderr: "Информационная база не найдена", Code: 255},
+			is:   uc.ErrInfobaseNotFound,
+		},
+		{
+			name: "Session not found",
+			err:  &pipe.ExitError{Err: exit, Stderr: "Сеанс с указанным идентификатором не найден", Code: 255},
+			is:   uc.ErrSessionNotFound,
+		},
+		{
+			name: "Connection not found",
+			err:  &pipe.ExitError{Err: exit, Stderr: "Connection is not found", Code: 255},
+			is:   uc.ErrConnectionNotFound,
+		},
+		{
+			name: "Server not found",
+			err:  &pipe.ExitError{Err: exit, Stderr: "Ошибка соединения с сервером 1С:Предприятия 8.3: server not found", Code: 255},
+			is:   uc.ErrClusterUnreachable,
+		},
+		{
+			name: "Unreachable",
+			err:  &pipe.ExitError{Err: exit, Stderr: "Ошибка соединения с сервером 1С:Предприятия 8.3: Connection refused", Code: 255},
+			is:   uc.ErrClusterUnreachable,
+		},
+		{
+			name: "Other failure",
+			err:  &pipe.ExitError{Err: exit, Stderr: "Кластер с указанным идентификатором не найден", Code: 255},
+		},
+		{
+			name: "No stderr",
+			err:  exit,
+		},
+	}
+
+	kinds := []error{uc.ErrAuthFailed, uc.ErrInsufficientRights, uc.ErrInfobaseNotFound, uc.ErrClusterUnreachable,
+		uc.ErrSessionNotFound, uc.ErrConnectionNotFound}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := racError(tc.err)
+
+			require.ErrorIs(t, err, exit)
+
+			var ce *uc.ClusterError
+
+			if tc.is == nil {
+				for _, kind := range kinds {
+					require.NotErrorIs(t, err, kind)
+				}
+
+				return
+			}
+
+			require.ErrorIs(t, err, tc.is)
+			require.ErrorAs(t, err, &ce)
+			require.Equal(t, 255, ce.Code)
+			require.NotEmpty(t, ce.Message)
+		})
+	}
+}
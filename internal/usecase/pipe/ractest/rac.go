@@ -0,0 +1,429 @@
+package ractest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+)
+
+const (
+	// _exitError - rac exits with non-zero code and prints reason to stderr on any failure
+	_exitError = 255
+
+	_formatDate = "2006-01-02T15:04:05"
+)
+
+var (
+	ErrUnknownCommand   = errors.New("unknown command")
+	ErrUnknownOption    = errors.New("unknown option")
+	ErrRequiredOption   = errors.New("required option is missing")
+	ErrAgentAuth        = errors.New("central server administrator is not authenticated")
+	ErrClusterAuth      = errors.New("cluster administrator is not authenticated")
+	ErrInfobaseRights   = errors.New("insufficient user rights for infobase")
+	ErrClusterNotFound  = errors.New("cluster not found")
+	ErrInfobaseNotFound = errors.New("infobase not found")
+	ErrSessionNotFound  = errors.New("session not found")
+	ErrConnNotFound     = errors.New("connection not found")
+	ErrInvalidValue     = errors.New("invalid option value")
+)
+
+const (
+	_clusterOptions  = "cluster cluster-user cluster-pwd "
+	_infobaseOptions = "infobase infobase-user infobase-pwd "
+)
+
+// command - rac command and options it accepts, others are rejected.
+type command struct {
+	options string
+	run     func(f *Fixture, o options, out io.Writer) error
+}
+
+var commands = map[string]command{
+	"cluster list": {
+		options: "agent-user agent-pwd",
+		run:     clusterList,
+	},
+	"infobase summary list": {
+		options: _clusterOptions,
+		run:     infobaseSummaryList,
+	},
+	"infobase info": {
+		options: _clusterOptions + _infobaseOptions,
+		run:     infobaseInfo,
+	},
+	"infobase update": {
+		options: _clusterOptions + _infobaseOptions +
+			"denied-from denied-message denied-parameter denied-to permission-code scheduled-jobs-deny sessions-deny",
+		run: infobaseUpdate,
+	},
+	"session list": {
+		options: _clusterOptions + "infobase",
+		run:     sessionList,
+	},
+	"session terminate": {
+		options: _clusterOptions + "session error-message",
+		run:     sessionTerminate,
+	},
+	"connection list": {
+		options: _clusterOptions + "infobase",
+		run:     connectionList,
+	},
+	"connection disconnect": {
+		options: _clusterOptions + "connection",
+		run:     connectionDisconnect,
+	},
+}
+
+// options - values of --name=value or --name value arguments.
+type options map[string]string
+
+// Run - executes rac command against state file, returns exit code.
+func Run(state string, args []string, stdout, stderr io.Writer) int {
+	if err := run(state, args, stdout); err != nil {
+		fmt.Fprintf(stderr, "rac: %s\n", err)
+
+		return _exitError
+	}
+
+	return 0
+}
+
+func run(state string, args []string, out io.Writer) (err error) {
+	unlock, err := lock(state)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	f, err := load(state)
+	if err != nil {
+		return err
+	}
+
+	// calls are saved even if they fail, tests check what was attempted
+	f.Calls = append(f.Calls, args)
+
+	defer func() {
+		if e := save(state, f); e != nil && err == nil {
+			err = e
+		}
+	}()
+
+	name, o, err := parse(args)
+	if err != nil {
+		return err
+	}
+
+	return commands[name].run(&f, o, out)
+}
+
+// parse - splits arguments into command name and options, address of ras is skipped.
+func parse(args []string) (string, options, error) {
+	var words []string
+
+	o := options{}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		if !strings.HasPrefix(arg, "--") {
+			// host:port of ras
+			if len(words) == 0 && strings.Contains(arg, ":") {
+				continue
+			}
+
+			words = append(words, arg)
+
+			continue
+		}
+
+		key, value, ok := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+		if !ok && i+1 < len(args) {
+			i++
+			value = args[i]
+		}
+
+		o[key] = value
+	}
+
+	name := strings.Join(words, " ")
+
+	cmd, ok := commands[name]
+	if !ok {
+		return "", nil, fmt.Errorf("%w: %s", ErrUnknownCommand, name)
+	}
+
+	allowed := strings.Fields(cmd.options)
+
+	for key := range o {
+		if !contains(allowed, key) {
+			return "", nil, fmt.Errorf("%w: --%s", ErrUnknownOption, key)
+		}
+	}
+
+	return name, o, nil
+}
+
+func contains(list []string, s string) bool {
+	for i := range list {
+		if list[i] == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// require - value of mandatory option.
+func (o options) require(key string) (string, error) {
+	v, ok := o[key]
+	if !ok {
+		return "", fmt.Errorf("%w: --%s", ErrRequiredOption, key)
+	}
+
+	return v, nil
+}
+
+// authorized - empty admin allows anyone, as rac does for cluster without administrators.
+func (o options) authorized(prefix string, admin entity.Credentials) bool {
+	if admin == (entity.Credentials{}) {
+		return true
+	}
+
+	return o[prefix+"-user"] == admin.Name && o[prefix+"-pwd"] == admin.Pwd
+}
+
+// cluster - cluster of --cluster option with administrator checked.
+func (f *Fixture) cluster(o options) (*Cluster, error) {
+	id, err := o.require("cluster")
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range f.Clusters {
+		if f.Clusters[i].ID != id {
+			continue
+		}
+
+		if !o.authorized("cluster", f.Clusters[i].Admin) {
+			return nil, ErrClusterAuth
+		}
+
+		return &f.Clusters[i], nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrClusterNotFound, id)
+}
+
+// infobase - infobase of --infobase option, infobase user is checked if rights are needed.
+func (c *Cluster) infobase(o options, rights bool) (*Infobase, error) {
+	id, err := o.require("infobase")
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range c.Infobases {
+		if c.Infobases[i].ID != id {
+			continue
+		}
+
+		if rights && !o.authorized("infobase", c.Infobases[i].Admin) {
+			return nil, ErrInfobaseRights
+		}
+
+		return &c.Infobases[i], nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrInfobaseNotFound, id)
+}
+
+func clusterList(f *Fixture, o options, out io.Writer) error {
+	if !o.authorized("agent", f.Agent) {
+		return ErrAgentAuth
+	}
+
+	list := make([]entity.Cluster, 0, len(f.Clusters))
+	for i := range f.Clusters {
+		list = append(list, f.Clusters[i].Cluster)
+	}
+
+	return entity.MarshalList(out, list)
+}
+
+func infobaseSummaryList(f *Fixture, o options, out io.Writer) error {
+	c, err := f.cluster(o)
+	if err != nil {
+		return err
+	}
+
+	list := make([]entity.Infobase, 0, len(c.Infobases))
+	for i := range c.Infobases {
+		list = append(list, entity.Infobase{
+			ID:   c.Infobases[i].ID,
+			Name: c.Infobases[i].Name,
+			Desc: c.Infobases[i].Desc,
+		})
+	}
+
+	return entity.MarshalList(out, list)
+}
+
+func infobaseInfo(f *Fixture, o options, out io.Writer) error {
+	c, err := f.cluster(o)
+	if err != nil {
+		return err
+	}
+
+	ib, err := c.infobase(o, true)
+	if err != nil {
+		return err
+	}
+
+	return entity.MarshalList(out, []entity.InfobaseInfo{ib.InfobaseInfo})
+}
+
+func infobaseUpdate(f *Fixture, o options, _ io.Writer) error {
+	c, err := f.cluster(o)
+	if err != nil {
+		return err
+	}
+
+	ib, err := c.infobase(o, true)
+	if err != nil {
+		return err
+	}
+
+	info := ib.InfobaseInfo
+
+	for key, value := range o {
+		switch key {
+		case "denied-from":
+			info.DeniedFrom, err = parseTime(value)
+		case "denied-to":
+			info.DeniedTo, err = parseTime(value)
+		case "denied-message":
+			info.DeniedMessage = value
+		case "denied-parameter":
+			info.DeniedParameter = value
+		case "permission-code":
+			info.PermissionCode = value
+		case "scheduled-jobs-deny":
+			info.ScheduledJobsDeny, err = parseOnOff(value)
+		case "sessions-deny":
+			info.SessionsDeny, err = parseOnOff(value)
+		}
+
+		if err != nil {
+			return fmt.Errorf("--%s: %w", key, err)
+		}
+	}
+
+	ib.InfobaseInfo = info
+
+	return nil
+}
+
+func sessionList(f *Fixture, o options, out io.Writer) error {
+	c, err := f.cluster(o)
+	if err != nil {
+		return err
+	}
+
+	list := make([]entity.Session, 0, len(c.Sessions))
+	for i := range c.Sessions {
+		if id, ok := o["infobase"]; !ok || c.Sessions[i].InfobaseID == id {
+			list = append(list, c.Sessions[i])
+		}
+	}
+
+	return entity.MarshalList(out, list)
+}
+
+func sessionTerminate(f *Fixture, o options, _ io.Writer) error {
+	c, err := f.cluster(o)
+	if err != nil {
+		return err
+	}
+
+	id, err := o.require("session")
+	if err != nil {
+		return err
+	}
+
+	for i := range c.Sessions {
+		if c.Sessions[i].ID == id {
+			c.Sessions = append(c.Sessions[:i], c.Sessions[i+1:]...)
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrSessionNotFound, id)
+}
+
+func connectionList(f *Fixture, o options, out io.Writer) error {
+	c, err := f.cluster(o)
+	if err != nil {
+		return err
+	}
+
+	list := make([]entity.Connection, 0, len(c.Connections))
+	for i := range c.Connections {
+		if id, ok := o["infobase"]; !ok || c.Connections[i].InfobaseID == id {
+			list = append(list, c.Connections[i])
+		}
+	}
+
+	return entity.MarshalList(out, list)
+}
+
+func connectionDisconnect(f *Fixture, o options, _ io.Writer) error {
+	c, err := f.cluster(o)
+	if err != nil {
+		return err
+	}
+
+	id, err := o.require("connection")
+	if err != nil {
+		return err
+	}
+
+	for i := range c.Connections {
+		if c.Connections[i].ID == id {
+			c.Connections = append(c.Connections[:i], c.Connections[i+1:]...)
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrConnNotFound, id)
+}
+
+// parseTime - empty value resets lock bound.
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.ParseInLocation(_formatDate, value, time.UTC)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s", ErrInvalidValue, value)
+	}
+
+	return t, nil
+}
+
+func parseOnOff(value string) (bool, error) {
+	switch value {
+	case "on":
+		return true, nil
+	case "off":
+		return false, nil
+	default:
+		return false, fmt.Errorf("%w: %s", ErrInvalidValue, value)
+	}
+}
@@ -0,0 +1,174 @@
+// Package ractest implements fake rac for integration tests: test binary started by pipe.Pipe
+// serves rac commands from in-memory cluster state kept in YAML file between calls.
+package ractest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+)
+
+// EnvState - path to state file, test binary is fake rac when it is set.
+const EnvState = "RACTEST_STATE"
+
+const (
+	_lockTimeout = 10 * time.Second
+	_lockStale   = 2 * time.Second
+	_lockRetry   = time.Millisecond
+)
+
+var ErrLockTimeout = errors.New("state is locked")
+
+// Fixture - state of fake central server -.
+type Fixture struct {
+	// Agent - central server administrator, anyone is allowed if empty
+	Agent    entity.Credentials `yaml:"agent"`
+	Clusters []Cluster          `yaml:"clusters"`
+
+	// Calls - arguments of every rac call, in order
+	Calls [][]string `yaml:"calls,omitempty"`
+}
+
+// Cluster - cluster with its infobases, sessions and connections -.
+type Cluster struct {
+	entity.Cluster `yaml:",inline"`
+
+	// Admin - cluster administrator, anyone is allowed if empty
+	Admin       entity.Credentials  `yaml:"admin"`
+	Infobases   []Infobase          `yaml:"infobases"`
+	Sessions    []entity.Session    `yaml:"sessions"`
+	Connections []entity.Connection `yaml:"connections"`
+}
+
+// Infobase -.
+type Infobase struct {
+	entity.InfobaseInfo `yaml:",inline"`
+
+	// Admin - infobase user allowed to read and change it, anyone is allowed if empty
+	Admin entity.Credentials `yaml:"admin"`
+}
+
+// Rac - fake rac of a single test -.
+type Rac struct {
+	// Path - executable to pass to pipe.New
+	Path string
+
+	state string
+}
+
+// Main - turns test binary into fake rac when it is started by pipe, call it first in TestMain.
+func Main() {
+	if state := os.Getenv(EnvState); state != "" {
+		os.Exit(Run(state, os.Args[1:], os.Stdout, os.Stderr))
+	}
+}
+
+// New - fake rac serving copy of fixture, changes made by commands are seen by State.
+// Tests using it cannot be parallel since state is passed to rac by environment.
+func New(t testing.TB, fixture string) *Rac {
+	t.Helper()
+
+	data, err := os.ReadFile(fixture)
+	if err != nil {
+		t.Fatalf("ractest - New - os.ReadFile: %s", err)
+	}
+
+	state := filepath.Join(t.TempDir(), "state.yaml")
+
+	if err = os.WriteFile(state, data, 0o600); err != nil {
+		t.Fatalf("ractest - New - os.WriteFile: %s", err)
+	}
+
+	path, err := os.Executable()
+	if err != nil {
+		t.Fatalf("ractest - New - os.Executable: %s", err)
+	}
+
+	t.Setenv(EnvState, state)
+
+	return &Rac{
+		Path:  path,
+		state: state,
+	}
+}
+
+// State - current state of fake central server.
+func (r *Rac) State(t testing.TB) Fixture {
+	t.Helper()
+
+	f, err := load(r.state)
+	if err != nil {
+		t.Fatalf("ractest - State - load: %s", err)
+	}
+
+	return f
+}
+
+func load(path string) (Fixture, error) {
+	var f Fixture
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return f, err
+	}
+
+	if err = yaml.Unmarshal(data, &f); err != nil {
+		return f, fmt.Errorf("yaml.Unmarshal: %w", err)
+	}
+
+	return f, nil
+}
+
+func save(path string, f Fixture) error {
+	data, err := yaml.Marshal(f)
+	if err != nil {
+		return fmt.Errorf("yaml.Marshal: %w", err)
+	}
+
+	// rac killed by canceled context must not leave state half written
+	tmp := path + ".tmp"
+
+	if err = os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// lock - rac calls run in parallel, e.g. by DeleteSessions, so state is changed under lock file.
+// Lock file of rac killed by canceled context is stale and taken over.
+func lock(path string) (func(), error) {
+	deadline := time.Now().Add(_lockTimeout)
+
+	for {
+		f, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			f.Close()
+
+			return func() { os.Remove(path + ".lock") }, nil
+		}
+
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+
+		if fi, err := os.Stat(path + ".lock"); err == nil && time.Since(fi.ModTime()) > _lockStale {
+			os.Remove(path + ".lock")
+
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, ErrLockTimeout
+		}
+
+		time.Sleep(_lockRetry)
+	}
+}
@@ -0,0 +1,139 @@
+package pipe
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+	"github.com/antonmisa/1cctl_cli/pkg/pipe"
+)
+
+// record - entity decoded from rac output by generated code.
+type record[T any] interface {
+	*T
+	entity.RACUnmarshaler
+}
+
+// ReadRecords - runs rac with args and decodes its output record by record, records are separated by empty lines.
+// fn gets each record as soon as it is parsed, returning false stops reading and rac is killed.
+// Everything happens in caller's goroutine, so fn needs no synchronization.
+func ReadRecords[T any, PT record[T]](ctx context.Context, p pipe.Piper, args []string, fn func(T) bool) error {
+	cmd, stdout, err := p.Run(ctx, args...)
+	if err != nil {
+		return fmt.Errorf("pipe.Run: %w", err)
+	}
+
+	defer stdout.Close()
+
+	if err = cmd.Start(); err != nil {
+		return fmt.Errorf("cmd.Start: %w", err)
+	}
+
+	stopped, err := scanRecords[T, PT](stdout, fn)
+	if err != nil || stopped {
+		// rest of output is not needed, rac may be blocked writing it
+		_ = cmd.Cancel() //nolint:errcheck // rac may be finished already
+		_ = cmd.Wait()   //nolint:errcheck // killed on purpose
+
+		return err
+	}
+
+	if err = cmd.Wait(); err != nil {
+		return fmt.Errorf("cmd.Wait: %w", racError(err))
+	}
+
+	return nil
+}
+
+// scanRecords - calls fn for each record of r, reports whether fn stopped reading.
+func scanRecords[T any, PT record[T]](r io.Reader, fn func(T) bool) (bool, error) {
+	lines := make([]string, 0, initialPropertiesSizeBig)
+
+	// emit - decodes collected lines, they are reused for next record
+	emit := func() (bool, error) {
+		var data T
+
+		if err := PT(&data).UnmarshalRAC(lines); err != nil {
+			return false, fmt.Errorf("UnmarshalRAC: %w", err)
+		}
+
+		lines = lines[:0]
+
+		return fn(data), nil
+	}
+
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+
+			continue
+		}
+
+		if len(lines) == 0 {
+			continue
+		}
+
+		if next, err := emit(); err != nil || !next {
+			return !next, err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("scanner.Err: %w", err)
+	}
+
+	if len(lines) > 0 {
+		next, err := emit()
+
+		return !next, err
+	}
+
+	return false, nil
+}
+
+// readAll - all records of rac output, call is repeated from scratch on transient failure.
+func readAll[T any, PT record[T]](ctx context.Context, c call, args []string) ([]T, error) {
+	rv := make([]T, 0, initialDataSize)
+
+	err := c.do(ctx, args, func(ctx context.Context) error {
+		rv = rv[:0]
+
+		return ReadRecords[T, PT](ctx, c.p, args, func(data T) bool {
+			rv = append(rv, data)
+
+			return true
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rv, nil
+}
+
+// exec - runs rac which prints nothing on success.
+func exec(ctx context.Context, c call, args []string) error {
+	return c.do(ctx, args, func(ctx context.Context) error {
+		cmd, _, err := c.p.Run(ctx, args...)
+		if err != nil {
+			return fmt.Errorf("pipe.Run: %w", err)
+		}
+
+		if err = cmd.Start(); err != nil {
+			return fmt.Errorf("cmd.Start: %w", err)
+		}
+
+		if err = cmd.Wait(); err != nil {
+			return fmt.Errorf("cmd.Wait: %w", racError(err))
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,245 @@
+// nolint
+package pipe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+	"github.com/antonmisa/1cctl_cli/pkg/pipe"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePiper - rac printing out, safe for concurrent use unlike mocks with shared readers.
+type fakePiper struct {
+	out      string
+	startErr error
+	waitErr  error
+
+	mu       sync.Mutex
+	canceled int
+}
+
+type fakeCommand struct {
+	p *fakePiper
+}
+
+func (c fakeCommand) Start() error { return c.p.startErr }
+func (c fakeCommand) Wait() error  { return c.p.waitErr }
+
+func (c fakeCommand) Cancel() error {
+	c.p.mu.Lock()
+	defer c.p.mu.Unlock()
+
+	c.p.canceled++
+
+	return nil
+}
+
+func (p *fakePiper) Run(_ context.Context, _ ...string) (pipe.Commander, io.ReadCloser, error) {
+	return fakeCommand{p: p}, io.NopCloser(strings.NewReader(p.out)), nil
+}
+
+const threeSessions = `session : 1
+user-name : Иванов
+
+session : 2
+user-name : Петров
+
+
+session : 3
+user-name : Сидоров
+`
+
+func TestReadRecords(t *testing.T) {
+	cases := []struct {
+		name     string
+		p        *fakePiper
+		stopAt   int
+		ids      []string
+		canceled int
+		err      string
+	}{
+		{
+			name: "All",
+			p:    &fakePiper{out: threeSessions},
+			ids:  []string{"1", "2", "3"},
+		},
+		{
+			name: "Leading and trailing empty lines",
+			p:    &fakePiper{out: "\n\n" + threeSessions + "\n\n"},
+			ids:  []string{"1", "2", "3"},
+		},
+		{
+			name:     "Stop early",
+			p:        &fakePiper{out: threeSessions},
+			stopAt:   2,
+			ids:      []string{"1", "2"},
+			canceled: 1,
+		},
+		{
+			name: "Empty output",
+			p:    &fakePiper{},
+		},
+		{
+			name: "Error start",
+			p:    &fakePiper{out: threeSessions, startErr: errors.New("start error")},
+			err:  "cmd.Start: start error",
+		},
+		{
+			name: "Error wait",
+			p:    &fakePiper{out: threeSessions, waitErr: errors.New("wait error")},
+			ids:  []string{"1", "2", "3"},
+			err:  "cmd.Wait: wait error",
+		},
+		{
+			name: "Error cluster auth",
+			p: &fakePiper{waitErr: &pipe.ExitError{
+				Err:    errors.New("exit status 255"),
+				Stderr: "Администратор кластера не аутентифицирован",
+			}},
+			err: ErrClusterAuth.Error(),
+		},
+		{
+			name:     "Error unmarshal",
+			p:        &fakePiper{out: "unknown : 1\n\nsession : 2\n"},
+			canceled: 1,
+			err:      "UnmarshalRAC: key not found",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var ids []string
+
+			err := ReadRecords(context.Background(), tc.p, nil, func(s entity.Session) bool {
+				ids = append(ids, s.ID)
+
+				return len(ids) != tc.stopAt
+			})
+
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.ids, ids)
+			require.Equal(t, tc.canceled, tc.p.canceled)
+		})
+	}
+}
+
+func TestReadRecordsConcurrent(t *testing.T) {
+	t.Parallel()
+
+	p := &fakePiper{out: threeSessions}
+	ctrl := New(p, "localhost:1545")
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			sessions, err := ctrl.GetSessions(context.Background(), entity.Cluster{ID: "1"}, entity.Infobase{}, entity.Credentials{})
+
+			require.NoError(t, err)
+			require.Len(t, sessions, 3)
+		}()
+	}
+
+	wg.Wait()
+}
+
+// sessionsOutput - rac session list output of n sessions.
+func sessionsOutput(n int) string {
+	var b strings.Builder
+
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, `session                          : %08d-2222-4333-8444-555555555555
+session-id                       : %d
+infobase                         : 6f3c2b1a-0e9d-4c8b-a7f6-5e4d3c2b1a00
+connection                       : abcdefab-cdef-4abc-8def-abcdefabcdef
+process                          : 01234567-89ab-4cde-8f01-23456789abcd
+user-name                        : user%d
+host                             : pc-%d
+app-id                           : 1CV8C
+locale                           : ru_RU
+started-at                       : 2023-08-08T09:00:00
+last-active-at                   : 2023-08-08T10:30:00
+hibernate                        : no
+passive-session-hibernate-time   : 1200
+hibernate-session-terminate-time : 86400
+blocked-by-dbms                  : 0
+blocked-by-ls                    : 0
+bytes-all                        : 1024
+bytes-last-5min                  : 512
+calls-all                        : 10
+calls-last-5min                  : 5
+dbms-bytes-all                   : 0
+dbms-bytes-last-5min             : 0
+db-proc-info                     :
+db-proc-took                     : 0
+db-proc-took-at                  :
+duration-all                     : 100
+duration-all-dbms                : 50
+duration-current                 : 0
+duration-current-dbms            : 0
+duration-last-5min               : 20
+duration-last-5min-dbms          : 10
+memory-current                   : 0
+memory-last-5min                 : 0
+memory-total                     : 4096
+read-current                     : 0
+read-last-5min                   : 0
+read-total                       : 0
+write-current                    : 0
+write-last-5min                  : 0
+write-total                      : 0
+duration-current-service         : 0
+duration-last-5min-service       : 0
+duration-all-service             : 0
+current-service-name             :
+cpu-time-current                 : 0
+cpu-time-last-5min               : 0
+cpu-time-total                   : 15
+data-separation                  : ''
+
+`, i, i, i, i)
+	}
+
+	return b.String()
+}
+
+func BenchmarkReadRecordsSessions10k(b *testing.B) {
+	p := &fakePiper{out: sessionsOutput(10000)}
+
+	b.SetBytes(int64(len(p.out)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		n := 0
+
+		err := ReadRecords(context.Background(), p, nil, func(entity.Session) bool {
+			n++
+
+			return true
+		})
+		if err != nil || n != 10000 {
+			b.Fatalf("got %d sessions: %v", n, err)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package pipe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+	uc "github.com/antonmisa/1cctl_cli/internal/usecase"
+	"github.com/antonmisa/1cctl_cli/pkg/logger"
+	"github.com/antonmisa/1cctl_cli/pkg/pipe"
+)
+
+// Operation types having own retry policies.
+const (
+	OpRead      = "read"      // lists and infobase info
+	OpUpdate    = "update"    // infobase lock
+	OpTerminate = "terminate" // sessions and connections
+)
+
+// Operations - all operation types.
+var Operations = []string{OpRead, OpUpdate, OpTerminate}
+
+// transient - call may succeed if repeated: rac failed to reach RAS or RAS dropped connection.
+// Authentication and "not found" failures are never transient.
+func transient(err error) bool {
+	return errors.Is(err, uc.ErrClusterUnreachable)
+}
+
+// gone - rac failed since session or connection is not found. Repeated termination fails so when attempt,
+// which rac reported as failed, was completed by RAS, then object is gone as termination wanted.
+// Unreachable RAS, e.g. "server not found", tells nothing about the object and is never gone.
+func gone(err error) bool {
+	if errors.Is(err, uc.ErrClusterUnreachable) {
+		return false
+	}
+
+	return errors.Is(err, uc.ErrSessionNotFound) || errors.Is(err, uc.ErrConnectionNotFound)
+}
+
+// WithRetry - transient failures of rac calls are repeated by policy of their operation type,
+// each attempt is logged. Operation types absent in policies are not repeated.
+// Update sets whole state of infobase lock, so repeating it is safe. Termination repeated
+// after transient failure succeeds if session or connection is not found any more.
+func (r *CtrlPipe) WithRetry(policies map[string]entity.RetryPolicy, l logger.Interface) *CtrlPipe {
+	r.retry = policies
+	r.l = l
+
+	return r
+}
+
+// call - rac call of operation type.
+type call struct {
+	p      pipe.Piper
+	op     string
+	policy entity.RetryPolicy
+	l      logger.Interface
+}
+
+func (r *CtrlPipe) call(op string) call {
+	return call{
+		p:      r.pipe,
+		op:     op,
+		policy: r.retry[op],
+		l:      r.l,
+	}
+}
+
+// do - runs fn until it succeeds, fails with error which is not transient or policy is exhausted.
+func (c call) do(ctx context.Context, args []string, fn func(ctx context.Context) error) error {
+	start := time.Now()
+
+	// passwords are among args, only command is logged
+	name := command(args)
+
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			if attempt > 1 {
+				c.info("ctrlpipe - %s %q - attempt %d succeeded", c.op, name, attempt)
+			}
+
+			return nil
+		}
+
+		if attempt > 1 && c.op == OpTerminate && gone(err) {
+			c.info("ctrlpipe - %s %q - attempt %d: not found, terminated by previous attempt: %s", c.op, name, attempt, err)
+
+			return nil
+		}
+
+		if !transient(err) {
+			return err
+		}
+
+		if attempt >= c.policy.Attempts {
+			if attempt > 1 {
+				c.warn("ctrlpipe - %s %q - attempt %d of %d failed, attempts exhausted: %s", c.op, name, attempt, c.policy.Attempts, err)
+			}
+
+			return err
+		}
+
+		delay := c.policy.Backoff(attempt)
+
+		if c.policy.Deadline > 0 && time.Since(start)+delay > c.policy.Deadline {
+			c.warn("ctrlpipe - %s %q - attempt %d of %d failed, retry deadline %s exceeded: %s",
+				c.op, name, attempt, c.policy.Attempts, c.policy.Deadline, err)
+
+			return fmt.Errorf("retry deadline %s exceeded: %w", c.policy.Deadline, err)
+		}
+
+		c.warn("ctrlpipe - %s %q - attempt %d of %d failed, retry in %s: %s",
+			c.op, name, attempt, c.policy.Attempts, delay.Round(time.Millisecond), err)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", ctx.Err(), err)
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (c call) info(message string, args ...interface{}) {
+	if c.l != nil {
+		c.l.Info(message, args...)
+	}
+}
+
+func (c call) warn(message string, args ...interface{}) {
+	if c.l != nil {
+		c.l.Warn(message, args...)
+	}
+}
+
+// command - rac command of args, like "session list", without address and options.
+func command(args []string) string {
+	words := make([]string, 0, len(args))
+
+	for i, arg := range args {
+		if strings.HasPrefix(arg, "--") {
+			break
+		}
+
+		if i == 0 && strings.Contains(arg, ":") {
+			continue
+		}
+
+		words = append(words, arg)
+	}
+
+	return strings.Join(words, " ")
+}
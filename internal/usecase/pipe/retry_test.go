@@ -0,0 +1,280 @@
+// nolint
+package pipe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+	"github.com/antonmisa/1cctl_cli/pkg/pipe"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyPiper - rac failing with stderr given number of times before printing out,
+// failures after first one print next stderr if it is given.
+type flakyPiper struct {
+	failures int
+	stderr   string
+	next     string
+	out      string
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (p *flakyPiper) Run(_ context.Context, _ ...string) (pipe.Commander, io.ReadCloser, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.calls++
+
+	var err error
+	if p.calls <= p.failures {
+		stderr := p.stderr
+		if p.calls > 1 && p.next != "" {
+			stderr = p.next
+		}
+
+		err = &pipe.ExitError{Err: errors.New("exit status 255"), Stderr: stderr}
+	}
+
+	out := p.out
+	if err != nil {
+		out = ""
+	}
+
+	return flakyCommand{waitErr: err}, io.NopCloser(strings.NewReader(out)), nil
+}
+
+type flakyCommand struct {
+	waitErr error
+}
+
+func (c flakyCommand) Start() error  { return nil }
+func (c flakyCommand) Wait() error   { return c.waitErr }
+func (c flakyCommand) Cancel() error { return nil }
+
+// recordingLogger - keeps messages of Info and Warn.
+type recordingLogger struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *recordingLogger) add(message string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.messages = append(l.messages, fmt.Sprintf(message, args...))
+}
+
+func (l *recordingLogger) Debug(message interface{}, args ...interface{}) {}
+func (l *recordingLogger) Info(message string, args ...interface{})       { l.add(message, args...) }
+func (l *recordingLogger) Warn(message string, args ...interface{})       { l.add(message, args...) }
+func (l *recordingLogger) Error(message interface{}, args ...interface{}) {}
+func (l *recordingLogger) Fatal(message interface{}, args ...interface{}) {}
+
+func TestRetry(t *testing.T) {
+	policy := entity.RetryPolicy{Attempts: 3, Initial: time.Millisecond, Max: 5 * time.Millisecond}
+
+	cases := []struct {
+		name     string
+		failures int
+		stderr   string
+		policy   entity.RetryPolicy
+		calls    int
+		logged   int
+		err      string
+	}{
+		{
+			name:     "Transient failure retried",
+			failures: 2,
+			stderr:   "Ошибка соединения с сервером 1С:Предприятия 8.3: Connection refused",
+			policy:   policy,
+			calls:    3,
+			logged:   3,
+		},
+		{
+			name:     "Attempts exhausted",
+			failures: 5,
+			stderr:   "Ошибка соединения с сервером: превышено время ожидания",
+			policy:   policy,
+			calls:    3,
+			logged:   3,
+			err:      "превышено время ожидания",
+		},
+		{
+			name:     "Authentication is not retried",
+			failures: 1,
+			stderr:   "Администратор кластера не аутентифицирован",
+			policy:   policy,
+			calls:    1,
+			err:      ErrClusterAuth.Error(),
+		},
+		{
+			name:     "Not found is not retried",
+			failures: 1,
+			stderr:   "Кластер с указанным идентификатором не найден",
+			policy:   policy,
+			calls:    1,
+			err:      "не найден",
+		},
+		{
+			name:     "Deadline",
+			failures: 5,
+			stderr:   "connection reset by peer",
+			policy:   entity.RetryPolicy{Attempts: 10, Initial: 10 * time.Millisecond, Max: 40 * time.Millisecond, Deadline: 15 * time.Millisecond},
+			calls:    2,
+			logged:   2,
+			err:      "retry deadline 15ms exceeded",
+		},
+		{
+			name:     "No policy",
+			failures: 1,
+			stderr:   "connection refused",
+			calls:    1,
+			err:      "connection refused",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := &flakyPiper{failures: tc.failures, stderr: tc.stderr, out: "cluster : 1\nhost : srv\n"}
+			l := &recordingLogger{}
+
+			ctrl := New(p, "localhost:1545").WithRetry(map[string]entity.RetryPolicy{OpRead: tc.policy}, l)
+
+			clusters, err := ctrl.GetClusters(context.Background(), entity.Credentials{Name: "admin", Pwd: "secret"})
+
+			require.Equal(t, tc.calls, p.calls)
+			require.Len(t, l.messages, tc.logged)
+
+			for _, m := range l.messages {
+				require.Contains(t, m, `read "cluster list"`)
+				require.NotContains(t, m, "secret")
+			}
+
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, []entity.Cluster{{ID: "1", Host: "srv"}}, clusters)
+		})
+	}
+}
+
+func TestRetryPerOperation(t *testing.T) {
+	p := &flakyPiper{failures: 1, stderr: "connection refused"}
+
+	ctrl := New(p, "localhost:1545").WithRetry(map[string]entity.RetryPolicy{
+		OpRead:      {Attempts: 3, Initial: time.Millisecond},
+		OpTerminate: {Attempts: 1},
+	}, nil)
+
+	err := ctrl.DeleteSession(context.Background(), entity.Cluster{ID: "1"}, entity.Session{ID: "2"}, entity.Credentials{}, "")
+	require.ErrorContains(t, err, "connection refused")
+	require.Equal(t, 1, p.calls)
+}
+
+func TestRetryCanceled(t *testing.T) {
+	p := &flakyPiper{failures: 5, stderr: "connection refused"}
+
+	ctrl := New(p, "localhost:1545").WithRetry(map[string]entity.RetryPolicy{
+		OpUpdate: {Attempts: 5, Initial: time.Hour},
+	}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := ctrl.RestoreLock(ctx, entity.Cluster{ID: "1"}, entity.Infobase{ID: "2"}, entity.Credentials{}, entity.Credentials{}, entity.InfobaseLock{})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, 1, p.calls)
+}
+
+func TestRetryTerminate(t *testing.T) {
+	const notFound = "Сеанс с указанным идентификатором не найден"
+
+	cases := []struct {
+		name     string
+		failures int
+		stderr   string
+		next     string
+		calls    int
+		logged   int
+		err      string
+	}{
+		{
+			name:     "Not found after transient failure",
+			failures: 2,
+			stderr:   "connection reset by peer",
+			next:     notFound,
+			calls:    2,
+			logged:   2,
+		},
+		{
+			name:     "Unreachable server is not gone",
+			failures: 3,
+			stderr:   "connection reset by peer",
+			next:     "Ошибка соединения с сервером 1С:Предприятия 8.3: server not found",
+			calls:    3,
+			logged:   3,
+			err:      "server not found",
+		},
+		{
+			name:     "Not found of other object is not gone",
+			failures: 2,
+			stderr:   "connection reset by peer",
+			next:     "Кластер с указанным идентификатором не найден",
+			calls:    2,
+			logged:   1,
+			err:      "не найден",
+		},
+		{
+			name:     "Not found at first attempt",
+			failures: 1,
+			stderr:   notFound,
+			calls:    1,
+			err:      "не найден",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := &flakyPiper{failures: tc.failures, stderr: tc.stderr, next: tc.next}
+			l := &recordingLogger{}
+
+			ctrl := New(p, "localhost:1545").WithRetry(map[string]entity.RetryPolicy{
+				OpTerminate: {Attempts: 3, Initial: time.Millisecond},
+			}, l)
+
+			err := ctrl.DeleteSession(context.Background(), entity.Cluster{ID: "1"}, entity.Session{ID: "2"}, entity.Credentials{}, "")
+
+			require.Equal(t, tc.calls, p.calls)
+			require.Len(t, l.messages, tc.logged)
+
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
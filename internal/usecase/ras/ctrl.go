@@ -0,0 +1,470 @@
+// Package ras implements CtrlPipe over RAS binary protocol, so rac executable is not required.
+package ras
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+	uc "github.com/antonmisa/1cctl_cli/internal/usecase"
+	"github.com/antonmisa/1cctl_cli/pkg/ras"
+)
+
+var (
+	ErrInfobaseIsEmpty   = errors.New("infobase is empty")
+	ErrSessionIsEmpty    = errors.New("session is empty")
+	ErrConnectionIsEmpty = errors.New("connection is empty")
+	ErrNotSupported      = errors.New("not supported by ras engine, use rac one")
+	ErrUnverified        = fmt.Errorf("changes are not verified against real RAS yet: %w", ErrNotSupported)
+	ErrAgentAuth         = uc.ErrAgentAuth
+	ErrClusterAuth       = uc.ErrClusterAuth
+)
+
+// CtrlRAS - each call dials RAS, authenticates and closes connection, as rac does -.
+type CtrlRAS struct {
+	clusterConnection string
+	changes           bool
+}
+
+var _ uc.CtrlPipe = (*CtrlRAS)(nil)
+
+// New -.
+func New(cc string) *CtrlRAS {
+	return &CtrlRAS{
+		clusterConnection: cc,
+	}
+}
+
+// WithChanges - lets termination of sessions and connections and updates of infobase lock go to RAS.
+// Their exchanges are written by protocol description and replayed by tests only, not captured from real RAS,
+// so they are refused with ErrUnverified unless enabled.
+func (r *CtrlRAS) WithChanges() *CtrlRAS {
+	r.changes = true
+
+	return r
+}
+
+// GetClusters - agent credentials are required by central servers with administrators.
+func (r *CtrlRAS) GetClusters(ctx context.Context, agentCred entity.Credentials) ([]entity.Cluster, error) {
+	c, err := ras.Dial(ctx, r.clusterConnection)
+	if err != nil {
+		return nil, fmt.Errorf("ctrlras - getclusters - ras.Dial: %w", dialError(err))
+	}
+	defer c.Close()
+
+	if agentCred != (entity.Credentials{}) {
+		err = c.Exec(ctx, authenticateAgentRequest, func(e *ras.Encoder) error {
+			e.String(agentCred.Name)
+			e.String(agentCred.Pwd)
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("ctrlras - getclusters - authenticate agent: %w", rasError(err))
+		}
+	}
+
+	d, err := c.Call(ctx, getClustersRequest, nil, getClustersResponse)
+	if err != nil {
+		return nil, fmt.Errorf("ctrlras - getclusters - c.Call: %w", rasError(err))
+	}
+
+	rv := decodeList(d, decodeCluster)
+
+	if err = d.Err(); err != nil {
+		return nil, fmt.Errorf("ctrlras - getclusters - decodeCluster: %w", err)
+	}
+
+	return rv, nil
+}
+
+func (r *CtrlRAS) GetInfobases(ctx context.Context, cluster entity.Cluster, clusterCred entity.Credentials) ([]entity.Infobase, error) {
+	c, err := r.dial(ctx, cluster, clusterCred)
+	if err != nil {
+		return nil, fmt.Errorf("ctrlras - getinfobases - r.dial: %w", err)
+	}
+	defer c.Close()
+
+	d, err := c.Call(ctx, getInfobasesShortRequest, clusterBody(cluster), getInfobasesShortResponse)
+	if err != nil {
+		return nil, fmt.Errorf("ctrlras - getinfobases - c.Call: %w", rasError(err))
+	}
+
+	rv := decodeList(d, decodeInfobase)
+
+	if err = d.Err(); err != nil {
+		return nil, fmt.Errorf("ctrlras - getinfobases - decodeInfobase: %w", err)
+	}
+
+	return rv, nil
+}
+
+func (r *CtrlRAS) GetSessions(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials) ([]entity.Session, error) {
+	c, err := r.dial(ctx, cluster, clusterCred)
+	if err != nil {
+		return nil, fmt.Errorf("ctrlras - getsessions - r.dial: %w", err)
+	}
+	defer c.Close()
+
+	var d *ras.Decoder
+
+	if infobase == (entity.Infobase{}) {
+		d, err = c.Call(ctx, getSessionsRequest, clusterBody(cluster), getSessionsResponse)
+	} else {
+		d, err = c.Call(ctx, getInfobaseSessionsRequest, infobaseBody(cluster, infobase), getInfobaseSessionsResponse)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("ctrlras - getsessions - c.Call: %w", rasError(err))
+	}
+
+	rv := decodeList(d, decodeSession)
+
+	if err = d.Err(); err != nil {
+		return nil, fmt.Errorf("ctrlras - getsessions - decodeSession: %w", err)
+	}
+
+	return rv, nil
+}
+
+func (r *CtrlRAS) GetConnections(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials) ([]entity.Connection, error) {
+	c, err := r.dial(ctx, cluster, clusterCred)
+	if err != nil {
+		return nil, fmt.Errorf("ctrlras - getconnections - r.dial: %w", err)
+	}
+	defer c.Close()
+
+	var d *ras.Decoder
+
+	if infobase == (entity.Infobase{}) {
+		d, err = c.Call(ctx, getConnectionsRequest, clusterBody(cluster), getConnectionsResponse)
+	} else {
+		d, err = c.Call(ctx, getInfobaseConnectionsRequest, infobaseBody(cluster, infobase), getInfobaseConnectionsResponse)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("ctrlras - getconnections - c.Call: %w", rasError(err))
+	}
+
+	rv := decodeList(d, decodeConnection)
+
+	if err = d.Err(); err != nil {
+		return nil, fmt.Errorf("ctrlras - getconnections - decodeConnection: %w", err)
+	}
+
+	return rv, nil
+}
+
+// GetProcesses - layout of working process is not implemented yet.
+func (r *CtrlRAS) GetProcesses(_ context.Context, _ entity.Cluster, _ entity.Credentials) ([]entity.Process, error) {
+	return nil, fmt.Errorf("ctrlras - getprocesses: %w", ErrNotSupported)
+}
+
+// GetServers - layout of working server is not implemented yet.
+func (r *CtrlRAS) GetServers(_ context.Context, _ entity.Cluster, _ entity.Credentials) ([]entity.Server, error) {
+	return nil, fmt.Errorf("ctrlras - getservers: %w", ErrNotSupported)
+}
+
+// GetManagers - layout of cluster manager is not implemented yet.
+func (r *CtrlRAS) GetManagers(_ context.Context, _ entity.Cluster, _ entity.Credentials) ([]entity.Manager, error) {
+	return nil, fmt.Errorf("ctrlras - getmanagers: %w", ErrNotSupported)
+}
+
+// GetInfobaseInfo - reads full properties of infobase, infobase credentials are required by RAS.
+func (r *CtrlRAS) GetInfobaseInfo(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials) (entity.InfobaseInfo, error) {
+	if infobase == (entity.Infobase{}) {
+		return entity.InfobaseInfo{}, fmt.Errorf("ctrlras - getinfobaseinfo: %w", ErrInfobaseIsEmpty)
+	}
+
+	c, err := r.dialInfobase(ctx, cluster, clusterCred, infobaseCred)
+	if err != nil {
+		return entity.InfobaseInfo{}, fmt.Errorf("ctrlras - getinfobaseinfo - r.dialInfobase: %w", err)
+	}
+	defer c.Close()
+
+	rec, err := infobaseInfo(ctx, c, cluster, infobase)
+	if err != nil {
+		return entity.InfobaseInfo{}, fmt.Errorf("ctrlras - getinfobaseinfo - infobaseInfo: %w", err)
+	}
+
+	return rec.info, nil
+}
+
+// GetInfobaseLock - reads current denial settings of infobase.
+func (r *CtrlRAS) GetInfobaseLock(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials) (entity.InfobaseLock, error) {
+	info, err := r.GetInfobaseInfo(ctx, cluster, infobase, clusterCred, infobaseCred)
+	if err != nil {
+		return entity.InfobaseLock{}, fmt.Errorf("ctrlras - getinfobaselock - r.GetInfobaseInfo: %w", err)
+	}
+
+	return info.Lock(), nil
+}
+
+func (r *CtrlRAS) DisableSessions(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials, lock entity.SessionsLock) error {
+	err := r.updateInfobase(ctx, cluster, infobase, clusterCred, infobaseCred, func(info *entity.InfobaseInfo) {
+		info.SessionsDeny = true
+		info.DeniedFrom = lock.From
+		info.DeniedTo = lock.To
+		info.DeniedMessage = lock.Message
+		info.PermissionCode = lock.Code
+		info.ScheduledJobsDeny = lock.ScheduledJobsDeny
+	})
+	if err != nil {
+		return fmt.Errorf("ctrlras - disablesessions - r.updateInfobase: %w", err)
+	}
+
+	return nil
+}
+
+// RestoreLock - sets denial settings of infobase to state saved by GetInfobaseLock.
+func (r *CtrlRAS) RestoreLock(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials, state entity.InfobaseLock) error {
+	err := r.updateInfobase(ctx, cluster, infobase, clusterCred, infobaseCred, func(info *entity.InfobaseInfo) {
+		info.SessionsDeny = state.SessionsDeny
+		info.DeniedFrom = state.From
+		info.DeniedTo = state.To
+		info.DeniedMessage = state.Message
+		info.DeniedParameter = state.Parameter
+		info.PermissionCode = state.Code
+		info.ScheduledJobsDeny = state.ScheduledJobsDeny
+	})
+	if err != nil {
+		return fmt.Errorf("ctrlras - restorelock - r.updateInfobase: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CtrlRAS) DeleteSession(ctx context.Context, cluster entity.Cluster, session entity.Session, clusterCred entity.Credentials, message string) error {
+	return r.DeleteSessions(ctx, cluster, []entity.Session{session}, clusterCred, message)
+}
+
+// DeleteSessions - terminates sessions one by one over single connection.
+func (r *CtrlRAS) DeleteSessions(ctx context.Context, cluster entity.Cluster, sessions []entity.Session, clusterCred entity.Credentials, message string) error {
+	if !r.changes {
+		return fmt.Errorf("ctrlras - deletesessions: %w", ErrUnverified)
+	}
+
+	for i := range sessions {
+		if sessions[i] == (entity.Session{}) {
+			return fmt.Errorf("ctrlras - deletesessions: %w", ErrSessionIsEmpty)
+		}
+	}
+
+	c, err := r.dial(ctx, cluster, clusterCred)
+	if err != nil {
+		return fmt.Errorf("ctrlras - deletesessions - r.dial: %w", err)
+	}
+	defer c.Close()
+
+	for i := range sessions {
+		err = c.Exec(ctx, terminateSessionRequest, func(e *ras.Encoder) error {
+			if err := e.UUID(cluster.ID); err != nil {
+				return err
+			}
+
+			if err := e.UUID(sessions[i].ID); err != nil {
+				return err
+			}
+
+			e.String(message)
+
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("ctrlras - deletesessions - c.Exec: %w", rasError(err))
+		}
+	}
+
+	return nil
+}
+
+func (r *CtrlRAS) DeleteConnection(ctx context.Context, cluster entity.Cluster, connection entity.Connection, clusterCred entity.Credentials) error {
+	return r.DeleteConnections(ctx, cluster, []entity.Connection{connection}, clusterCred)
+}
+
+// DeleteConnections - breaks connections one by one over single connection.
+func (r *CtrlRAS) DeleteConnections(ctx context.Context, cluster entity.Cluster, connections []entity.Connection, clusterCred entity.Credentials) error {
+	if !r.changes {
+		return fmt.Errorf("ctrlras - deleteconnections: %w", ErrUnverified)
+	}
+
+	for i := range connections {
+		if connections[i] == (entity.Connection{}) {
+			return fmt.Errorf("ctrlras - deleteconnections: %w", ErrConnectionIsEmpty)
+		}
+	}
+
+	c, err := r.dial(ctx, cluster, clusterCred)
+	if err != nil {
+		return fmt.Errorf("ctrlras - deleteconnections - r.dial: %w", err)
+	}
+	defer c.Close()
+
+	for i := range connections {
+		err = c.Exec(ctx, disconnectRequest, func(e *ras.Encoder) error {
+			if err := e.UUID(cluster.ID); err != nil {
+				return err
+			}
+
+			if err := e.UUID(connections[i].ProcessID); err != nil {
+				return err
+			}
+
+			return e.UUID(connections[i].ID)
+		})
+		if err != nil {
+			return fmt.Errorf("ctrlras - deleteconnections - c.Exec: %w", rasError(err))
+		}
+	}
+
+	return nil
+}
+
+// updateInfobase - reads properties of infobase, changes them by fn and writes back.
+func (r *CtrlRAS) updateInfobase(ctx context.Context, cluster entity.Cluster, infobase entity.Infobase, clusterCred entity.Credentials, infobaseCred entity.Credentials, fn func(info *entity.InfobaseInfo)) error {
+	if !r.changes {
+		return ErrUnverified
+	}
+
+	if infobase == (entity.Infobase{}) {
+		return ErrInfobaseIsEmpty
+	}
+
+	c, err := r.dialInfobase(ctx, cluster, clusterCred, infobaseCred)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	rec, err := infobaseInfo(ctx, c, cluster, infobase)
+	if err != nil {
+		return err
+	}
+
+	fn(&rec.info)
+
+	err = c.Exec(ctx, updateInfobaseRequest, func(e *ras.Encoder) error {
+		if err := e.UUID(cluster.ID); err != nil {
+			return err
+		}
+
+		return encodeInfobaseInfo(e, rec)
+	})
+	if err != nil {
+		return rasError(err)
+	}
+
+	return nil
+}
+
+// dial - connection authenticated as cluster administrator.
+func (r *CtrlRAS) dial(ctx context.Context, cluster entity.Cluster, clusterCred entity.Credentials) (*ras.Client, error) {
+	c, err := ras.Dial(ctx, r.clusterConnection)
+	if err != nil {
+		return nil, dialError(err)
+	}
+
+	err = c.Exec(ctx, authenticateClusterRequest, func(e *ras.Encoder) error {
+		if err := e.UUID(cluster.ID); err != nil {
+			return err
+		}
+
+		e.String(clusterCred.Name)
+		e.String(clusterCred.Pwd)
+
+		return nil
+	})
+	if err != nil {
+		c.Close()
+
+		return nil, rasError(err)
+	}
+
+	return c, nil
+}
+
+// dialInfobase - connection authenticated as cluster administrator and infobase user.
+func (r *CtrlRAS) dialInfobase(ctx context.Context, cluster entity.Cluster, clusterCred entity.Credentials, infobaseCred entity.Credentials) (*ras.Client, error) {
+	c, err := r.dial(ctx, cluster, clusterCred)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.Exec(ctx, addAuthenticationRequest, func(e *ras.Encoder) error {
+		if err := e.UUID(cluster.ID); err != nil {
+			return err
+		}
+
+		e.String(infobaseCred.Name)
+		e.String(infobaseCred.Pwd)
+
+		return nil
+	})
+	if err != nil {
+		c.Close()
+
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func infobaseInfo(ctx context.Context, c *ras.Client, cluster entity.Cluster, infobase entity.Infobase) (infobaseRecord, error) {
+	d, err := c.Call(ctx, getInfobaseInfoRequest, infobaseBody(cluster, infobase), getInfobaseInfoResponse)
+	if err != nil {
+		return infobaseRecord{}, rasError(err)
+	}
+
+	rec := decodeInfobaseInfo(d)
+
+	if err = d.Err(); err != nil {
+		return infobaseRecord{}, err
+	}
+
+	return rec, nil
+}
+
+func clusterBody(cluster entity.Cluster) func(e *ras.Encoder) error {
+	return func(e *ras.Encoder) error {
+		return e.UUID(cluster.ID)
+	}
+}
+
+func infobaseBody(cluster entity.Cluster, infobase entity.Infobase) func(e *ras.Encoder) error {
+	return func(e *ras.Encoder) error {
+		if err := e.UUID(cluster.ID); err != nil {
+			return err
+		}
+
+		return e.UUID(infobase.ID)
+	}
+}
+
+// rasError - RAS exception with its reason told by known messages.
+func rasError(err error) error {
+	var se *ras.ServiceError
+
+	if !errors.As(err, &se) {
+		return err
+	}
+
+	return &uc.ClusterError{
+		Kind:    uc.ClassifyMessage(se.Message),
+		Message: se.Message,
+		Err:     err,
+	}
+}
+
+// dialError - RAS is not reached, unless dial is canceled.
+func dialError(err error) error {
+	if errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	return &uc.ClusterError{
+		Kind:    uc.ErrClusterUnreachable,
+		Message: err.Error(),
+		Err:     err,
+	}
+}
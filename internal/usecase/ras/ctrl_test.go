@@ -0,0 +1,313 @@
+// nolint
+package ras
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+	"github.com/antonmisa/1cctl_cli/pkg/ras"
+	"github.com/antonmisa/1cctl_cli/pkg/ras/rastest"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testCluster    = "b7b2a7e2-1b2b-4b7f-9f4c-3d2d1c0b0a09"
+	testInfobase   = "6f3c2b1a-0e9d-4c8b-a7f6-5e4d3c2b1a00"
+	testSession    = "11111111-2222-4333-8444-555555555555"
+	testConnection = "abcdefab-cdef-4abc-8def-abcdefabcdef"
+	testProcess    = "01234567-89ab-4cde-8f01-23456789abcd"
+)
+
+var (
+	clusterCred  = entity.Credentials{Name: "admin", Pwd: "secret"}
+	infobaseCred = entity.Credentials{Name: "backup", Pwd: "ibpwd"}
+)
+
+func TestGetClusters(t *testing.T) {
+	cases := []struct {
+		name     string
+		exchange string
+		cred     entity.Credentials
+		count    int
+		first    entity.Cluster
+		err      error
+	}{
+		{
+			name:     "OK",
+			exchange: "testdata/get_clusters.txt",
+			cred:     entity.Credentials{Name: "agent", Pwd: "agentpwd"},
+			count:    2,
+			first: entity.Cluster{
+				ID:         testCluster,
+				Host:       "srv1",
+				Port:       "1541",
+				Name:       "Локальный кластер",
+				Exp:        60,
+				SesFTLevel: 1,
+				LBMode:     "performance",
+			},
+		},
+		{
+			name:     "Error agent auth",
+			exchange: "testdata/get_clusters_agent_auth.txt",
+			cred:     entity.Credentials{Name: "agent", Pwd: "wrong"},
+			err:      ErrAgentAuth,
+		},
+		{
+			name:     "Error size over limit",
+			exchange: "testdata/get_clusters_size_limit.txt",
+			cred:     entity.Credentials{Name: "agent", Pwd: "agentpwd"},
+			err:      ras.ErrSizeLimit,
+		},
+		{
+			name:     "Error list shorter than size",
+			exchange: "testdata/get_clusters_truncated.txt",
+			cred:     entity.Credentials{Name: "agent", Pwd: "agentpwd"},
+			err:      io.EOF,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := rastest.NewServer(t, tc.exchange)
+
+			clusters, err := New(srv.Addr).GetClusters(context.Background(), tc.cred)
+
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, clusters, tc.count)
+			require.Equal(t, tc.first, clusters[0])
+		})
+	}
+}
+
+func TestGetInfobases(t *testing.T) {
+	cases := []struct {
+		name     string
+		exchange string
+		cred     entity.Credentials
+		result   []entity.Infobase
+		err      error
+	}{
+		{
+			name:     "OK",
+			exchange: "testdata/get_infobases.txt",
+			cred:     clusterCred,
+			result: []entity.Infobase{
+				{ID: testInfobase, Name: "buh", Desc: "Бухгалтерия"},
+				{ID: "0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d", Name: "zup"},
+			},
+		},
+		{
+			name:     "Error cluster auth",
+			exchange: "testdata/get_infobases_cluster_auth.txt",
+			cred:     entity.Credentials{Name: "admin", Pwd: "wrong"},
+			err:      ErrClusterAuth,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := rastest.NewServer(t, tc.exchange)
+
+			infobases, err := New(srv.Addr).GetInfobases(context.Background(), entity.Cluster{ID: testCluster}, tc.cred)
+
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.result, infobases)
+		})
+	}
+}
+
+func TestGetSessions(t *testing.T) {
+	t.Parallel()
+
+	srv := rastest.NewServer(t, "testdata/get_sessions.txt")
+
+	sessions, err := New(srv.Addr).GetSessions(context.Background(),
+		entity.Cluster{ID: testCluster}, entity.Infobase{ID: testInfobase}, clusterCred)
+
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+
+	s := sessions[0]
+
+	require.Equal(t, testSession, s.ID)
+	require.Equal(t, 3, s.SID)
+	require.Equal(t, testInfobase, s.InfobaseID)
+	require.Equal(t, testConnection, s.ConnectionID)
+	require.Equal(t, testProcess, s.ProcessID)
+	require.Equal(t, "Иванов", s.UserName)
+	require.Equal(t, "pc-01", s.Host)
+	require.Equal(t, "1CV8C", s.AppID)
+	require.Equal(t, "ru_RU", s.Loc)
+	require.Equal(t, "no", s.Hibernate)
+	require.Equal(t, time.Date(2023, time.August, 8, 9, 0, 0, 0, entity.ServerLocation), s.Started)
+	require.Equal(t, time.Date(2023, time.August, 8, 10, 30, 0, 0, entity.ServerLocation), s.LastActive)
+	require.Equal(t, 1024, s.Bytes)
+	require.Equal(t, 8, s.Write)
+}
+
+func TestGetConnections(t *testing.T) {
+	t.Parallel()
+
+	srv := rastest.NewServer(t, "testdata/get_connections.txt")
+
+	connections, err := New(srv.Addr).GetConnections(context.Background(), entity.Cluster{ID: testCluster}, entity.Infobase{}, clusterCred)
+
+	require.NoError(t, err)
+	require.Equal(t, []entity.Connection{{
+		ID:         testConnection,
+		CID:        42,
+		InfobaseID: testInfobase,
+		ProcessID:  testProcess,
+		Host:       "pc-01",
+		AppID:      "1CV8C",
+		Connected:  time.Date(2023, time.August, 8, 9, 0, 0, 0, entity.ServerLocation),
+		SID:        3,
+	}}, connections)
+}
+
+func TestGetInfobaseLock(t *testing.T) {
+	t.Parallel()
+
+	srv := rastest.NewServer(t, "testdata/get_infobase_info.txt")
+
+	from := time.Date(2023, time.August, 8, 22, 0, 0, 0, entity.ServerLocation)
+
+	lock, err := New(srv.Addr).GetInfobaseLock(context.Background(),
+		entity.Cluster{ID: testCluster}, entity.Infobase{ID: testInfobase}, clusterCred, infobaseCred)
+
+	require.NoError(t, err)
+	require.Equal(t, entity.InfobaseLock{
+		InfobaseID:   testInfobase,
+		SessionsDeny: true,
+		From:         from,
+		To:           from.Add(time.Hour),
+		Message:      "closed",
+		Code:         "123",
+	}, lock)
+}
+
+func TestRestoreLock(t *testing.T) {
+	cases := []struct {
+		name     string
+		exchange string
+		infobase entity.Infobase
+		err      error
+	}{
+		{
+			name:     "OK",
+			exchange: "testdata/restore_lock.txt",
+			infobase: entity.Infobase{ID: testInfobase},
+		},
+		{
+			name:     "DB password kept",
+			exchange: "testdata/restore_lock_db_pwd.txt",
+			infobase: entity.Infobase{ID: testInfobase},
+		},
+		{
+			name:     "Error infobase is empty",
+			exchange: "testdata/empty.txt",
+			err:      ErrInfobaseIsEmpty,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := rastest.NewServer(t, tc.exchange)
+
+			err := New(srv.Addr).WithChanges().RestoreLock(context.Background(),
+				entity.Cluster{ID: testCluster}, tc.infobase, clusterCred, infobaseCred,
+				entity.InfobaseLock{InfobaseID: testInfobase})
+
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestDeleteSessions(t *testing.T) {
+	t.Parallel()
+
+	srv := rastest.NewServer(t, "testdata/delete_sessions.txt")
+
+	err := New(srv.Addr).WithChanges().DeleteSessions(context.Background(), entity.Cluster{ID: testCluster}, []entity.Session{
+		{ID: testSession},
+		{ID: "66666666-7777-4888-9999-aaaaaaaaaaaa"},
+	}, clusterCred, "bye")
+
+	require.NoError(t, err)
+}
+
+func TestDeleteConnections(t *testing.T) {
+	t.Parallel()
+
+	srv := rastest.NewServer(t, "testdata/delete_connections.txt")
+
+	err := New(srv.Addr).WithChanges().DeleteConnections(context.Background(), entity.Cluster{ID: testCluster}, []entity.Connection{
+		{ID: testConnection, ProcessID: testProcess},
+	}, clusterCred)
+
+	require.NoError(t, err)
+}
+
+func TestNotSupported(t *testing.T) {
+	t.Parallel()
+
+	r := New("localhost:1545")
+
+	_, err := r.GetProcesses(context.Background(), entity.Cluster{}, clusterCred)
+	require.ErrorIs(t, err, ErrNotSupported)
+
+	_, err = r.GetServers(context.Background(), entity.Cluster{}, clusterCred)
+	require.ErrorIs(t, err, ErrNotSupported)
+
+	_, err = r.GetManagers(context.Background(), entity.Cluster{}, clusterCred)
+	require.ErrorIs(t, err, ErrNotSupported)
+}
+
+func TestChangesRefused(t *testing.T) {
+	t.Parallel()
+
+	r := New("localhost:1545")
+
+	err := r.DisableSessions(context.Background(), entity.Cluster{ID: testCluster}, entity.Infobase{ID: testInfobase},
+		clusterCred, infobaseCred, entity.SessionsLock{})
+	require.ErrorIs(t, err, ErrUnverified)
+
+	err = r.RestoreLock(context.Background(), entity.Cluster{ID: testCluster}, entity.Infobase{ID: testInfobase},
+		clusterCred, infobaseCred, entity.InfobaseLock{})
+	require.ErrorIs(t, err, ErrUnverified)
+
+	err = r.DeleteSession(context.Background(), entity.Cluster{ID: testCluster}, entity.Session{ID: testSession}, clusterCred, "bye")
+	require.ErrorIs(t, err, ErrUnverified)
+
+	err = r.DeleteConnections(context.Background(), entity.Cluster{ID: testCluster},
+		[]entity.Connection{{ID: testConnection}}, clusterCred)
+	require.ErrorIs(t, err, ErrNotSupported)
+}
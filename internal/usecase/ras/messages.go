@@ -0,0 +1,289 @@
+package ras
+
+import (
+	"strconv"
+
+	"github.com/antonmisa/1cctl_cli/internal/entity"
+	"github.com/antonmisa/1cctl_cli/pkg/ras"
+)
+
+// types of administration service messages -.
+const (
+	authenticateAgentRequest   byte = 8
+	authenticateClusterRequest byte = 9
+	addAuthenticationRequest   byte = 10
+
+	getClustersRequest  byte = 11
+	getClustersResponse byte = 12
+
+	getInfobasesShortRequest  byte = 42
+	getInfobasesShortResponse byte = 43
+
+	getInfobaseInfoRequest  byte = 48
+	getInfobaseInfoResponse byte = 49
+
+	getConnectionsRequest          byte = 50
+	getConnectionsResponse         byte = 51
+	getInfobaseConnectionsRequest  byte = 52
+	getInfobaseConnectionsResponse byte = 53
+
+	updateInfobaseRequest byte = 57
+	disconnectRequest     byte = 59
+
+	getSessionsRequest          byte = 65
+	getSessionsResponse         byte = 66
+	getInfobaseSessionsRequest  byte = 67
+	getInfobaseSessionsResponse byte = 68
+
+	terminateSessionRequest byte = 71
+)
+
+// load balancing modes as rac names them -.
+var loadBalancingModes = map[int32]string{
+	0: "performance",
+	1: "memory",
+}
+
+// license distribution as rac names it -.
+var licenseDistributions = map[int32]string{
+	0: "deny",
+	1: "allow",
+}
+
+func decodeCluster(d *ras.Decoder) entity.Cluster {
+	var c entity.Cluster
+
+	c.ID = d.UUID()
+	c.Exp = int(d.Int())
+	c.Host = d.String()
+	c.LT = int(d.Int())
+	c.Port = strconv.Itoa(int(d.Short()))
+	c.Name = d.String()
+	c.SecLevel = int(d.Int())
+	c.SesFTLevel = int(d.Int())
+	c.LBMode = loadBalancingModes[d.Int()]
+	c.ErrCountTh = int(d.Int())
+	c.KillPP = int(d.Byte())
+	c.MaxMemSize = int(d.Int())
+	c.MaxMemTimeLim = int(d.Int())
+
+	return c
+}
+
+func decodeInfobase(d *ras.Decoder) entity.Infobase {
+	var ib entity.Infobase
+
+	ib.ID = d.UUID()
+	ib.Desc = d.String()
+	ib.Name = d.String()
+
+	return ib
+}
+
+// infobaseRecord - properties of infobase and db password, which is never shown but written back on update.
+type infobaseRecord struct {
+	info  entity.InfobaseInfo
+	dbPwd string
+}
+
+func decodeInfobaseInfo(d *ras.Decoder) infobaseRecord {
+	var (
+		rec infobaseRecord
+		ib  = &rec.info
+	)
+
+	ib.ID = d.UUID()
+	ib.DateOffset = int(d.Int())
+	ib.DBMS = d.String()
+	ib.DBName = d.String()
+	rec.dbPwd = d.String()
+	ib.DBServer = d.String()
+	ib.DBUser = d.String()
+	ib.DeniedFrom = entity.ServerTime(d.Time())
+	ib.DeniedMessage = d.String()
+	ib.DeniedParameter = d.String()
+	ib.DeniedTo = entity.ServerTime(d.Time())
+	ib.Desc = d.String()
+	ib.Locale = d.String()
+	ib.Name = d.String()
+	ib.PermissionCode = d.String()
+	ib.ScheduledJobsDeny = d.Bool()
+	ib.SecLevel = int(d.Int())
+	ib.SessionsDeny = d.Bool()
+	ib.LicenseDistribution = licenseDistributions[d.Int()]
+	ib.ExtSessionMgrConn = d.String()
+	ib.ExtSessionMgrRequired = d.Bool()
+	ib.SecProfile = d.String()
+	ib.SafeModeSecProfile = d.String()
+	ib.ReserveProcesses = d.Bool()
+
+	return rec
+}
+
+// encodeInfobaseInfo - same layout as decodeInfobaseInfo, db password is sent as it was read.
+func encodeInfobaseInfo(e *ras.Encoder, rec infobaseRecord) error {
+	ib := rec.info
+
+	if err := e.UUID(ib.ID); err != nil {
+		return err
+	}
+
+	e.Int(int32(ib.DateOffset))
+	e.String(ib.DBMS)
+	e.String(ib.DBName)
+	e.String(rec.dbPwd)
+	e.String(ib.DBServer)
+	e.String(ib.DBUser)
+	e.Time(entity.WallClock(ib.DeniedFrom))
+	e.String(ib.DeniedMessage)
+	e.String(ib.DeniedParameter)
+	e.Time(entity.WallClock(ib.DeniedTo))
+	e.String(ib.Desc)
+	e.String(ib.Locale)
+	e.String(ib.Name)
+	e.String(ib.PermissionCode)
+	e.Bool(ib.ScheduledJobsDeny)
+	e.Int(int32(ib.SecLevel))
+	e.Bool(ib.SessionsDeny)
+
+	var lic int32
+
+	for k, v := range licenseDistributions {
+		if v == ib.LicenseDistribution {
+			lic = k
+		}
+	}
+
+	e.Int(lic)
+	e.String(ib.ExtSessionMgrConn)
+	e.Bool(ib.ExtSessionMgrRequired)
+	e.String(ib.SecProfile)
+	e.String(ib.SafeModeSecProfile)
+	e.Bool(ib.ReserveProcesses)
+
+	return nil
+}
+
+func decodeConnection(d *ras.Decoder) entity.Connection {
+	var c entity.Connection
+
+	c.ID = d.UUID()
+	c.AppID = d.String()
+	c.Blocked = int(d.Int())
+	c.Connected = entity.ServerTime(d.Time())
+	c.CID = int(d.Int())
+	c.Host = d.String()
+	c.InfobaseID = d.UUID()
+	c.ProcessID = d.UUID()
+	c.SID = int(d.Int())
+
+	return c
+}
+
+func decodeSession(d *ras.Decoder) entity.Session {
+	var s entity.Session
+
+	s.ID = d.UUID()
+	s.AppID = d.String()
+	s.BlockedDB = int(d.Int())
+	s.BlockedLS = int(d.Int())
+	s.Bytes = int(d.Long())
+	s.Bytes5m = int(d.Long())
+	s.Calls = int(d.Int())
+	s.Calls5m = int(d.Long())
+	s.ConnectionID = d.UUID()
+	s.BytesDB = int(d.Long())
+	s.BytesDB5m = int(d.Long())
+	s.DBProcInfo = d.String()
+	s.DBProc = int(d.Int())
+
+	if at := entity.ServerTime(d.Time()); !at.IsZero() {
+		s.DBProcAt = at.Format("2006-01-02T15:04:05")
+	}
+
+	s.Duration = int(d.Int())
+	s.DurationDB = int(d.Int())
+	s.DurationCur = int(d.Int())
+	s.DurationCurDB = int(d.Int())
+	s.Duration5m = int(d.Long())
+	s.DurationDB5m = int(d.Long())
+	s.Host = d.String()
+	s.InfobaseID = d.UUID()
+	s.LastActive = entity.ServerTime(d.Time())
+	s.Hibernate = yesNo(d.Bool())
+	s.HiberTime = int(d.Int())
+	s.HiberTermTime = int(d.Int())
+
+	for n := d.Size(); n > 0 && d.Err() == nil; n-- {
+		skipLicense(d)
+	}
+
+	s.Loc = d.String()
+	s.ProcessID = d.UUID()
+	s.SID = int(d.Int())
+	s.Started = entity.ServerTime(d.Time())
+	s.UserName = d.String()
+	s.MemoryCur = int(d.Long())
+	s.Memory5m = int(d.Long())
+	s.Memory = int(d.Long())
+	s.ReadCur = int(d.Long())
+	s.Read5m = int(d.Long())
+	s.Read = int(d.Long())
+	s.WriteCur = int(d.Long())
+	s.Write5m = int(d.Long())
+	s.Write = int(d.Long())
+	s.DurationSvcCur = int(d.Int())
+	s.DurationSvc5m = int(d.Long())
+	s.DurationSvc = int(d.Int())
+	s.Svc = d.String()
+	s.CPUCur = int(d.Long())
+	s.CPU5m = int(d.Long())
+	s.CPU = int(d.Long())
+	s.Sep = d.String()
+
+	return s
+}
+
+// decodeList - list prefixed by its size. Memory is taken as items are read, not by size from the wire,
+// so broken size fails on end of data instead of allocation.
+func decodeList[T any](d *ras.Decoder, fn func(d *ras.Decoder) T) []T {
+	const prealloc = 64
+
+	n := d.Size()
+
+	rv := make([]T, 0, prealloc)
+	if n < prealloc {
+		rv = make([]T, 0, n)
+	}
+
+	for i := 0; i < n && d.Err() == nil; i++ {
+		rv = append(rv, fn(d))
+	}
+
+	return rv
+}
+
+// skipLicense - licenses of session are not kept.
+func skipLicense(d *ras.Decoder) {
+	_ = d.String() // file name
+	_ = d.String() // full presentation
+	_ = d.Bool()   // issued by server
+	_ = d.Int()    // license type
+	_ = d.Int()    // max users all
+	_ = d.Int()    // max users current
+	_ = d.Bool()   // network key
+	_ = d.String() // rmngr address
+	_ = d.String() // rmngr pid
+	_ = d.Int()    // rmngr port
+	_ = d.String() // series
+	_ = d.String() // short presentation
+}
+
+// yesNo - rac representation of boolean property.
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+
+	return "no"
+}
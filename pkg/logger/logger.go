@@ -20,6 +20,7 @@ type Interface interface {
 // Logger -.
 type Logger struct {
 	logger *zerolog.Logger
+	redact func(string) string
 }
 
 var _ Interface = (*Logger)(nil)
@@ -61,6 +62,11 @@ func New(path string, level string) (*Logger, error) {
 	}, nil
 }
 
+// Redact - messages are passed through fn before they are written, e.g. to hide passwords.
+func (l *Logger) Redact(fn func(string) string) {
+	l.redact = fn
+}
+
 // Debug -.
 func (l *Logger) Debug(message interface{}, args ...interface{}) {
 	l.msg("debug", message, args...)
@@ -93,11 +99,15 @@ func (l *Logger) Fatal(message interface{}, args ...interface{}) {
 }
 
 func (l *Logger) log(message string, args ...interface{}) {
-	if len(args) == 0 {
-		l.logger.Info().Msg(message)
-	} else {
-		l.logger.Info().Msgf(message, args...)
+	if len(args) > 0 {
+		message = fmt.Sprintf(message, args...)
 	}
+
+	if l.redact != nil {
+		message = l.redact(message)
+	}
+
+	l.logger.Info().Msg(message)
 }
 
 func (l *Logger) msg(level string, message interface{}, args ...interface{}) {
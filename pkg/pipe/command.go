@@ -1,6 +1,12 @@
 package pipe
 
-import "os/exec"
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
 
 //go:generate go run github.com/vektra/mockery/v2@v2.32.0 --all
 
@@ -10,10 +16,52 @@ type Commander interface {
 	Cancel() error
 }
 
+// ExitError - failed command with its error output -.
+type ExitError struct {
+	Err    error
+	Stderr string
+	Code   int // exit code, -1 if command did not exit by itself
+}
+
+func (e *ExitError) Error() string {
+	if e.Stderr == "" {
+		return e.Err.Error()
+	}
+
+	return fmt.Sprintf("%s: %s", e.Err, e.Stderr)
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
 type Command struct {
 	*exec.Cmd
+
+	stderr *bytes.Buffer
 }
 
 func (c *Command) Cancel() error {
 	return c.Cmd.Cancel()
 }
+
+// Wait - waits for command, error output is attached to error.
+func (c *Command) Wait() error {
+	err := c.Cmd.Wait()
+	if err != nil && c.stderr != nil {
+		ee := &ExitError{
+			Err:    err,
+			Stderr: strings.TrimSpace(c.stderr.String()),
+			Code:   -1,
+		}
+
+		var xe *exec.ExitError
+		if errors.As(err, &xe) {
+			ee.Code = xe.ExitCode()
+		}
+
+		return ee
+	}
+
+	return err
+}
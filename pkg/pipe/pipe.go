@@ -1,6 +1,7 @@
 package pipe
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -45,5 +46,10 @@ func (p Pipe) Run(ctx context.Context, arg ...string) (Commander, io.ReadCloser,
 		return nil, nil, err
 	}
 
-	return &Command{cmd}, stdout, nil
+	// rac reports reasons of failures, e.g. authentication, to stderr
+	var stderr bytes.Buffer
+
+	cmd.Stderr = &stderr
+
+	return &Command{Cmd: cmd, stderr: &stderr}, stdout, nil
 }
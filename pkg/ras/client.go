@@ -0,0 +1,324 @@
+// Package ras implements transport of 1C:Enterprise remote administration server (RAS) binary protocol.
+package ras
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	_magic           = 475223888
+	_protocolVersion = 256
+
+	// Service - administration service of cluster, rac 8.3 speaks version 10.0 of it
+	Service        = "v8.service.Admin.Cluster"
+	ServiceVersion = "10.0"
+
+	_connectTimeout = 2000 // ms, sent to RAS in connect parameters
+	_paramLong      = 0x03 // type of connect parameter
+)
+
+// packet types -.
+const (
+	_packetConnect         = 0x01
+	_packetConnectAck      = 0x02
+	_packetDisconnect      = 0x04
+	_packetEndpointOpen    = 0x0B
+	_packetEndpointOpenAck = 0x0C
+	_packetEndpointMessage = 0x0E
+	_packetEndpointFailure = 0x0F
+)
+
+// kinds of endpoint messages -.
+const (
+	_kindVoid      = 0x00
+	_kindMessage   = 0x01
+	_kindException = 0xFF
+)
+
+var (
+	ErrUnexpectedPacket  = errors.New("unexpected packet")
+	ErrUnexpectedMessage = errors.New("unexpected message")
+	ErrEndpointFailure   = errors.New("endpoint failure")
+)
+
+// ServiceError - exception raised by RAS while handling request, e.g. wrong credentials -.
+type ServiceError struct {
+	Message string
+}
+
+func (e *ServiceError) Error() string {
+	return e.Message
+}
+
+// Client - connection to RAS with opened administration endpoint, calls are serialized -.
+type Client struct {
+	mu       sync.Mutex
+	conn     net.Conn
+	r        *bufio.Reader
+	endpoint int
+}
+
+// Dial - connects to RAS at addr (host:port) and opens administration endpoint.
+func Dial(ctx context.Context, addr string) (*Client, error) {
+	var d net.Dialer
+
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("ras - Dial - d.DialContext: %w", err)
+	}
+
+	c := &Client{
+		conn: conn,
+		r:    bufio.NewReader(conn),
+	}
+
+	if err = c.open(ctx); err != nil {
+		conn.Close()
+
+		return nil, fmt.Errorf("ras - Dial - c.open: %w", err)
+	}
+
+	return c, nil
+}
+
+// Close - says goodbye to RAS and closes connection.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_ = c.writePacket(_packetDisconnect, []byte{0}) //nolint:errcheck // connection is closed anyway
+
+	return c.conn.Close()
+}
+
+// Call - sends request of type req and decodes response of type resp, body writes fields of request.
+func (c *Client) Call(ctx context.Context, req byte, body func(e *Encoder) error, resp byte) (*Decoder, error) {
+	d, err := c.call(ctx, req, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if d == nil {
+		return nil, fmt.Errorf("%w: void instead of %d", ErrUnexpectedMessage, resp)
+	}
+
+	if t := d.Byte(); t != resp {
+		return nil, fmt.Errorf("%w: %d instead of %d", ErrUnexpectedMessage, t, resp)
+	}
+
+	return d, nil
+}
+
+// Exec - sends request of type req expecting no data in response.
+func (c *Client) Exec(ctx context.Context, req byte, body func(e *Encoder) error) error {
+	d, err := c.call(ctx, req, body)
+	if err != nil {
+		return err
+	}
+
+	if d != nil {
+		return fmt.Errorf("%w: data instead of void", ErrUnexpectedMessage)
+	}
+
+	return nil
+}
+
+// call - round trip of endpoint message, decoder is nil for void response.
+func (c *Client) call(ctx context.Context, req byte, body func(e *Encoder) error) (*Decoder, error) {
+	var e Encoder
+
+	e.Size(c.endpoint)
+	e.Short(0) // format
+	e.Byte(_kindMessage)
+	e.Byte(req)
+
+	if body != nil {
+		if err := body(&e); err != nil {
+			return nil, err
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.deadline(ctx)
+
+	if err := c.writePacket(_packetEndpointMessage, e.Bytes()); err != nil {
+		return nil, err
+	}
+
+	typ, payload, err := c.readPacket()
+	if err != nil {
+		return nil, err
+	}
+
+	d := NewDecoder(bytes.NewReader(payload))
+
+	switch typ {
+	case _packetEndpointMessage:
+	case _packetEndpointFailure:
+		return nil, failure(d)
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnexpectedPacket, typ)
+	}
+
+	_ = d.Size()  // endpoint
+	_ = d.Short() // format
+	kind := d.Byte()
+
+	if err = d.Err(); err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case _kindVoid:
+		return nil, nil //nolint:nilnil // void response
+	case _kindMessage:
+		return d, nil
+	case _kindException:
+		_ = d.String() // class of exception
+
+		return nil, &ServiceError{Message: d.String()}
+	default:
+		return nil, fmt.Errorf("%w: kind %d", ErrUnexpectedMessage, kind)
+	}
+}
+
+// open - negotiates protocol, connects and opens administration endpoint.
+func (c *Client) open(ctx context.Context) error {
+	c.deadline(ctx)
+
+	var e Encoder
+
+	e.Int(_magic)
+	e.Short(_protocolVersion)
+	e.Short(_protocolVersion)
+
+	if _, err := c.conn.Write(e.Bytes()); err != nil {
+		return err
+	}
+
+	e = Encoder{}
+
+	e.Size(1) // parameters
+	e.String("connect.timeout")
+	e.Byte(_paramLong)
+	e.Long(_connectTimeout)
+
+	if err := c.writePacket(_packetConnect, e.Bytes()); err != nil {
+		return err
+	}
+
+	if err := c.expect(_packetConnectAck); err != nil {
+		return err
+	}
+
+	e = Encoder{}
+
+	e.String(Service)
+	e.String(ServiceVersion)
+
+	if err := c.writePacket(_packetEndpointOpen, e.Bytes()); err != nil {
+		return err
+	}
+
+	typ, payload, err := c.readPacket()
+	if err != nil {
+		return err
+	}
+
+	d := NewDecoder(bytes.NewReader(payload))
+
+	switch typ {
+	case _packetEndpointOpenAck:
+	case _packetEndpointFailure:
+		return failure(d)
+	default:
+		return fmt.Errorf("%w: %d", ErrUnexpectedPacket, typ)
+	}
+
+	_ = d.String() // service
+	_ = d.String() // version
+	c.endpoint = d.Size()
+
+	return d.Err()
+}
+
+// expect - reads packet of given type ignoring its data.
+func (c *Client) expect(typ byte) error {
+	t, _, err := c.readPacket()
+	if err != nil {
+		return err
+	}
+
+	if t != typ {
+		return fmt.Errorf("%w: %d instead of %d", ErrUnexpectedPacket, t, typ)
+	}
+
+	return nil
+}
+
+// deadline - RAS calls honour context deadline only, there is no way to interrupt blocked read otherwise.
+func (c *Client) deadline(ctx context.Context) {
+	t, ok := ctx.Deadline()
+	if !ok {
+		t = time.Time{}
+	}
+
+	_ = c.conn.SetDeadline(t) //nolint:errcheck // fails on closed connection only, read reports it
+}
+
+// writePacket - packet is type, size and data.
+func (c *Client) writePacket(typ byte, data []byte) error {
+	var e Encoder
+
+	e.Byte(typ)
+	e.Size(len(data))
+
+	_, err := c.conn.Write(append(e.Bytes(), data...))
+
+	return err
+}
+
+func (c *Client) readPacket() (byte, []byte, error) {
+	d := NewDecoder(c.r)
+
+	typ := d.Byte()
+	n := d.Size()
+
+	if err := d.Err(); err != nil {
+		return 0, nil, err
+	}
+
+	data := make([]byte, n)
+
+	if _, err := io.ReadFull(c.r, data); err != nil {
+		return 0, nil, err
+	}
+
+	return typ, data, nil
+}
+
+// failure - error of endpoint failure packet.
+func failure(d *Decoder) error {
+	_ = d.String() // service
+	_ = d.String() // version
+	_ = d.Size()   // endpoint
+	_ = d.String() // class of cause
+
+	msg := d.String()
+
+	if err := d.Err(); err != nil {
+		return fmt.Errorf("%w: %w", ErrEndpointFailure, err)
+	}
+
+	return fmt.Errorf("%w: %s", ErrEndpointFailure, msg)
+}
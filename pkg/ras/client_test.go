@@ -0,0 +1,53 @@
+package ras
+
+import (
+	"context"
+	"testing"
+
+	"github.com/antonmisa/1cctl_cli/pkg/ras/rastest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+
+	srv := rastest.NewServer(t, "testdata/exec.txt")
+	ctx := context.Background()
+
+	c, err := Dial(ctx, srv.Addr)
+	require.NoError(t, err)
+
+	err = c.Exec(ctx, 8, func(e *Encoder) error {
+		e.String("user")
+		e.String("pwd")
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	d, err := c.Call(ctx, 11, nil, 12)
+	require.NoError(t, err)
+	require.Equal(t, 300, d.Size())
+
+	_, err = c.Call(ctx, 11, nil, 12)
+
+	var se *ServiceError
+
+	require.ErrorAs(t, err, &se)
+	require.Equal(t, "Недостаточно прав", se.Message)
+
+	_, err = c.Call(ctx, 11, nil, 12)
+	require.ErrorIs(t, err, ErrUnexpectedMessage)
+
+	require.NoError(t, c.Close())
+}
+
+func TestDialEndpointFailure(t *testing.T) {
+	t.Parallel()
+
+	srv := rastest.NewServer(t, "testdata/endpoint_failure.txt")
+
+	_, err := Dial(context.Background(), srv.Addr)
+	require.ErrorIs(t, err, ErrEndpointFailure)
+	require.ErrorContains(t, err, "service is not found")
+}
@@ -0,0 +1,246 @@
+package ras
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	_sizeNull = 0x80 // first byte of size: value is null
+	_sizeNext = 0x40 // first byte of size: more bytes follow
+	_sizeLow  = 0x3F // first byte of size: low bits of value
+	_moreNext = 0x80 // next bytes of size: more bytes follow
+	_moreLow  = 0x7F // next bytes of size: bits of value
+
+	_uuidSize = 16
+
+	// MaxSize - limit of size read from RAS, larger one is taken for broken or hostile stream
+	MaxSize = 32 << 20
+
+	// _ageDelta - 0001-01-01 in unix milliseconds multiplied by 10, RAS time is 1/10 ms since it
+	_ageDelta = 621355968000000
+)
+
+var (
+	ErrInvalidUUID = errors.New("invalid uuid")
+	ErrSizeLimit   = errors.New("size exceeds limit")
+)
+
+// Encoder - writes values in RAS wire format -.
+type Encoder struct {
+	buf bytes.Buffer
+}
+
+// Bytes - encoded data.
+func (e *Encoder) Bytes() []byte {
+	return e.buf.Bytes()
+}
+
+func (e *Encoder) Byte(v byte) {
+	e.buf.WriteByte(v)
+}
+
+func (e *Encoder) Bool(v bool) {
+	if v {
+		e.buf.WriteByte(1)
+	} else {
+		e.buf.WriteByte(0)
+	}
+}
+
+func (e *Encoder) Short(v int16) {
+	_ = binary.Write(&e.buf, binary.BigEndian, v) //nolint:errcheck // bytes.Buffer does not fail
+}
+
+func (e *Encoder) Int(v int32) {
+	_ = binary.Write(&e.buf, binary.BigEndian, v) //nolint:errcheck // bytes.Buffer does not fail
+}
+
+func (e *Encoder) Long(v int64) {
+	_ = binary.Write(&e.buf, binary.BigEndian, v) //nolint:errcheck // bytes.Buffer does not fail
+}
+
+func (e *Encoder) Double(v float64) {
+	e.Long(int64(math.Float64bits(v)))
+}
+
+// Size - variable length unsigned value: 6 bits in first byte, 7 bits in each next one.
+func (e *Encoder) Size(v int) {
+	b := byte(v & _sizeLow)
+	v >>= 6
+
+	if v > 0 {
+		b |= _sizeNext
+	}
+
+	e.buf.WriteByte(b)
+
+	for v > 0 {
+		b = byte(v & _moreLow)
+		v >>= 7
+
+		if v > 0 {
+			b |= _moreNext
+		}
+
+		e.buf.WriteByte(b)
+	}
+}
+
+// String - size prefixed UTF-8.
+func (e *Encoder) String(v string) {
+	e.Size(len(v))
+	e.buf.WriteString(v)
+}
+
+// UUID - 16 bytes, empty string is nil UUID.
+func (e *Encoder) UUID(v string) error {
+	if v == "" {
+		e.buf.Write(make([]byte, _uuidSize))
+
+		return nil
+	}
+
+	b, err := hex.DecodeString(strings.ReplaceAll(v, "-", ""))
+	if err != nil || len(b) != _uuidSize {
+		return fmt.Errorf("%w: %q", ErrInvalidUUID, v)
+	}
+
+	e.buf.Write(b)
+
+	return nil
+}
+
+// Time - 1/10 ms since 0001-01-01, zero time is 0.
+func (e *Encoder) Time(v time.Time) {
+	if v.IsZero() {
+		e.Long(0)
+
+		return
+	}
+
+	e.Long(v.UnixMilli()*10 + _ageDelta) //nolint:gomnd // 1/10 ms
+}
+
+// Decoder - reads values in RAS wire format, first error sticks and is returned by Err -.
+type Decoder struct {
+	r   io.Reader
+	err error
+}
+
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r}
+}
+
+// Err - first error of reading.
+func (d *Decoder) Err() error {
+	return d.err
+}
+
+func (d *Decoder) read(n int) []byte {
+	b := make([]byte, n)
+
+	if d.err != nil {
+		return b
+	}
+
+	if _, err := io.ReadFull(d.r, b); err != nil {
+		d.err = err
+	}
+
+	return b
+}
+
+func (d *Decoder) Byte() byte {
+	return d.read(1)[0]
+}
+
+func (d *Decoder) Bool() bool {
+	return d.Byte() != 0
+}
+
+func (d *Decoder) Short() int16 {
+	return int16(binary.BigEndian.Uint16(d.read(2))) //nolint:gomnd // size of short
+}
+
+func (d *Decoder) Int() int32 {
+	return int32(binary.BigEndian.Uint32(d.read(4))) //nolint:gomnd // size of int
+}
+
+func (d *Decoder) Long() int64 {
+	return int64(binary.BigEndian.Uint64(d.read(8))) //nolint:gomnd // size of long
+}
+
+func (d *Decoder) Double() float64 {
+	return math.Float64frombits(uint64(d.Long()))
+}
+
+// Size - see Encoder.Size, null is 0. Value over MaxSize is decode error and 0 is returned.
+func (d *Decoder) Size() int {
+	b := d.Byte()
+	if b&_sizeNull != 0 {
+		return 0
+	}
+
+	v := int(b & _sizeLow)
+
+	if b&_sizeNext == 0 {
+		return v
+	}
+
+	for shift := 6; d.err == nil; shift += 7 {
+		b = d.Byte()
+		v |= int(b&_moreLow) << shift
+
+		if v > MaxSize || shift > 32 {
+			d.err = fmt.Errorf("%w: %d", ErrSizeLimit, v)
+
+			return 0
+		}
+
+		if b&_moreNext == 0 {
+			break
+		}
+	}
+
+	return v
+}
+
+func (d *Decoder) String() string {
+	n := d.Size()
+	if n == 0 {
+		return ""
+	}
+
+	return string(d.read(n))
+}
+
+// UUID - in canonical form, nil UUID is empty string.
+func (d *Decoder) UUID() string {
+	b := d.read(_uuidSize)
+
+	if bytes.Equal(b, make([]byte, _uuidSize)) {
+		return ""
+	}
+
+	h := hex.EncodeToString(b)
+
+	return h[:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
+
+// Time - see Encoder.Time, 0 is zero time.
+func (d *Decoder) Time() time.Time {
+	v := d.Long()
+	if v == 0 {
+		return time.Time{}
+	}
+
+	return time.UnixMilli((v - _ageDelta) / 10).UTC() //nolint:gomnd // 1/10 ms
+}
@@ -0,0 +1,111 @@
+package ras
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSize(t *testing.T) {
+	cases := []struct {
+		name  string
+		value int
+		raw   []byte
+	}{
+		{name: "Zero", value: 0, raw: []byte{0x00}},
+		{name: "One byte", value: 63, raw: []byte{0x3F}},
+		{name: "Two bytes", value: 64, raw: []byte{0x40, 0x01}},
+		{name: "Two bytes max", value: 8191, raw: []byte{0x7F, 0x7F}},
+		{name: "Three bytes", value: 8192, raw: []byte{0x40, 0x80, 0x01}},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var e Encoder
+
+			e.Size(tc.value)
+			require.Equal(t, tc.raw, e.Bytes())
+
+			d := NewDecoder(bytes.NewReader(tc.raw))
+			require.Equal(t, tc.value, d.Size())
+			require.NoError(t, d.Err())
+		})
+	}
+}
+
+func TestSizeLimit(t *testing.T) {
+	cases := []struct {
+		name string
+		raw  []byte
+	}{
+		{name: "Over limit", raw: []byte{0x40, 0x80, 0x80, 0x80, 0x01}},
+		{name: "Endless", raw: []byte{0x40, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x00}},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := NewDecoder(bytes.NewReader(tc.raw))
+
+			require.Equal(t, 0, d.Size())
+			require.ErrorIs(t, d.Err(), ErrSizeLimit)
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2023, time.August, 8, 22, 0, 0, 0, time.UTC)
+
+	var e Encoder
+
+	e.Byte(7)
+	e.Bool(true)
+	e.Short(-2)
+	e.Int(1541)
+	e.Long(1 << 40)
+	e.Double(0.5)
+	e.String("Бухгалтерия")
+	e.String("")
+	require.NoError(t, e.UUID("6f3c2b1a-0e9d-4c8b-a7f6-5e4d3c2b1a00"))
+	require.NoError(t, e.UUID(""))
+	e.Time(at)
+	e.Time(time.Time{})
+
+	d := NewDecoder(bytes.NewReader(e.Bytes()))
+
+	require.Equal(t, byte(7), d.Byte())
+	require.True(t, d.Bool())
+	require.Equal(t, int16(-2), d.Short())
+	require.Equal(t, int32(1541), d.Int())
+	require.Equal(t, int64(1<<40), d.Long())
+	require.Equal(t, 0.5, d.Double())
+	require.Equal(t, "Бухгалтерия", d.String())
+	require.Equal(t, "", d.String())
+	require.Equal(t, "6f3c2b1a-0e9d-4c8b-a7f6-5e4d3c2b1a00", d.UUID())
+	require.Equal(t, "", d.UUID())
+	require.Equal(t, at, d.Time())
+	require.True(t, d.Time().IsZero())
+	require.NoError(t, d.Err())
+
+	_ = d.Byte()
+	require.Error(t, d.Err())
+}
+
+func TestUUIDInvalid(t *testing.T) {
+	t.Parallel()
+
+	var e Encoder
+
+	require.ErrorIs(t, e.UUID("1111-2222"), ErrInvalidUUID)
+}
@@ -0,0 +1,161 @@
+// Package rastest provides fake RAS replaying recorded exchanges, for tests of RAS clients.
+package rastest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"testing"
+)
+
+// step - bytes expected from client (in) or sent to it (out).
+type step struct {
+	in   bool
+	data []byte
+	line int
+}
+
+// Server - listens on loopback and replays exchange recorded in file:
+//
+//	# comment
+//	> bytes sent by client, hex
+//	< bytes answered by RAS, hex
+//
+// Spaces inside hex are ignored. Exchange may span several connections, next one is accepted
+// when client closes previous one. Mismatch of client bytes fails test -.
+type Server struct {
+	Addr string
+
+	t     testing.TB
+	ln    net.Listener
+	steps []step
+	done  chan struct{}
+}
+
+// NewServer - starts server replaying file at path, it's stopped on test cleanup.
+func NewServer(t testing.TB, path string) *Server {
+	t.Helper()
+
+	steps, err := load(path)
+	if err != nil {
+		t.Fatalf("rastest - load %s: %s", path, err)
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("rastest - net.Listen: %s", err)
+	}
+
+	s := &Server{
+		Addr:  ln.Addr().String(),
+		t:     t,
+		ln:    ln,
+		steps: steps,
+		done:  make(chan struct{}),
+	}
+
+	go s.serve()
+
+	t.Cleanup(func() {
+		ln.Close()
+		<-s.done
+	})
+
+	return s
+}
+
+func (s *Server) serve() {
+	defer close(s.done)
+
+	i := 0
+
+	for i < len(s.steps) {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			s.t.Errorf("rastest - exchange is not finished, step at line %d", s.steps[i].line)
+
+			return
+		}
+
+		i = s.play(conn, i)
+
+		conn.Close()
+	}
+}
+
+// play - replays steps from i on conn until client closes it, returns next step.
+func (s *Server) play(conn net.Conn, i int) int {
+	r := bufio.NewReader(conn)
+
+	for ; i < len(s.steps); i++ {
+		st := s.steps[i]
+
+		if !st.in {
+			if _, err := conn.Write(st.data); err != nil {
+				s.t.Errorf("rastest - write at line %d: %s", st.line, err)
+			}
+
+			continue
+		}
+
+		got := make([]byte, len(st.data))
+
+		n, err := io.ReadFull(r, got)
+		if n == 0 && errors.Is(err, io.EOF) {
+			return i
+		}
+
+		if err != nil || !bytes.Equal(got, st.data) {
+			s.t.Errorf("rastest - line %d: expected %x, got %x (%v)", st.line, st.data, got[:n], err)
+
+			return len(s.steps)
+		}
+	}
+
+	return i
+}
+
+func load(path string) ([]step, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var (
+		steps []step
+		line  int
+	)
+
+	sc := bufio.NewScanner(f)
+
+	for sc.Scan() {
+		line++
+
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		data, err := hex.DecodeString(strings.Join(strings.Fields(text[1:]), ""))
+		if err != nil {
+			return nil, err
+		}
+
+		switch text[0] {
+		case '>':
+			steps = append(steps, step{in: true, data: data, line: line})
+		case '<':
+			steps = append(steps, step{in: false, data: data, line: line})
+		default:
+			return nil, errors.New("line must start with > or <")
+		}
+	}
+
+	return steps, sc.Err()
+}
@@ -0,0 +1,264 @@
+// Package secrets keeps named secrets, e.g. passwords, in file encrypted by AES-256-GCM.
+// Config values refer to them as secret://name, names are slash separated like prod/buh/infobase_pwd.
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// RefPrefix - prefix of reference to secret in config values
+	RefPrefix = "secret://"
+
+	// EnvKey, EnvKeyFile - key itself or path to file with it, key is base64 of 32 bytes
+	EnvKey     = "SECRETS_KEY"
+	EnvKeyFile = "SECRETS_KEY_FILE"
+
+	_header  = "1cctl-secrets/1"
+	_keySize = 32
+)
+
+var (
+	ErrNoKey         = errors.New("key is not given, set " + EnvKey + " or " + EnvKeyFile)
+	ErrInvalidKey    = errors.New("key must be base64 of 32 bytes")
+	ErrInvalidName   = errors.New("secret name must be slash separated words of letters, digits, '_', '-' and '.'")
+	ErrUnknownSecret = errors.New("secret is not found")
+	ErrCorrupted     = errors.New("secrets file is corrupted or key is wrong")
+)
+
+var _name = regexp.MustCompile(`^[A-Za-z0-9_.-]+(/[A-Za-z0-9_.-]+)*$`)
+
+// Key - AES-256 key -.
+type Key [_keySize]byte
+
+// NewKey - random key.
+func NewKey() (Key, error) {
+	var k Key
+
+	if _, err := io.ReadFull(rand.Reader, k[:]); err != nil {
+		return Key{}, fmt.Errorf("secrets - NewKey - rand.Read: %w", err)
+	}
+
+	return k, nil
+}
+
+// ParseKey - key of its base64 text.
+func ParseKey(s string) (Key, error) {
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil || len(b) != _keySize {
+		return Key{}, ErrInvalidKey
+	}
+
+	var k Key
+
+	copy(k[:], b)
+
+	return k, nil
+}
+
+// String - base64 text of key.
+func (k Key) String() string {
+	return base64.StdEncoding.EncodeToString(k[:])
+}
+
+// LoadKey - key of env SECRETS_KEY, otherwise of file given by env SECRETS_KEY_FILE or keyFile.
+func LoadKey(keyFile string) (Key, error) {
+	if s := os.Getenv(EnvKey); s != "" {
+		return ParseKey(s)
+	}
+
+	if s := os.Getenv(EnvKeyFile); s != "" {
+		keyFile = s
+	}
+
+	if keyFile == "" {
+		return Key{}, ErrNoKey
+	}
+
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return Key{}, fmt.Errorf("secrets - LoadKey - os.ReadFile: %w", err)
+	}
+
+	return ParseKey(string(data))
+}
+
+// Store - decrypted secrets of file, changes are written by Save -.
+type Store struct {
+	path   string
+	key    Key
+	values map[string]string
+}
+
+// New - empty store to be saved at path.
+func New(path string, key Key) *Store {
+	return &Store{
+		path:   path,
+		key:    key,
+		values: make(map[string]string),
+	}
+}
+
+// Open - store of existing file.
+func Open(path string, key Key) (*Store, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("secrets - Open - os.ReadFile: %w", err)
+	}
+
+	header, body, ok := strings.Cut(string(data), "\n")
+	if !ok || header != _header {
+		return nil, fmt.Errorf("secrets - Open - %s: %w", path, ErrCorrupted)
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(body))
+	if err != nil {
+		return nil, fmt.Errorf("secrets - Open - %s: %w", path, ErrCorrupted)
+	}
+
+	gcm, err := key.aead()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return nil, fmt.Errorf("secrets - Open - %s: %w", path, ErrCorrupted)
+	}
+
+	plain, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], []byte(_header))
+	if err != nil {
+		return nil, fmt.Errorf("secrets - Open - %s: %w", path, ErrCorrupted)
+	}
+
+	s := New(path, key)
+
+	if err = json.Unmarshal(plain, &s.values); err != nil {
+		return nil, fmt.Errorf("secrets - Open - json.Unmarshal: %w", err)
+	}
+
+	return s, nil
+}
+
+// Get - secret by name.
+func (s *Store) Get(name string) (string, error) {
+	v, ok := s.values[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownSecret, name)
+	}
+
+	return v, nil
+}
+
+// Set - adds or replaces secret.
+func (s *Store) Set(name, value string) error {
+	if !_name.MatchString(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidName, name)
+	}
+
+	s.values[name] = value
+
+	return nil
+}
+
+// Delete - removes secret, tells whether it was there.
+func (s *Store) Delete(name string) bool {
+	_, ok := s.values[name]
+
+	delete(s.values, name)
+
+	return ok
+}
+
+// Names - sorted names of secrets.
+func (s *Store) Names() []string {
+	rv := make([]string, 0, len(s.values))
+
+	for name := range s.values {
+		rv = append(rv, name)
+	}
+
+	sort.Strings(rv)
+
+	return rv
+}
+
+// Resolve - secret of reference secret://name.
+func (s *Store) Resolve(ref string) (string, error) {
+	return s.Get(strings.TrimPrefix(ref, RefPrefix))
+}
+
+// Save - encrypts secrets with fresh nonce and replaces file atomically, file is readable by owner only.
+func (s *Store) Save() error {
+	plain, err := json.Marshal(s.values)
+	if err != nil {
+		return fmt.Errorf("secrets - Save - json.Marshal: %w", err)
+	}
+
+	gcm, err := s.key.aead()
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return fmt.Errorf("secrets - Save - rand.Read: %w", err)
+	}
+
+	sealed := gcm.Seal(nonce, nonce, plain, []byte(_header))
+	data := _header + "\n" + base64.StdEncoding.EncodeToString(sealed) + "\n"
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("secrets - Save - os.CreateTemp: %w", err)
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.WriteString(data); err != nil {
+		tmp.Close()
+
+		return fmt.Errorf("secrets - Save - tmp.WriteString: %w", err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("secrets - Save - tmp.Close: %w", err)
+	}
+
+	if err = os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("secrets - Save - os.Rename: %w", err)
+	}
+
+	return nil
+}
+
+// IsRef - whether value refers to secret.
+func IsRef(v string) bool {
+	return strings.HasPrefix(v, RefPrefix)
+}
+
+func (k Key) aead() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(k[:])
+	if err != nil {
+		return nil, fmt.Errorf("secrets - aes.NewCipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("secrets - cipher.NewGCM: %w", err)
+	}
+
+	return gcm, nil
+}
@@ -0,0 +1,143 @@
+package secrets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreRoundTrip(t *testing.T) {
+	key, err := NewKey()
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "secrets.enc")
+
+	s := New(path, key)
+	require.NoError(t, s.Set("prod/buh/infobase_pwd", "p@ss \"word\""))
+	require.NoError(t, s.Set("prod/cluster_pwd", "admin-pwd"))
+	require.NoError(t, s.Save())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "p@ss")
+	require.NotContains(t, string(data), "prod/buh")
+
+	fi, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Zero(t, fi.Mode().Perm()&0o077)
+
+	s, err = Open(path, key)
+	require.NoError(t, err)
+	require.Equal(t, []string{"prod/buh/infobase_pwd", "prod/cluster_pwd"}, s.Names())
+
+	v, err := s.Resolve("secret://prod/buh/infobase_pwd")
+	require.NoError(t, err)
+	require.Equal(t, "p@ss \"word\"", v)
+
+	require.True(t, s.Delete("prod/cluster_pwd"))
+	require.False(t, s.Delete("prod/cluster_pwd"))
+
+	_, err = s.Get("prod/cluster_pwd")
+	require.ErrorIs(t, err, ErrUnknownSecret)
+}
+
+func TestOpenErrors(t *testing.T) {
+	key, err := NewKey()
+	require.NoError(t, err)
+
+	other, err := NewKey()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "secrets.enc")
+
+	s := New(path, key)
+	require.NoError(t, s.Set("pwd", "secret"))
+	require.NoError(t, s.Save())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	tampered := []byte(string(data))
+	tampered[len(_header)+5] ^= 1
+
+	cases := []struct {
+		name string
+		data []byte
+		key  Key
+	}{
+		{
+			name: "Wrong key",
+			data: data,
+			key:  other,
+		},
+		{
+			name: "Tampered",
+			data: tampered,
+			key:  key,
+		},
+		{
+			name: "No header",
+			data: []byte("pwd: secret\n"),
+			key:  key,
+		},
+		{
+			name: "Truncated",
+			data: []byte(_header + "\nAAAA\n"),
+			key:  key,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := filepath.Join(dir, tc.name)
+			require.NoError(t, os.WriteFile(p, tc.data, 0o600))
+
+			_, err := Open(p, tc.key)
+			require.ErrorIs(t, err, ErrCorrupted)
+		})
+	}
+}
+
+func TestSetInvalidName(t *testing.T) {
+	s := New("secrets.enc", Key{})
+
+	for _, name := range []string{"", "/prod", "prod/", "prod//buh", "prod buh", "secret://prod"} {
+		require.ErrorIs(t, s.Set(name, "v"), ErrInvalidName, name)
+	}
+}
+
+func TestLoadKey(t *testing.T) {
+	key, err := NewKey()
+	require.NoError(t, err)
+
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(key.String()+"\n"), 0o600))
+
+	t.Setenv(EnvKey, "")
+	t.Setenv(EnvKeyFile, "")
+
+	_, err = LoadKey("")
+	require.ErrorIs(t, err, ErrNoKey)
+
+	got, err := LoadKey(keyFile)
+	require.NoError(t, err)
+	require.Equal(t, key, got)
+
+	t.Setenv(EnvKey, "short")
+
+	_, err = LoadKey(keyFile)
+	require.ErrorIs(t, err, ErrInvalidKey)
+
+	t.Setenv(EnvKey, key.String())
+
+	got, err = LoadKey("")
+	require.NoError(t, err)
+	require.Equal(t, key, got)
+}